@@ -47,6 +47,7 @@ func GeneratePages(ctx context.Context) {
 		pageContent = &models.PageContentModel{
 			UID:     pageId,
 			Content: lipsumMarkdown()}
+		page.Analyze(pageContent.Render())
 		if err = customMongo.Transaction(ctx, dbConn, false,
 			func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
 				if sErr = repositories.SaveOnePage(dbConn, sCtx, page); sErr != nil {
@@ -53,6 +53,7 @@ func GeneratePosts(ctx context.Context) {
 		postContent = &models.PostContentModel{
 			UID:     postId,
 			Content: lipsumMarkdown()}
+		post.Analyze(postContent.Render())
 		if err = customMongo.Transaction(ctx, dbConn, false,
 			func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
 				if sErr = repositories.SaveOnePost(
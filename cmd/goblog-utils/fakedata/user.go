@@ -42,9 +42,10 @@ func GenerateUsers(ctx context.Context) {
 				Level: 3,
 				Name:  "Writer",
 				Since: now}},
-			CreatedAt: now,
-			UpdatedAt: now,
-			DeletedAt: nil,
+			VerifiedAt: now,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			DeletedAt:  nil,
 		}
 		if err = customMongo.Transaction(ctx, dbConn, false,
 			func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
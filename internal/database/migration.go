@@ -44,6 +44,27 @@ func getMigrations() (migrationInstances []MigrationInterface) {
 		new(migrations.CreateNotificationCollection),
 		new(migrations.CreateRevokedTokenCollection),
 		new(migrations.CreatePagesCollection),
+		new(migrations.CreateEmailVerificationsCollection),
+		new(migrations.CreatePasswordResetsCollection),
+		new(migrations.IndexRevokedTokenFamilies),
+		new(migrations.CreateSessionsCollection),
+		new(migrations.CreateLoginAttemptsCollection),
+		new(migrations.CreatePersonalTokensCollection),
+		new(migrations.CreateOidcStatesCollection),
+		new(migrations.CreateAuditLogsCollection),
+		new(migrations.CreateRolesCollection),
+		new(migrations.CreateDataExportsCollection),
+		new(migrations.CreateInvitationsCollection),
+		new(migrations.CreateRevisionsCollections),
+		new(migrations.IndexPublishSchedules),
+		new(migrations.GrantPostReviewPermission),
+		new(migrations.IndexPostContributors),
+		new(migrations.CreateSeriesCollection),
+		new(migrations.GrantSeriesPermissions),
+		new(migrations.CreateRelatedPostsCollection),
+		new(migrations.RenderContents),
+		new(migrations.AnalyzeContents),
+		new(migrations.GrantAdminCommentPermissions),
 	}
 }
 
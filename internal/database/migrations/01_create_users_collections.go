@@ -11,7 +11,7 @@ import (
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
 	"github.com/misterabdul/goblog-server/internal/database/repositories"
-	"github.com/misterabdul/goblog-server/pkg/hash"
+	"github.com/misterabdul/goblog-server/internal/pkg/hash"
 )
 
 const usersCollectionName = "users"
@@ -90,8 +90,9 @@ func insertSuperAdmin(ctx context.Context, dbConn *mongo.Database) (err error) {
 			Name:  "Writer",
 			Since: now,
 		}},
-		CreatedAt: now,
-		UpdatedAt: now,
+		VerifiedAt: now,
+		CreatedAt:  now,
+		UpdatedAt:  now,
 	}
 
 	return repositories.SaveOneUser(dbConn, ctx, &superAdmin)
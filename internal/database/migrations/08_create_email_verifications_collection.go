@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const emailVerificationCollectionName = "emailVerifications"
+
+// Create the email verifications collection.
+// Mark all existing users as verified.
+type CreateEmailVerificationsCollection struct{}
+
+func (m *CreateEmailVerificationsCollection) Name() (collectionName string) {
+	return "08_create_email_verifications_collections"
+}
+
+func (m *CreateEmailVerificationsCollection) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if err = dbConn.CreateCollection(ctx, emailVerificationCollectionName); err != nil {
+		return err
+	}
+	indexes := []mongo.IndexModel{{
+		Keys:    bson.D{{Key: "tokenhash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}, {
+		Keys:    bson.D{{Key: "owner._id", Value: 1}},
+		Options: nil,
+	}, {
+		Keys:    bson.D{{Key: "expiresat", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}}
+	if _, err = dbConn.Collection(emailVerificationCollectionName).Indexes().
+		CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+	if _, err = dbConn.Collection(usersCollectionName).UpdateMany(ctx,
+		bson.M{"verifiedat": bson.M{"$exists": false}},
+		bson.A{bson.M{"$set": bson.M{"verifiedat": "$createdat"}}},
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *CreateEmailVerificationsCollection) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	return dbConn.Collection(emailVerificationCollectionName).Drop(ctx)
+}
@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	passwordResetCollectionName       = "passwordResets"
+	revokedTokenOwnerIssuedBeforeName = "owner._id_1_issuedbefore_-1"
+)
+
+// Create the password resets collection.
+// Index the owner-wide revoked tokens.
+type CreatePasswordResetsCollection struct{}
+
+func (m *CreatePasswordResetsCollection) Name() (collectionName string) {
+	return "09_create_password_resets_collections"
+}
+
+func (m *CreatePasswordResetsCollection) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if err = dbConn.CreateCollection(ctx, passwordResetCollectionName); err != nil {
+		return err
+	}
+	indexes := []mongo.IndexModel{{
+		Keys:    bson.D{{Key: "tokenhash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}, {
+		Keys:    bson.D{{Key: "owner._id", Value: 1}},
+		Options: nil,
+	}, {
+		Keys:    bson.D{{Key: "expiresat", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}}
+	if _, err = dbConn.Collection(passwordResetCollectionName).Indexes().
+		CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+	if _, err = dbConn.Collection(revokedTokenCollectionName).Indexes().
+		CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "owner._id", Value: 1},
+				{Key: "issuedbefore", Value: -1}},
+			Options: options.Index().SetName(revokedTokenOwnerIssuedBeforeName),
+		}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *CreatePasswordResetsCollection) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if _, err = dbConn.Collection(revokedTokenCollectionName).Indexes().
+		DropOne(ctx, revokedTokenOwnerIssuedBeforeName); err != nil {
+		return err
+	}
+
+	return dbConn.Collection(passwordResetCollectionName).Drop(ctx)
+}
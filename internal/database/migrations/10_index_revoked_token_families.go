@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	revokedTokenRevokedFamilyName = "revokedfamily_1"
+	revokedTokenExpiresAtTTLName  = "expiresat_ttl"
+)
+
+// Index the revoked token families.
+// Expire the revoked tokens, as every refresh now leaves one behind.
+type IndexRevokedTokenFamilies struct{}
+
+func (m *IndexRevokedTokenFamilies) Name() (collectionName string) {
+	return "10_index_revoked_token_families"
+}
+
+func (m *IndexRevokedTokenFamilies) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	indexes := []mongo.IndexModel{{
+		Keys:    bson.D{{Key: "revokedfamily", Value: 1}},
+		Options: options.Index().SetName(revokedTokenRevokedFamilyName),
+	}, {
+		Keys: bson.D{{Key: "expiresat", Value: 1}},
+		Options: options.Index().
+			SetName(revokedTokenExpiresAtTTLName).
+			SetExpireAfterSeconds(0),
+	}}
+	if _, err = dbConn.Collection(revokedTokenCollectionName).Indexes().
+		CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IndexRevokedTokenFamilies) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if _, err = dbConn.Collection(revokedTokenCollectionName).Indexes().
+		DropOne(ctx, revokedTokenExpiresAtTTLName); err != nil {
+		return err
+	}
+	if _, err = dbConn.Collection(revokedTokenCollectionName).Indexes().
+		DropOne(ctx, revokedTokenRevokedFamilyName); err != nil {
+		return err
+	}
+
+	return nil
+}
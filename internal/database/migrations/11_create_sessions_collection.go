@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const sessionCollectionName = "sessions"
+
+// Create the sessions collection.
+type CreateSessionsCollection struct{}
+
+func (m *CreateSessionsCollection) Name() (collectionName string) {
+	return "11_create_sessions_collections"
+}
+
+func (m *CreateSessionsCollection) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if err = dbConn.CreateCollection(ctx, sessionCollectionName); err != nil {
+		return err
+	}
+	indexes := []mongo.IndexModel{{
+		Keys: bson.D{
+			{Key: "owner._id", Value: 1},
+			{Key: "lastusedat", Value: -1}},
+		Options: nil,
+	}, {
+		Keys:    bson.D{{Key: "expiresat", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}}
+	if _, err = dbConn.Collection(sessionCollectionName).Indexes().
+		CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *CreateSessionsCollection) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	return dbConn.Collection(sessionCollectionName).Drop(ctx)
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const loginAttemptCollectionName = "loginAttempts"
+
+// Create the login attempts collection.
+type CreateLoginAttemptsCollection struct{}
+
+func (m *CreateLoginAttemptsCollection) Name() (collectionName string) {
+	return "12_create_login_attempts_collection"
+}
+
+func (m *CreateLoginAttemptsCollection) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if err = dbConn.CreateCollection(ctx, loginAttemptCollectionName); err != nil {
+		return err
+	}
+	indexes := []mongo.IndexModel{{
+		Keys:    bson.D{{Key: "key", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}, {
+		Keys:    bson.D{{Key: "lockeduntil", Value: -1}},
+		Options: nil,
+	}, {
+		Keys:    bson.D{{Key: "expiresat", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}}
+	if _, err = dbConn.Collection(loginAttemptCollectionName).Indexes().
+		CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *CreateLoginAttemptsCollection) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	return dbConn.Collection(loginAttemptCollectionName).Drop(ctx)
+}
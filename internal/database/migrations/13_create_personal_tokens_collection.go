@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const personalTokenCollectionName = "personalTokens"
+
+// Create the personal tokens collection.
+type CreatePersonalTokensCollection struct{}
+
+func (m *CreatePersonalTokensCollection) Name() (collectionName string) {
+	return "13_create_personal_tokens_collection"
+}
+
+func (m *CreatePersonalTokensCollection) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if err = dbConn.CreateCollection(ctx, personalTokenCollectionName); err != nil {
+		return err
+	}
+	indexes := []mongo.IndexModel{{
+		Keys:    bson.D{{Key: "tokenhash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}, {
+		Keys: bson.D{
+			{Key: "owner._id", Value: 1},
+			{Key: "createdat", Value: -1}},
+		Options: nil,
+	}, {
+		Keys:    bson.D{{Key: "expiresat", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}}
+	if _, err = dbConn.Collection(personalTokenCollectionName).Indexes().
+		CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *CreatePersonalTokensCollection) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	return dbConn.Collection(personalTokenCollectionName).Drop(ctx)
+}
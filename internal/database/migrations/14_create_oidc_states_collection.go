@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const oidcStateCollectionName = "oidcStates"
+
+// Create the OIDC states collection & index the users' external identities.
+type CreateOidcStatesCollection struct{}
+
+func (m *CreateOidcStatesCollection) Name() (collectionName string) {
+	return "14_create_oidc_states_collection"
+}
+
+func (m *CreateOidcStatesCollection) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if err = dbConn.CreateCollection(ctx, oidcStateCollectionName); err != nil {
+		return err
+	}
+	indexes := []mongo.IndexModel{{
+		Keys:    bson.D{{Key: "statehash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}, {
+		Keys:    bson.D{{Key: "expiresat", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}}
+	if _, err = dbConn.Collection(oidcStateCollectionName).Indexes().
+		CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+	if _, err = dbConn.Collection(usersCollectionName).Indexes().
+		CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "identities.provider", Value: 1},
+				{Key: "identities.subject", Value: 1}},
+			Options: options.Index().SetName("identities_1"),
+		}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *CreateOidcStatesCollection) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if _, err = dbConn.Collection(usersCollectionName).Indexes().
+		DropOne(ctx, "identities_1"); err != nil {
+		return err
+	}
+
+	return dbConn.Collection(oidcStateCollectionName).Drop(ctx)
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const auditLogCollectionName = "auditLogs"
+
+// Create the append-only audit logs collection.
+type CreateAuditLogsCollection struct{}
+
+func (m *CreateAuditLogsCollection) Name() (collectionName string) {
+	return "15_create_audit_logs_collection"
+}
+
+func (m *CreateAuditLogsCollection) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if err = dbConn.CreateCollection(ctx, auditLogCollectionName); err != nil {
+		return err
+	}
+	indexes := []mongo.IndexModel{{
+		Keys: bson.D{{Key: "createdat", Value: -1}},
+	}, {
+		Keys: bson.D{
+			{Key: "action", Value: 1},
+			{Key: "createdat", Value: -1}},
+	}, {
+		Keys: bson.D{
+			{Key: "actor._id", Value: 1},
+			{Key: "createdat", Value: -1}},
+	}, {
+		Keys: bson.D{
+			{Key: "target.uid", Value: 1},
+			{Key: "createdat", Value: -1}},
+	}}
+	if _, err = dbConn.Collection(auditLogCollectionName).Indexes().
+		CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *CreateAuditLogsCollection) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	return dbConn.Collection(auditLogCollectionName).Drop(ctx)
+}
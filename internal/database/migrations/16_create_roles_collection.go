@@ -0,0 +1,83 @@
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+const roleCollectionName = "roles"
+
+// Create the roles collection, seeded with the built-in roles.
+type CreateRolesCollection struct{}
+
+func (m *CreateRolesCollection) Name() (collectionName string) {
+	return "16_create_roles_collection"
+}
+
+func (m *CreateRolesCollection) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if err = dbConn.CreateCollection(ctx, roleCollectionName); err != nil {
+		return err
+	}
+	indexes := []mongo.IndexModel{{
+		Keys:    bson.D{{Key: "level", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}, {
+		Keys:    bson.D{{Key: "name", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}}
+	if _, err = dbConn.Collection(roleCollectionName).Indexes().
+		CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+
+	return insertSystemRoles(ctx, dbConn)
+}
+
+func (m *CreateRolesCollection) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	return dbConn.Collection(roleCollectionName).Drop(ctx)
+}
+
+func insertSystemRoles(ctx context.Context, dbConn *mongo.Database) (err error) {
+	var (
+		now   = primitive.NewDateTimeFromTime(time.Now())
+		roles = []interface{}{}
+	)
+
+	for _, role := range []models.RoleModel{{
+		Level:       models.RoleLevelSuperAdmin,
+		Name:        "SuperAdmin",
+		Description: "Granted every permission.",
+		Permissions: models.GetAllPermissions(),
+	}, {
+		Level:       models.RoleLevelAdmin,
+		Name:        "Admin",
+		Description: "Manages the users & moderates the comments.",
+		Permissions: models.AdminPermissions,
+	}, {
+		Level:       models.RoleLevelEditor,
+		Name:        "Editor",
+		Description: "Manages every post, page, category & comment.",
+		Permissions: models.EditorPermissions,
+	}, {
+		Level:       models.RoleLevelWriter,
+		Name:        "Writer",
+		Description: "Manages their own posts & the comments on them.",
+		Permissions: models.WriterPermissions,
+	}} {
+		role.UID = primitive.NewObjectID()
+		role.System = true
+		role.CreatedAt = now
+		role.UpdatedAt = now
+		roles = append(roles, role)
+	}
+	_, err = dbConn.Collection(roleCollectionName).InsertMany(ctx, roles)
+
+	return err
+}
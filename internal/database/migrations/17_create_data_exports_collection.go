@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const dataExportCollectionName = "dataExports"
+
+// Create the data exports collection, the archives are stored
+// in the "dataExportFiles" GridFS bucket created on first upload.
+type CreateDataExportsCollection struct{}
+
+func (m *CreateDataExportsCollection) Name() (collectionName string) {
+	return "17_create_data_exports_collection"
+}
+
+func (m *CreateDataExportsCollection) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if err = dbConn.CreateCollection(ctx, dataExportCollectionName); err != nil {
+		return err
+	}
+	indexes := []mongo.IndexModel{{
+		Keys: bson.D{
+			{Key: "owner._id", Value: 1},
+			{Key: "createdat", Value: -1}},
+		Options: nil,
+	}, {
+		Keys:    bson.D{{Key: "expiresat", Value: 1}},
+		Options: nil,
+	}}
+	if _, err = dbConn.Collection(dataExportCollectionName).Indexes().
+		CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *CreateDataExportsCollection) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if err = dbConn.Collection("dataExportFiles.files").Drop(ctx); err != nil {
+		return err
+	}
+	if err = dbConn.Collection("dataExportFiles.chunks").Drop(ctx); err != nil {
+		return err
+	}
+
+	return dbConn.Collection(dataExportCollectionName).Drop(ctx)
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const invitationCollectionName = "invitations"
+
+// Create the staff invitations collection.
+type CreateInvitationsCollection struct{}
+
+func (m *CreateInvitationsCollection) Name() (collectionName string) {
+	return "18_create_invitations_collection"
+}
+
+func (m *CreateInvitationsCollection) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if err = dbConn.CreateCollection(ctx, invitationCollectionName); err != nil {
+		return err
+	}
+	indexes := []mongo.IndexModel{{
+		Keys:    bson.D{{Key: "tokenhash", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}, {
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: nil,
+	}, {
+		Keys:    bson.D{{Key: "createdat", Value: -1}},
+		Options: nil,
+	}}
+	if _, err = dbConn.Collection(invitationCollectionName).Indexes().
+		CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *CreateInvitationsCollection) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	return dbConn.Collection(invitationCollectionName).Drop(ctx)
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	postRevisionCollectionName = "postRevisions"
+	pageRevisionCollectionName = "pageRevisions"
+)
+
+// Create the post & page revisions collections.
+type CreateRevisionsCollections struct{}
+
+func (m *CreateRevisionsCollections) Name() (collectionName string) {
+	return "19_create_revisions_collections"
+}
+
+func (m *CreateRevisionsCollections) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	for collectionName, ownerKey := range map[string]string{
+		postRevisionCollectionName: "postuid",
+		pageRevisionCollectionName: "pageuid",
+	} {
+		if err = dbConn.CreateCollection(ctx, collectionName); err != nil {
+			return err
+		}
+		indexes := []mongo.IndexModel{{
+			Keys: bson.D{
+				{Key: ownerKey, Value: 1},
+				{Key: "number", Value: -1}},
+			Options: options.Index().SetUnique(true),
+		}}
+		if _, err = dbConn.Collection(collectionName).Indexes().
+			CreateMany(ctx, indexes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *CreateRevisionsCollections) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if err = dbConn.Collection(pageRevisionCollectionName).Drop(ctx); err != nil {
+		return err
+	}
+
+	return dbConn.Collection(postRevisionCollectionName).Drop(ctx)
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const publishAtScheduleName = "publishat_1"
+
+// Index the publish schedules of the posts & pages, for the editorial calendar.
+type IndexPublishSchedules struct{}
+
+func (m *IndexPublishSchedules) Name() (collectionName string) {
+	return "20_index_publish_schedules"
+}
+
+func (m *IndexPublishSchedules) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	for _, collectionName := range []string{postCollectionName, pageCollectionName} {
+		index := mongo.IndexModel{
+			Keys:    bson.D{{Key: "publishat", Value: 1}},
+			Options: options.Index().SetName(publishAtScheduleName),
+		}
+		if _, err = dbConn.Collection(collectionName).Indexes().
+			CreateOne(ctx, index); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *IndexPublishSchedules) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	for _, collectionName := range []string{pageCollectionName, postCollectionName} {
+		if _, err = dbConn.Collection(collectionName).Indexes().
+			DropOne(ctx, publishAtScheduleName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
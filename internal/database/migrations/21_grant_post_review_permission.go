@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+// Grant the post review permission to the built-in roles having it.
+type GrantPostReviewPermission struct{}
+
+func (m *GrantPostReviewPermission) Name() (collectionName string) {
+	return "21_grant_post_review_permission"
+}
+
+func (m *GrantPostReviewPermission) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	_, err = dbConn.Collection(roleCollectionName).UpdateMany(ctx,
+		bson.M{"$and": []bson.M{
+			{"system": bson.M{"$eq": true}},
+			{"level": bson.M{"$in": []int{models.RoleLevelSuperAdmin, models.RoleLevelEditor}}}}},
+		bson.M{"$addToSet": bson.M{"permissions": models.PermissionPostReview}})
+
+	return err
+}
+
+func (m *GrantPostReviewPermission) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	_, err = dbConn.Collection(roleCollectionName).UpdateMany(ctx,
+		bson.M{},
+		bson.M{"$pull": bson.M{"permissions": models.PermissionPostReview}})
+
+	return err
+}
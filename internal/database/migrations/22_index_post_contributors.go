@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const postContributorName = "contributors_id_1"
+
+// Index the contributors of the posts, for the author pages & the writer routes.
+type IndexPostContributors struct{}
+
+func (m *IndexPostContributors) Name() (collectionName string) {
+	return "22_index_post_contributors"
+}
+
+func (m *IndexPostContributors) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "contributors._id", Value: 1}},
+		Options: options.Index().SetName(postContributorName),
+	}
+	if _, err = dbConn.Collection(postCollectionName).Indexes().
+		CreateOne(ctx, index); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *IndexPostContributors) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	_, err = dbConn.Collection(postCollectionName).Indexes().
+		DropOne(ctx, postContributorName)
+
+	return err
+}
@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const seriesCollectionName = "series"
+
+// Create the series collection.
+type CreateSeriesCollection struct{}
+
+func (m *CreateSeriesCollection) Name() (collectionName string) {
+	return "23_create_series_collection"
+}
+
+func (m *CreateSeriesCollection) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if err = dbConn.CreateCollection(ctx, seriesCollectionName); err != nil {
+		return err
+	}
+	indexes := []mongo.IndexModel{{
+		Keys:    bson.D{{Key: "slug", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}, {
+		Keys:    bson.D{{Key: "posts", Value: 1}},
+		Options: nil,
+	}, {
+		Keys:    bson.D{{Key: "createdat", Value: -1}},
+		Options: nil,
+	}, {
+		Keys:    bson.D{{Key: "updatedat", Value: -1}},
+		Options: nil,
+	}, {
+		Keys:    bson.D{{Key: "deletedat", Value: 1}},
+		Options: nil,
+	}}
+	if _, err = dbConn.Collection(seriesCollectionName).Indexes().
+		CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *CreateSeriesCollection) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	return dbConn.Collection(seriesCollectionName).Drop(ctx)
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+var seriesPermissions = bson.A{
+	models.PermissionSeriesRead,
+	models.PermissionSeriesCreate,
+	models.PermissionSeriesUpdate,
+	models.PermissionSeriesTrash,
+	models.PermissionSeriesDelete}
+
+// Grant the series permissions to the built-in roles having them.
+type GrantSeriesPermissions struct{}
+
+func (m *GrantSeriesPermissions) Name() (collectionName string) {
+	return "24_grant_series_permissions"
+}
+
+func (m *GrantSeriesPermissions) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	_, err = dbConn.Collection(roleCollectionName).UpdateMany(ctx,
+		bson.M{"$and": []bson.M{
+			{"system": bson.M{"$eq": true}},
+			{"level": bson.M{"$in": []int{models.RoleLevelSuperAdmin, models.RoleLevelEditor}}}}},
+		bson.M{"$addToSet": bson.M{"permissions": bson.M{"$each": seriesPermissions}}})
+
+	return err
+}
+
+func (m *GrantSeriesPermissions) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	_, err = dbConn.Collection(roleCollectionName).UpdateMany(ctx,
+		bson.M{},
+		bson.M{"$pull": bson.M{"permissions": bson.M{"$in": seriesPermissions}}})
+
+	return err
+}
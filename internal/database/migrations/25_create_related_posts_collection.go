@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const relatedPostsCollectionName = "relatedPosts"
+
+// Create the related posts collection, caching the ranking per post.
+type CreateRelatedPostsCollection struct{}
+
+func (m *CreateRelatedPostsCollection) Name() (collectionName string) {
+	return "25_create_related_posts_collection"
+}
+
+func (m *CreateRelatedPostsCollection) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if err = dbConn.CreateCollection(ctx, relatedPostsCollectionName); err != nil {
+		return err
+	}
+	indexes := []mongo.IndexModel{{
+		Keys:    bson.D{{Key: "posts", Value: 1}},
+		Options: nil,
+	}, {
+		Keys:    bson.D{{Key: "expiresat", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}}
+	if _, err = dbConn.Collection(relatedPostsCollectionName).Indexes().
+		CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *CreateRelatedPostsCollection) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	return dbConn.Collection(relatedPostsCollectionName).Drop(ctx)
+}
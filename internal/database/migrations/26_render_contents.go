@@ -0,0 +1,101 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+const (
+	postContentCollectionName = "postContents"
+	pageContentCollectionName = "pageContents"
+)
+
+// Render the stored markdown contents into their HTML & table of contents.
+type RenderContents struct{}
+
+func (m *RenderContents) Name() (collectionName string) {
+	return "26_render_contents"
+}
+
+func (m *RenderContents) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if err = renderPostContents(ctx, dbConn); err != nil {
+		return err
+	}
+
+	return renderPageContents(ctx, dbConn)
+}
+
+func (m *RenderContents) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	var unset = bson.M{"$unset": bson.M{"html": "", "tableofcontents": ""}}
+
+	if _, err = dbConn.Collection(postContentCollectionName).
+		UpdateMany(ctx, bson.M{}, unset); err != nil {
+		return err
+	}
+	_, err = dbConn.Collection(pageContentCollectionName).
+		UpdateMany(ctx, bson.M{}, unset)
+
+	return err
+}
+
+func renderPostContents(ctx context.Context, dbConn *mongo.Database) (err error) {
+	var (
+		collection = dbConn.Collection(postContentCollectionName)
+		cursor     *mongo.Cursor
+	)
+
+	if cursor, err = collection.Find(ctx, bson.M{}); err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		content := &models.PostContentModel{}
+		if err = cursor.Decode(content); err != nil {
+			return err
+		}
+		content.Render()
+		if _, err = collection.UpdateOne(ctx,
+			bson.M{"_id": bson.M{"$eq": content.UID}},
+			bson.M{"$set": bson.M{
+				"html":            content.HTML,
+				"tableofcontents": content.TableOfContents}},
+		); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+func renderPageContents(ctx context.Context, dbConn *mongo.Database) (err error) {
+	var (
+		collection = dbConn.Collection(pageContentCollectionName)
+		cursor     *mongo.Cursor
+	)
+
+	if cursor, err = collection.Find(ctx, bson.M{}); err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		content := &models.PageContentModel{}
+		if err = cursor.Decode(content); err != nil {
+			return err
+		}
+		content.Render()
+		if _, err = collection.UpdateOne(ctx,
+			bson.M{"_id": bson.M{"$eq": content.UID}},
+			bson.M{"$set": bson.M{
+				"html":            content.HTML,
+				"tableofcontents": content.TableOfContents}},
+		); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
@@ -0,0 +1,105 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/pkg/markdown"
+)
+
+// Compute the analytics of the stored posts & pages from their contents.
+type AnalyzeContents struct{}
+
+func (m *AnalyzeContents) Name() (collectionName string) {
+	return "27_analyze_contents"
+}
+
+func (m *AnalyzeContents) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	if err = analyzePosts(ctx, dbConn); err != nil {
+		return err
+	}
+
+	return analyzePages(ctx, dbConn)
+}
+
+func (m *AnalyzeContents) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	var unset = bson.M{"$unset": bson.M{"analytics": ""}}
+
+	if _, err = dbConn.Collection(postCollectionName).
+		UpdateMany(ctx, bson.M{}, unset); err != nil {
+		return err
+	}
+	_, err = dbConn.Collection(pageCollectionName).
+		UpdateMany(ctx, bson.M{}, unset)
+
+	return err
+}
+
+func analyzePosts(ctx context.Context, dbConn *mongo.Database) (err error) {
+	var (
+		collection = dbConn.Collection(postCollectionName)
+		cursor     *mongo.Cursor
+	)
+
+	if cursor, err = collection.Find(ctx, bson.M{}); err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		post := &models.PostModel{}
+		content := &models.PostContentModel{}
+		if err = cursor.Decode(post); err != nil {
+			return err
+		}
+		if err = dbConn.Collection(postContentCollectionName).FindOne(ctx,
+			bson.M{"_id": bson.M{"$eq": post.UID}},
+		).Decode(content); err != nil && err != mongo.ErrNoDocuments {
+			return err
+		}
+		post.Analyze(markdown.Render(content.Content))
+		if _, err = collection.UpdateOne(ctx,
+			bson.M{"_id": bson.M{"$eq": post.UID}},
+			bson.M{"$set": bson.M{"analytics": post.Analytics}},
+		); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+func analyzePages(ctx context.Context, dbConn *mongo.Database) (err error) {
+	var (
+		collection = dbConn.Collection(pageCollectionName)
+		cursor     *mongo.Cursor
+	)
+
+	if cursor, err = collection.Find(ctx, bson.M{}); err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		page := &models.PageModel{}
+		content := &models.PageContentModel{}
+		if err = cursor.Decode(page); err != nil {
+			return err
+		}
+		if err = dbConn.Collection(pageContentCollectionName).FindOne(ctx,
+			bson.M{"_id": bson.M{"$eq": page.UID}},
+		).Decode(content); err != nil && err != mongo.ErrNoDocuments {
+			return err
+		}
+		page.Analyze(markdown.Render(content.Content))
+		if _, err = collection.UpdateOne(ctx,
+			bson.M{"_id": bson.M{"$eq": page.UID}},
+			bson.M{"$set": bson.M{"analytics": page.Analytics}},
+		); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
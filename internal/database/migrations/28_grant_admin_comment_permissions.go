@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+var adminCommentPermissions = bson.A{
+	models.PermissionCommentReadAny,
+	models.PermissionCommentTrashAny,
+	models.PermissionCommentDeleteAny}
+
+// Grant the comment moderation permissions to the built-in Admin role
+// seeded before it had them.
+type GrantAdminCommentPermissions struct{}
+
+func (m *GrantAdminCommentPermissions) Name() (collectionName string) {
+	return "28_grant_admin_comment_permissions"
+}
+
+func (m *GrantAdminCommentPermissions) Up(ctx context.Context, dbConn *mongo.Database) (err error) {
+	_, err = dbConn.Collection(roleCollectionName).UpdateOne(ctx,
+		bson.M{"$and": []bson.M{
+			{"system": bson.M{"$eq": true}},
+			{"level": bson.M{"$eq": models.RoleLevelAdmin}}}},
+		bson.M{"$addToSet": bson.M{"permissions": bson.M{"$each": adminCommentPermissions}}})
+
+	return err
+}
+
+// The Admin role seeded by the roles collection's migration has them too,
+// so they're kept to not leave the Admin with less than a fresh seed.
+func (m *GrantAdminCommentPermissions) Down(ctx context.Context, dbConn *mongo.Database) (err error) {
+	return nil
+}
@@ -0,0 +1,54 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+const (
+	AuditActionSignIn         = "auth.signin"
+	AuditActionSignInPending  = "auth.signin.pending"
+	AuditActionSignOut        = "auth.signout"
+	AuditActionRefresh        = "auth.refresh"
+	AuditActionUpdatePassword = "me.password.update"
+	AuditActionDeleteMe       = "me.delete"
+	AuditActionAdminize       = "user.adminize"
+	AuditActionImpersonate    = "user.impersonate"
+	AuditActionDeadminize     = "user.deadminize"
+	AuditActionDeleteUser     = "user.delete"
+	AuditActionInviteUser     = "user.invite"
+	AuditActionResendInvite   = "user.invite.resend"
+	AuditActionRevokeInvite   = "user.invite.revoke"
+	AuditActionAcceptInvite   = "user.invite.accept"
+	AuditActionDeletePost     = "post.delete"
+	AuditActionDeletePage     = "page.delete"
+	AuditActionDeleteCategory = "category.delete"
+	AuditActionDeleteSeries   = "series.delete"
+	AuditActionDeleteComment  = "comment.delete"
+
+	AuditActionImpersonatedRequest = "impersonation.request"
+
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+)
+
+// Append-only record of an authentication or administrative action,
+// the actor is null when it's unknown, e.g. failed sign in.
+// The impersonator is set when the actor is being impersonated.
+type AuditLogModel struct {
+	UID          primitive.ObjectID `bson:"_id" json:"id,omitempty"`
+	Action       string             `json:"action"`
+	Actor        *UserCommonModel   `json:"actor"`
+	Impersonator *UserCommonModel   `json:"impersonator"`
+	Target       AuditTargetModel   `json:"target"`
+	IP           string             `json:"ip"`
+	UserAgent    string             `json:"userAgent"`
+	Outcome      string             `json:"outcome"`
+	Status       int                `json:"status"`
+	CreatedAt    primitive.DateTime `json:"createdAt"`
+}
+
+// The resource the action is done to, the name is whatever
+// identifies it to a human, e.g. the username or the post's title.
+type AuditTargetModel struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+	Name string `json:"name"`
+}
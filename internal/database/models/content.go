@@ -0,0 +1,42 @@
+package models
+
+import "github.com/misterabdul/goblog-server/pkg/markdown"
+
+// Heading of the rendered content, with its anchor in the HTML.
+type ContentHeadingModel struct {
+	Level  int    `json:"level"`
+	Text   string `json:"text"`
+	Anchor string `json:"anchor"`
+}
+
+// Render the markdown content into its sanitized HTML & table of contents,
+// the rendered document can be analyzed without parsing the content again.
+func (content *PostContentModel) Render() (document markdown.Document) {
+	document = markdown.Render(content.Content)
+	content.HTML = document.HTML
+	content.TableOfContents = contentHeadings(document.Headings)
+
+	return document
+}
+
+// Render the markdown content into its sanitized HTML & table of contents,
+// the rendered document can be analyzed without parsing the content again.
+func (content *PageContentModel) Render() (document markdown.Document) {
+	document = markdown.Render(content.Content)
+	content.HTML = document.HTML
+	content.TableOfContents = contentHeadings(document.Headings)
+
+	return document
+}
+
+func contentHeadings(headings []markdown.Heading) (contentHeadings []ContentHeadingModel) {
+	contentHeadings = []ContentHeadingModel{}
+	for _, heading := range headings {
+		contentHeadings = append(contentHeadings, ContentHeadingModel{
+			Level:  heading.Level,
+			Text:   heading.Text,
+			Anchor: heading.Anchor})
+	}
+
+	return contentHeadings
+}
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/misterabdul/goblog-server/pkg/markdown"
+)
+
+const (
+	ContentWordsPerMinute = 200
+
+	SEOCheckTitleLength      = "title-length"
+	SEOCheckDescription      = "description"
+	SEOCheckSlug             = "slug"
+	SEOCheckHeadingStructure = "heading-structure"
+
+	seoTitleMinLength       = 30
+	seoTitleMaxLength       = 60
+	seoDescriptionMaxLength = 160
+	seoSlugMaxLength        = 75
+)
+
+var seoSlugRegex = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// Analytics of the content computed on save, the reading time is in minutes.
+// The links without scheme & host, e.g. "/post/slug" or "#anchor", are internal.
+type ContentAnalyticsModel struct {
+	WordCount     int                    `json:"wordCount"`
+	ReadingTime   int                    `json:"readingTime"`
+	Outline       []ContentHeadingModel  `json:"outline"`
+	ImageCount    int                    `json:"imageCount"`
+	InternalLinks []string               `json:"internalLinks"`
+	ExternalLinks []string               `json:"externalLinks"`
+	SEOChecks     []ContentSEOCheckModel `json:"seoChecks"`
+}
+
+// Single check of the SEO checklist, the message tells what to fix.
+type ContentSEOCheckModel struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message"`
+}
+
+// Compute the post's analytics from its rendered content.
+func (post *PostModel) Analyze(document markdown.Document) {
+	post.Analytics = analyzeContent(document)
+	post.Analytics.SEOChecks = []ContentSEOCheckModel{
+		checkSEOTitleLength(post.Title),
+		checkSEODescription(post.Description),
+		checkSEOSlug(post.Slug),
+		checkSEOHeadingStructure(post.Analytics.Outline)}
+}
+
+// Compute the page's analytics from its rendered content.
+func (page *PageModel) Analyze(document markdown.Document) {
+	page.Analytics = analyzeContent(document)
+	page.Analytics.SEOChecks = []ContentSEOCheckModel{
+		checkSEOTitleLength(page.Title),
+		checkSEOSlug(page.Slug),
+		checkSEOHeadingStructure(page.Analytics.Outline)}
+}
+
+func analyzeContent(document markdown.Document) (analytics ContentAnalyticsModel) {
+	analytics = ContentAnalyticsModel{
+		WordCount:     document.Words,
+		ReadingTime:   int(math.Ceil(float64(document.Words) / ContentWordsPerMinute)),
+		Outline:       contentHeadings(document.Headings),
+		ImageCount:    len(document.Images),
+		InternalLinks: []string{},
+		ExternalLinks: []string{}}
+	for _, link := range document.Links {
+		if isInternalLink(link) {
+			analytics.InternalLinks = append(analytics.InternalLinks, link)
+		} else {
+			analytics.ExternalLinks = append(analytics.ExternalLinks, link)
+		}
+	}
+
+	return analytics
+}
+
+func isInternalLink(link string) (internal bool) {
+	var (
+		parsed *url.URL
+		err    error
+	)
+
+	if parsed, err = url.Parse(link); err != nil {
+		return false
+	}
+
+	return len(parsed.Scheme) == 0 && len(parsed.Host) == 0
+}
+
+func checkSEOTitleLength(title string) (check ContentSEOCheckModel) {
+	var length = utf8.RuneCountInString(title)
+
+	check = ContentSEOCheckModel{Name: SEOCheckTitleLength, Passed: true}
+	switch {
+	case length < seoTitleMinLength:
+		check.Passed = false
+		check.Message = fmt.Sprintf(
+			"title is too short, use at least %d characters", seoTitleMinLength)
+	case length > seoTitleMaxLength:
+		check.Passed = false
+		check.Message = fmt.Sprintf(
+			"title is too long, use at most %d characters", seoTitleMaxLength)
+	}
+
+	return check
+}
+
+func checkSEODescription(description string) (check ContentSEOCheckModel) {
+	var length = utf8.RuneCountInString(description)
+
+	check = ContentSEOCheckModel{Name: SEOCheckDescription, Passed: true}
+	switch {
+	case length == 0:
+		check.Passed = false
+		check.Message = "description is missing"
+	case length > seoDescriptionMaxLength:
+		check.Passed = false
+		check.Message = fmt.Sprintf(
+			"description is too long, use at most %d characters", seoDescriptionMaxLength)
+	}
+
+	return check
+}
+
+func checkSEOSlug(slug string) (check ContentSEOCheckModel) {
+	check = ContentSEOCheckModel{Name: SEOCheckSlug, Passed: true}
+	switch {
+	case !seoSlugRegex.MatchString(slug):
+		check.Passed = false
+		check.Message = "slug should only be lowercase words separated by single hyphens"
+	case len(slug) > seoSlugMaxLength:
+		check.Passed = false
+		check.Message = fmt.Sprintf(
+			"slug is too long, use at most %d characters", seoSlugMaxLength)
+	}
+
+	return check
+}
+
+// The title is the page's only level 1 heading, so the content's headings
+// start from level 2 & go down one level at a time.
+func checkSEOHeadingStructure(outline []ContentHeadingModel) (check ContentSEOCheckModel) {
+	var previousLevel = 1
+
+	check = ContentSEOCheckModel{Name: SEOCheckHeadingStructure, Passed: true}
+	for _, heading := range outline {
+		switch {
+		case heading.Level == 1:
+			check.Passed = false
+			check.Message = fmt.Sprintf(
+				"heading \"%s\" is level 1, the title is already the level 1 heading", heading.Text)
+			return check
+		case heading.Level > previousLevel+1:
+			check.Passed = false
+			check.Message = fmt.Sprintf(
+				"heading \"%s\" skips from level %d to %d", heading.Text, previousLevel, heading.Level)
+			return check
+		}
+		previousLevel = heading.Level
+	}
+
+	return check
+}
@@ -0,0 +1,22 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+const (
+	DataExportStatusPending = "pending"
+	DataExportStatusReady   = "ready"
+	DataExportStatusFailed  = "failed"
+)
+
+// Archive of the user's personal data, the archive itself
+// is stored in GridFS & removed once it's expired.
+type DataExportModel struct {
+	UID        primitive.ObjectID `bson:"_id" json:"id,omitempty"`
+	Owner      UserCommonModel    `json:"owner"`
+	Status     string             `json:"status"`
+	FileID     interface{}        `json:"fileId"`
+	Size       int64              `json:"size"`
+	ExpiresAt  interface{}        `json:"expiresAt"`
+	CreatedAt  interface{}        `json:"createdAt"`
+	FinishedAt interface{}        `json:"finishedAt"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Invitation of a new staff member by email with preassigned roles,
+// pending until it's accepted, revoked or expired. Only the token's hash is stored.
+type InvitationModel struct {
+	UID        primitive.ObjectID `bson:"_id" json:"id,omitempty"`
+	Email      string             `json:"email"`
+	FirstName  string             `json:"firstName"`
+	LastName   string             `json:"lastName"`
+	Roles      []UserRole         `json:"roles"`
+	TokenHash  string             `json:"tokenHash"`
+	InvitedBy  UserCommonModel    `json:"invitedBy"`
+	SentCount  int                `json:"sentCount"`
+	ExpiresAt  primitive.DateTime `json:"expiresAt"`
+	AcceptedAt interface{}        `json:"acceptedAt"`
+	RevokedAt  interface{}        `json:"revokedAt"`
+	CreatedAt  interface{}        `json:"createdAt"`
+	UpdatedAt  interface{}        `json:"updatedAt"`
+}
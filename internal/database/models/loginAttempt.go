@@ -0,0 +1,14 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Failed sign in attempts of a single account or client IP,
+// identified by its key, e.g. "account:<uid>" or "ip:<address>".
+type LoginAttemptModel struct {
+	UID          primitive.ObjectID `bson:"_id" json:"id,omitempty"`
+	Key          string             `json:"key"`
+	Failures     int                `json:"failures"`
+	LastFailedAt primitive.DateTime `json:"lastFailedAt"`
+	LockedUntil  primitive.DateTime `json:"lockedUntil"`
+	ExpiresAt    primitive.DateTime `json:"expiresAt"`
+}
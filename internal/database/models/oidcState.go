@@ -0,0 +1,15 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Pending OpenID Connect authorization, identified by the hash
+// of its state parameter & used only once on the callback.
+type OidcStateModel struct {
+	UID          primitive.ObjectID `bson:"_id" json:"id,omitempty"`
+	StateHash    string             `json:"stateHash"`
+	Provider     string             `json:"provider"`
+	Nonce        string             `json:"nonce"`
+	CodeVerifier string             `json:"codeVerifier"`
+	ExpiresAt    primitive.DateTime `json:"expiresAt"`
+	CreatedAt    interface{}        `json:"createdAt"`
+}
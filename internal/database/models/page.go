@@ -2,18 +2,25 @@ package models
 
 import "go.mongodb.org/mongo-driver/bson/primitive"
 
+// The page is scheduled when its publishAt is set,
+// it's published by the worker at that time.
+// The analytics are computed from the content on every save.
 type PageModel struct {
-	UID         primitive.ObjectID `bson:"_id" json:"id,omitempty"`
-	Slug        string             `json:"slug"`
-	Title       string             `json:"title"`
-	Author      UserCommonModel    `json:"author"`
-	PublishedAt interface{}        `json:"publishedAt"`
-	CreatedAt   interface{}        `json:"createdAt"`
-	UpdatedAt   interface{}        `json:"updatedAt"`
-	DeletedAt   interface{}        `json:"deletedAt"`
+	UID         primitive.ObjectID    `bson:"_id" json:"id,omitempty"`
+	Slug        string                `json:"slug"`
+	Title       string                `json:"title"`
+	Author      UserCommonModel       `json:"author"`
+	Analytics   ContentAnalyticsModel `json:"analytics"`
+	PublishAt   interface{}           `json:"publishAt"`
+	PublishedAt interface{}           `json:"publishedAt"`
+	CreatedAt   interface{}           `json:"createdAt"`
+	UpdatedAt   interface{}           `json:"updatedAt"`
+	DeletedAt   interface{}           `json:"deletedAt"`
 }
 
 type PageContentModel struct {
-	UID     primitive.ObjectID `bson:"_id" json:"id,omitempty"`
-	Content string             `json:"content"`
+	UID             primitive.ObjectID    `bson:"_id" json:"id,omitempty"`
+	Content         string                `json:"content"`
+	HTML            string                `json:"html"`
+	TableOfContents []ContentHeadingModel `json:"tableOfContents"`
 }
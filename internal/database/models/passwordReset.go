@@ -0,0 +1,12 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type PasswordResetModel struct {
+	UID       primitive.ObjectID `bson:"_id" json:"id,omitempty"`
+	TokenHash string             `json:"tokenHash"`
+	Owner     UserCommonModel    `json:"owner"`
+	ExpiresAt primitive.DateTime `json:"expiresAt"`
+	UsedAt    interface{}        `json:"usedAt"`
+	CreatedAt interface{}        `json:"createdAt"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Long-lived token for automation, acting as its owner
+// but limited to its scopes. Only the token's hash is stored.
+type PersonalTokenModel struct {
+	UID        primitive.ObjectID `bson:"_id" json:"id,omitempty"`
+	Name       string             `json:"name"`
+	TokenHash  string             `json:"tokenHash"`
+	Hint       string             `json:"hint"`
+	Scopes     []string           `json:"scopes"`
+	Owner      UserCommonModel    `json:"owner"`
+	ExpiresAt  primitive.DateTime `json:"expiresAt"`
+	LastUsedAt interface{}        `json:"lastUsedAt"`
+	CreatedAt  interface{}        `json:"createdAt"`
+	UpdatedAt  interface{}        `json:"updatedAt"`
+}
+
+// Available personal token scopes.
+var PersonalTokenScopes = []string{
+	"posts:read",
+	"posts:write",
+	"pages:read",
+	"pages:write",
+	"categories:read",
+	"categories:write",
+	"comments:read",
+	"comments:moderate",
+	"users:read",
+	"users:write"}
+
+func (token *PersonalTokenModel) HasScope(scope string) (exist bool) {
+	for _, tokenScope := range token.Scopes {
+		if tokenScope == scope {
+			return true
+		}
+	}
+
+	return false
+}
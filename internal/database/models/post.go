@@ -2,23 +2,118 @@ package models
 
 import "go.mongodb.org/mongo-driver/bson/primitive"
 
+const (
+	PostStatusDraft            = "draft"
+	PostStatusSubmitted        = "submitted"
+	PostStatusChangesRequested = "changes-requested"
+	PostStatusApproved         = "approved"
+	PostStatusPublished        = "published"
+
+	PostContributorRoleAuthor   = "author"
+	PostContributorRoleCoAuthor = "co-author"
+	PostContributorRoleEditor   = "editor"
+)
+
+// The post is scheduled when its publishAt is set,
+// it's published by the worker at that time.
+// The status follows the editorial review, every change of it is
+// kept in the transitions, oldest first.
+// The contributors are credited in the byline, starting with the author.
+// The analytics are computed from the content on every save.
 type PostModel struct {
-	UID                primitive.ObjectID    `bson:"_id" json:"id,omitempty"`
-	Slug               string                `json:"slug"`
-	Title              string                `json:"title"`
-	FeaturingImagePath string                `json:"featuringImagePath"`
-	Description        string                `json:"description"`
-	Categories         []CategoryCommonModel `json:"categories"`
-	Tags               []string              `json:"tags"`
-	Author             UserCommonModel       `json:"author"`
-	CommentCount       int16                 `json:"commentCount"`
-	PublishedAt        interface{}           `json:"publishedAt"`
-	CreatedAt          interface{}           `json:"createdAt"`
-	UpdatedAt          interface{}           `json:"updatedAt"`
-	DeletedAt          interface{}           `json:"deletedAt"`
+	UID                primitive.ObjectID     `bson:"_id" json:"id,omitempty"`
+	Slug               string                 `json:"slug"`
+	Title              string                 `json:"title"`
+	FeaturingImagePath string                 `json:"featuringImagePath"`
+	Description        string                 `json:"description"`
+	Categories         []CategoryCommonModel  `json:"categories"`
+	Tags               []string               `json:"tags"`
+	Author             UserCommonModel        `json:"author"`
+	Contributors       []PostContributorModel `json:"contributors"`
+	CommentCount       int16                  `json:"commentCount"`
+	Status             string                 `json:"status"`
+	Transitions        []PostTransitionModel  `json:"transitions"`
+	Analytics          ContentAnalyticsModel  `json:"analytics"`
+	PublishAt          interface{}            `json:"publishAt"`
+	PublishedAt        interface{}            `json:"publishedAt"`
+	CreatedAt          interface{}            `json:"createdAt"`
+	UpdatedAt          interface{}            `json:"updatedAt"`
+	DeletedAt          interface{}            `json:"deletedAt"`
 }
 
 type PostContentModel struct {
-	UID     primitive.ObjectID `bson:"_id" json:"id,omitempty"`
-	Content string             `json:"content"`
+	UID             primitive.ObjectID    `bson:"_id" json:"id,omitempty"`
+	Content         string                `json:"content"`
+	HTML            string                `json:"html"`
+	TableOfContents []ContentHeadingModel `json:"tableOfContents"`
+}
+
+type PostContributorModel struct {
+	UserCommonModel `bson:",inline"`
+	Role            string `json:"role"`
+}
+
+// A change of the post's status, the actor is null
+// when it's made by the worker, e.g. scheduled publish.
+type PostTransitionModel struct {
+	From      string           `json:"from"`
+	To        string           `json:"to"`
+	Note      string           `json:"note"`
+	Actor     *UserCommonModel `json:"actor"`
+	CreatedAt interface{}      `json:"createdAt"`
+}
+
+// Allowed status changes, publishing is allowed from every unpublished status.
+var postTransitions = map[string][]string{
+	PostStatusDraft:            {PostStatusSubmitted, PostStatusPublished},
+	PostStatusSubmitted:        {PostStatusApproved, PostStatusChangesRequested, PostStatusPublished},
+	PostStatusChangesRequested: {PostStatusSubmitted, PostStatusPublished},
+	PostStatusApproved:         {PostStatusChangesRequested, PostStatusSubmitted, PostStatusPublished},
+	PostStatusPublished:        {PostStatusDraft},
+}
+
+// Get the post's status, the posts saved before the review
+// workflow existed are either published or draft.
+func (post *PostModel) GetStatus() (status string) {
+	if len(post.Status) > 0 {
+		return post.Status
+	}
+	if post.PublishedAt != nil {
+		return PostStatusPublished
+	}
+
+	return PostStatusDraft
+}
+
+// Get the post's contributors, the posts saved before the contributors
+// existed have their author as the sole one.
+func (post *PostModel) GetContributors() (contributors []PostContributorModel) {
+	if len(post.Contributors) > 0 {
+		return post.Contributors
+	}
+
+	return []PostContributorModel{{
+		UserCommonModel: post.Author,
+		Role:            PostContributorRoleAuthor}}
+}
+
+// Check whether the user is the post's author or one of its co-authors
+func (post *PostModel) IsAuthoredBy(userUid primitive.ObjectID) (authored bool) {
+	for _, contributor := range post.GetContributors() {
+		if contributor.UID == userUid && contributor.Role != PostContributorRoleEditor {
+			return true
+		}
+	}
+
+	return post.Author.UID == userUid
+}
+
+func (post *PostModel) CanTransitTo(status string) (allowed bool) {
+	for _, next := range postTransitions[post.GetStatus()] {
+		if next == status {
+			return true
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,12 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Cached ranking of the posts related to a post, identified by the post's UID.
+// The posts are ordered by their relevance, most relevant first.
+type RelatedPostsModel struct {
+	UID       primitive.ObjectID   `bson:"_id" json:"id,omitempty"`
+	Posts     []primitive.ObjectID `json:"posts"`
+	ExpiresAt primitive.DateTime   `json:"expiresAt"`
+	CreatedAt interface{}          `json:"createdAt"`
+}
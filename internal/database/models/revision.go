@@ -0,0 +1,37 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Snapshot of the post & its content right after it's saved,
+// numbered per post. The restored one refers to its origin's number.
+type PostRevisionModel struct {
+	UID                primitive.ObjectID    `bson:"_id" json:"id,omitempty"`
+	PostUID            primitive.ObjectID    `json:"postUid"`
+	Number             int                   `json:"number"`
+	Slug               string                `json:"slug"`
+	Title              string                `json:"title"`
+	FeaturingImagePath string                `json:"featuringImagePath"`
+	Description        string                `json:"description"`
+	Categories         []CategoryCommonModel `json:"categories"`
+	Tags               []string              `json:"tags"`
+	Content            string                `json:"content"`
+	ChangedFields      []string              `json:"changedFields"`
+	Editor             UserCommonModel       `json:"editor"`
+	RestoredFrom       int                   `json:"restoredFrom"`
+	CreatedAt          primitive.DateTime    `json:"createdAt"`
+}
+
+// Snapshot of the page & its content right after it's saved,
+// numbered per page. The restored one refers to its origin's number.
+type PageRevisionModel struct {
+	UID           primitive.ObjectID `bson:"_id" json:"id,omitempty"`
+	PageUID       primitive.ObjectID `json:"pageUid"`
+	Number        int                `json:"number"`
+	Slug          string             `json:"slug"`
+	Title         string             `json:"title"`
+	Content       string             `json:"content"`
+	ChangedFields []string           `json:"changedFields"`
+	Editor        UserCommonModel    `json:"editor"`
+	RestoredFrom  int                `json:"restoredFrom"`
+	CreatedAt     primitive.DateTime `json:"createdAt"`
+}
@@ -0,0 +1,189 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Named set of permissions, referenced by the users' roles through its level.
+// The built-in roles take the levels 0 to 3, custom roles take the next ones.
+type RoleModel struct {
+	UID         primitive.ObjectID `bson:"_id" json:"id,omitempty"`
+	Level       int                `json:"level"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Permissions []string           `json:"permissions"`
+	System      bool               `json:"system"`
+	CreatedAt   interface{}        `json:"createdAt"`
+	UpdatedAt   interface{}        `json:"updatedAt"`
+}
+
+const (
+	RoleLevelSuperAdmin = 0
+	RoleLevelAdmin      = 1
+	RoleLevelEditor     = 2
+	RoleLevelWriter     = 3
+
+	PermissionPostCreate     = "post.create"
+	PermissionPostReadOwn    = "post.read.own"
+	PermissionPostUpdateOwn  = "post.update.own"
+	PermissionPostTrashOwn   = "post.trash.own"
+	PermissionPostDeleteOwn  = "post.delete.own"
+	PermissionPostPublishOwn = "post.publish.own"
+	PermissionPostReadAny    = "post.read.any"
+	PermissionPostUpdateAny  = "post.update.any"
+	PermissionPostTrashAny   = "post.trash.any"
+	PermissionPostDeleteAny  = "post.delete.any"
+	PermissionPostPublishAny = "post.publish.any"
+	PermissionPostReview     = "post.review"
+
+	PermissionCommentReadOwn   = "comment.read.own"
+	PermissionCommentTrashOwn  = "comment.trash.own"
+	PermissionCommentDeleteOwn = "comment.delete.own"
+	PermissionCommentReadAny   = "comment.read.any"
+	PermissionCommentTrashAny  = "comment.trash.any"
+	PermissionCommentDeleteAny = "comment.delete.any"
+
+	PermissionCategoryRead   = "category.read"
+	PermissionCategoryCreate = "category.create"
+	PermissionCategoryUpdate = "category.update"
+	PermissionCategoryTrash  = "category.trash"
+	PermissionCategoryDelete = "category.delete"
+
+	PermissionSeriesRead   = "series.read"
+	PermissionSeriesCreate = "series.create"
+	PermissionSeriesUpdate = "series.update"
+	PermissionSeriesTrash  = "series.trash"
+	PermissionSeriesDelete = "series.delete"
+
+	PermissionPageRead    = "page.read"
+	PermissionPageCreate  = "page.create"
+	PermissionPageUpdate  = "page.update"
+	PermissionPageTrash   = "page.trash"
+	PermissionPagePublish = "page.publish"
+	PermissionPageDelete  = "page.delete"
+
+	PermissionUserRead           = "user.read"
+	PermissionUserCreate         = "user.create"
+	PermissionUserUpdate         = "user.update"
+	PermissionUserTrash          = "user.trash"
+	PermissionUserDelete         = "user.delete"
+	PermissionUserAdminize       = "user.adminize"
+	PermissionUserRevokeSessions = "user.sessions.revoke"
+	PermissionUserResetTwoFactor = "user.2fa.reset"
+	PermissionUserImpersonate    = "user.impersonate"
+
+	PermissionLockoutRead   = "lockout.read"
+	PermissionLockoutDelete = "lockout.delete"
+
+	PermissionAuditRead = "audit.read"
+
+	PermissionRoleRead   = "role.read"
+	PermissionRoleManage = "role.manage"
+)
+
+// Permissions of the built-in roles, by level.
+// The SuperAdmin is granted every permission regardless of its stored ones.
+var (
+	WriterPermissions = []string{
+		PermissionPostCreate,
+		PermissionPostReadOwn,
+		PermissionPostUpdateOwn,
+		PermissionPostTrashOwn,
+		PermissionPostDeleteOwn,
+		PermissionPostPublishOwn,
+		PermissionCommentReadOwn,
+		PermissionCommentTrashOwn,
+		PermissionCommentDeleteOwn}
+
+	EditorPermissions = []string{
+		PermissionPostCreate,
+		PermissionPostReadAny,
+		PermissionPostUpdateAny,
+		PermissionPostTrashAny,
+		PermissionPostDeleteAny,
+		PermissionPostPublishAny,
+		PermissionPostReview,
+		PermissionCommentReadAny,
+		PermissionCommentTrashAny,
+		PermissionCommentDeleteAny,
+		PermissionCategoryRead,
+		PermissionCategoryCreate,
+		PermissionCategoryUpdate,
+		PermissionCategoryTrash,
+		PermissionCategoryDelete,
+		PermissionSeriesRead,
+		PermissionSeriesCreate,
+		PermissionSeriesUpdate,
+		PermissionSeriesTrash,
+		PermissionSeriesDelete,
+		PermissionPageRead,
+		PermissionPageCreate,
+		PermissionPageUpdate,
+		PermissionPageTrash,
+		PermissionPagePublish,
+		PermissionPageDelete}
+
+	AdminPermissions = []string{
+		PermissionCommentReadAny,
+		PermissionCommentTrashAny,
+		PermissionCommentDeleteAny,
+		PermissionUserRead,
+		PermissionUserCreate,
+		PermissionUserUpdate,
+		PermissionUserTrash,
+		PermissionUserRevokeSessions,
+		PermissionUserResetTwoFactor,
+		PermissionLockoutRead,
+		PermissionLockoutDelete}
+
+	SuperAdminPermissions = []string{
+		PermissionUserDelete,
+		PermissionUserAdminize,
+		PermissionUserImpersonate,
+		PermissionAuditRead,
+		PermissionRoleRead,
+		PermissionRoleManage}
+)
+
+// Get every available permission.
+func GetAllPermissions() (permissions []string) {
+	permissions = append(permissions, WriterPermissions...)
+	for _, group := range [][]string{
+		EditorPermissions,
+		AdminPermissions,
+		SuperAdminPermissions,
+	} {
+		for _, permission := range group {
+			if !containsPermission(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+
+	return permissions
+}
+
+// Get the permissions which can be granted by the roles other than
+// the SuperAdmin, the SuperAdmin's own permissions are never shared.
+func GetGrantablePermissions() (permissions []string) {
+	permissions = []string{}
+	for _, permission := range GetAllPermissions() {
+		if !containsPermission(SuperAdminPermissions, permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+
+	return permissions
+}
+
+func (role *RoleModel) HasPermission(permission string) (exist bool) {
+	return containsPermission(role.Permissions, permission)
+}
+
+func containsPermission(permissions []string, permission string) (exist bool) {
+	for _, _permission := range permissions {
+		if _permission == permission {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,16 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// Ordered group of posts, e.g. a multi-part tutorial.
+// A post belongs to one series at most, its position is its index in the posts.
+type SeriesModel struct {
+	UID         primitive.ObjectID   `bson:"_id" json:"id,omitempty"`
+	Slug        string               `json:"slug"`
+	Title       string               `json:"title"`
+	Description string               `json:"description"`
+	Posts       []primitive.ObjectID `json:"posts"`
+	CreatedAt   interface{}          `json:"createdAt"`
+	UpdatedAt   interface{}          `json:"updatedAt"`
+	DeletedAt   interface{}          `json:"deletedAt"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// A signed in session, identified by its refresh token family,
+// tracking the latest refresh token rotated within the family.
+type SessionModel struct {
+	UID        primitive.ObjectID `bson:"_id" json:"id,omitempty"`
+	TokenID    string             `json:"tokenId"`
+	Owner      UserCommonModel    `json:"owner"`
+	IP         string             `json:"ip"`
+	UserAgent  string             `json:"userAgent"`
+	ExpiresAt  primitive.DateTime `json:"expiresAt"`
+	LastUsedAt interface{}        `json:"lastUsedAt"`
+	CreatedAt  interface{}        `json:"createdAt"`
+	UpdatedAt  interface{}        `json:"updatedAt"`
+}
@@ -1,12 +1,45 @@
 package models
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// A revoked token is either a single token matched by its UID (jti),
+// all of the owner's tokens issued before IssuedBefore when it's set,
+// or all tokens of the RevokedFamily when it's set.
+//
+// RotatedAt marks a single refresh token replaced by its successor,
+// presenting it again after the reuse grace means the token family
+// is compromised.
 type RevokedTokenModel struct {
-	UID       primitive.ObjectID `bson:"_id" json:"id,omitempty"`
-	ExpiresAt primitive.DateTime `json:"expiresAt"`
-	Owner     UserCommonModel    `json:"owner"`
-	CreatedAt interface{}        `json:"createdAt"`
-	UpdatedAt interface{}        `json:"updatedAt"`
-	DeletedAt interface{}        `json:"deletedAt"`
+	UID           primitive.ObjectID `bson:"_id" json:"id,omitempty"`
+	ExpiresAt     primitive.DateTime `json:"expiresAt"`
+	IssuedBefore  interface{}        `json:"issuedBefore"`
+	FamilyID      string             `json:"familyId"`
+	RotatedAt     interface{}        `json:"rotatedAt"`
+	RevokedFamily string             `json:"revokedFamily"`
+	Owner         UserCommonModel    `json:"owner"`
+	CreatedAt     interface{}        `json:"createdAt"`
+	UpdatedAt     interface{}        `json:"updatedAt"`
+	DeletedAt     interface{}        `json:"deletedAt"`
+}
+
+// Check whether the token is rotated no longer than grace before now,
+// e.g. by a concurrent refresh from another tab rather than by a theft.
+func (token *RevokedTokenModel) IsRotatedWithin(
+	grace time.Duration,
+	now time.Time,
+) (within bool) {
+	var (
+		rotatedAt primitive.DateTime
+		ok        bool
+	)
+
+	if rotatedAt, ok = token.RotatedAt.(primitive.DateTime); !ok {
+		return false
+	}
+
+	return !now.After(rotatedAt.Time().Add(grace))
 }
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRevokedTokenIsRotatedWithin(t *testing.T) {
+	var (
+		now   = time.Unix(1700000000, 0)
+		grace = 10 * time.Second
+		tests = []struct {
+			name      string
+			rotatedAt interface{}
+			within    bool
+		}{{
+			name:      "rotated just now",
+			rotatedAt: primitive.NewDateTimeFromTime(now),
+			within:    true,
+		}, {
+			name:      "rotated within the grace",
+			rotatedAt: primitive.NewDateTimeFromTime(now.Add(-5 * time.Second)),
+			within:    true,
+		}, {
+			name:      "rotated exactly at the grace",
+			rotatedAt: primitive.NewDateTimeFromTime(now.Add(-grace)),
+			within:    true,
+		}, {
+			name:      "rotated beyond the grace",
+			rotatedAt: primitive.NewDateTimeFromTime(now.Add(-grace - time.Second)),
+			within:    false,
+		}, {
+			name:      "not rotated",
+			rotatedAt: nil,
+			within:    false,
+		}}
+	)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var token = &RevokedTokenModel{RotatedAt: test.rotatedAt}
+
+			if within := token.IsRotatedWithin(grace, now); within != test.within {
+				t.Errorf("IsRotatedWithin = %t, want %t", within, test.within)
+			}
+		})
+	}
+}
@@ -2,17 +2,26 @@ package models
 
 import "go.mongodb.org/mongo-driver/bson/primitive"
 
+// Tokens issued with older TokenVersion are no longer valid.
 type UserModel struct {
-	UID       primitive.ObjectID `bson:"_id" json:"id,omitempty"`
-	Username  string             `json:"username"`
-	Email     string             `json:"email"`
-	FirstName string             `json:"firstName"`
-	LastName  string             `json:"lastName"`
-	Password  string             `json:"password"`
-	Roles     []UserRole         `json:"roles"`
-	CreatedAt interface{}        `json:"createdAt"`
-	UpdatedAt interface{}        `json:"updatedAt"`
-	DeletedAt interface{}        `json:"deletedAt"`
+	UID          primitive.ObjectID `bson:"_id" json:"id,omitempty"`
+	Username     string             `json:"username"`
+	Email        string             `json:"email"`
+	FirstName    string             `json:"firstName"`
+	LastName     string             `json:"lastName"`
+	Bio          string             `json:"bio"`
+	Avatar       string             `json:"avatar"`
+	Website      string             `json:"website"`
+	Socials      []UserSocial       `json:"socials"`
+	Password     string             `json:"password"`
+	Roles        []UserRole         `json:"roles"`
+	VerifiedAt   interface{}        `json:"verifiedAt"`
+	TwoFactor    UserTwoFactor      `json:"twoFactor"`
+	TokenVersion int64              `json:"tokenVersion"`
+	Identities   []UserIdentity     `json:"identities"`
+	CreatedAt    interface{}        `json:"createdAt"`
+	UpdatedAt    interface{}        `json:"updatedAt"`
+	DeletedAt    interface{}        `json:"deletedAt"`
 }
 
 type UserCommonModel struct {
@@ -21,6 +30,31 @@ type UserCommonModel struct {
 	Email     string             `json:"email"`
 	FirstName string             `json:"firstName"`
 	LastName  string             `json:"lastName"`
+	Avatar    string             `json:"avatar"`
+}
+
+// Link to the user's profile on another network.
+type UserSocial struct {
+	Network string `json:"network"`
+	URL     string `json:"url"`
+}
+
+// Time-based one-time password second factor,
+// enabled once EnabledAt is set.
+type UserTwoFactor struct {
+	Secret        string      `json:"secret"`
+	PendingSecret string      `json:"pendingSecret"`
+	RecoveryCodes []string    `json:"recoveryCodes"`
+	LastUsedStep  int64       `json:"lastUsedStep"`
+	EnabledAt     interface{} `json:"enabledAt"`
+}
+
+// External identity of the user, signed in with the OpenID Connect provider.
+type UserIdentity struct {
+	Provider string             `json:"provider"`
+	Subject  string             `json:"subject"`
+	Email    string             `json:"email"`
+	LinkedAt primitive.DateTime `json:"linkedAt"`
 }
 
 // 0 => SuperAdmin
@@ -36,11 +70,42 @@ type UserRole struct {
 	Since primitive.DateTime `json:"since"`
 }
 
+// Get the user's highest role level, the lowest number, it's false
+// when the user has no role.
+func (user *UserModel) GetTopRoleLevel() (level int, ok bool) {
+	for _, role := range user.Roles {
+		if !ok || role.Level < level {
+			level, ok = role.Level, true
+		}
+	}
+
+	return level, ok
+}
+
+// Check whether the user can impersonate the target, only the targets
+// below the user's level can be impersonated, never the SuperAdmin.
+func (user *UserModel) CanImpersonate(target *UserModel) (can bool) {
+	var (
+		level, hasRole             = user.GetTopRoleLevel()
+		targetLevel, targetHasRole = target.GetTopRoleLevel()
+	)
+
+	switch {
+	case !hasRole || user.UID == target.UID:
+		return false
+	case !targetHasRole:
+		return true
+	default:
+		return targetLevel != RoleLevelSuperAdmin && targetLevel > level
+	}
+}
+
 func (user *UserModel) ToCommonModel() (commonModel UserCommonModel) {
 	return UserCommonModel{
 		UID:       user.UID,
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		Username:  user.Username,
-		Email:     user.Email}
+		Email:     user.Email,
+		Avatar:    user.Avatar}
 }
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newTestUser(levels ...int) (user *UserModel) {
+	user = &UserModel{UID: primitive.NewObjectID()}
+	for _, level := range levels {
+		user.Roles = append(user.Roles, UserRole{Level: level})
+	}
+
+	return user
+}
+
+func TestUserCanImpersonate(t *testing.T) {
+	var (
+		superAdmin = newTestUser(RoleLevelSuperAdmin)
+		tests      = []struct {
+			name   string
+			user   *UserModel
+			target *UserModel
+			can    bool
+		}{{
+			name:   "superadmin impersonating admin",
+			user:   superAdmin,
+			target: newTestUser(RoleLevelAdmin),
+			can:    true,
+		}, {
+			name:   "superadmin impersonating user without role",
+			user:   superAdmin,
+			target: newTestUser(),
+			can:    true,
+		}, {
+			name:   "superadmin impersonating another superadmin",
+			user:   superAdmin,
+			target: newTestUser(RoleLevelSuperAdmin),
+			can:    false,
+		}, {
+			name:   "superadmin impersonating themselves",
+			user:   superAdmin,
+			target: superAdmin,
+			can:    false,
+		}, {
+			name:   "admin impersonating editor",
+			user:   newTestUser(RoleLevelAdmin),
+			target: newTestUser(RoleLevelEditor),
+			can:    true,
+		}, {
+			name:   "admin impersonating another admin",
+			user:   newTestUser(RoleLevelAdmin),
+			target: newTestUser(RoleLevelAdmin),
+			can:    false,
+		}, {
+			name:   "admin impersonating superadmin",
+			user:   newTestUser(RoleLevelAdmin),
+			target: newTestUser(RoleLevelSuperAdmin),
+			can:    false,
+		}, {
+			name:   "custom role impersonating writer",
+			user:   newTestUser(4),
+			target: newTestUser(RoleLevelWriter),
+			can:    false,
+		}, {
+			name:   "target's highest role counts",
+			user:   newTestUser(RoleLevelEditor),
+			target: newTestUser(RoleLevelWriter, RoleLevelAdmin),
+			can:    false,
+		}, {
+			name:   "user without role",
+			user:   newTestUser(),
+			target: newTestUser(),
+			can:    false,
+		}}
+	)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if can := test.user.CanImpersonate(test.target); can != test.can {
+				t.Errorf("CanImpersonate = %t, want %t", can, test.can)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+const auditLogCollection = "auditLogs"
+
+// Get multiple audit logs
+func ReadManyAuditLogs(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (auditLogs []*models.AuditLogModel, err error) {
+	var (
+		collection = dbConn.Collection(auditLogCollection)
+		auditLog   *models.AuditLogModel
+		cursor     *mongo.Cursor
+	)
+
+	if cursor, err = collection.Find(ctx, filter, opts...); err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		auditLog = &models.AuditLogModel{}
+		if err = cursor.Decode(auditLog); err != nil {
+			return nil, err
+		}
+		auditLogs = append(auditLogs, auditLog)
+	}
+
+	return auditLogs, nil
+}
+
+// Count total audit logs
+func CountAuditLogs(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.CountOptions,
+) (count int64, err error) {
+	var collection = dbConn.Collection(auditLogCollection)
+
+	return collection.CountDocuments(ctx, filter, opts...)
+}
+
+// Save new audit log, there's no update nor delete of audit logs
+func SaveOneAuditLog(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	auditLog *models.AuditLogModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var (
+		collection = dbConn.Collection(auditLogCollection)
+		insRes     *mongo.InsertOneResult
+		insertedID primitive.ObjectID
+		ok         bool
+	)
+
+	if insRes, err = collection.InsertOne(ctx, auditLog, opts...); err != nil {
+		return err
+	}
+	if insertedID, ok = insRes.InsertedID.(primitive.ObjectID); !ok {
+		return errors.New("unable to assert inserted uid")
+	}
+	if auditLog.UID != insertedID {
+		return errors.New("inserted uid is not same with database")
+	}
+
+	return nil
+}
@@ -119,6 +119,23 @@ func UpdateOneComment(
 	return err
 }
 
+// Bulk replace the author of the commented posts
+func UpdateManyCommentPostAuthor(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	fromUid primitive.ObjectID,
+	toUid primitive.ObjectID,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var collection = dbConn.Collection(commentCollection)
+
+	_, err = collection.UpdateMany(ctx,
+		bson.M{"postauthoruid": bson.M{"$eq": fromUid}},
+		bson.M{"$set": bson.M{"postauthoruid": toUid}}, opts...)
+
+	return err
+}
+
 // Delete comment
 func DeleteOneComment(
 	dbConn *mongo.Database,
@@ -0,0 +1,196 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+const (
+	dataExportCollection = "dataExports"
+	dataExportBucket     = "dataExportFiles"
+)
+
+// Get single data export
+func ReadOneDataExport(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (dataExport *models.DataExportModel, err error) {
+	var (
+		collection  = dbConn.Collection(dataExportCollection)
+		_dataExport models.DataExportModel
+	)
+
+	if err = collection.FindOne(ctx, filter, opts...).Decode(&_dataExport); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &_dataExport, nil
+}
+
+// Get multiple data exports
+func ReadManyDataExports(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (dataExports []*models.DataExportModel, err error) {
+	var (
+		collection = dbConn.Collection(dataExportCollection)
+		dataExport *models.DataExportModel
+		cursor     *mongo.Cursor
+	)
+
+	if cursor, err = collection.Find(ctx, filter, opts...); err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		dataExport = &models.DataExportModel{}
+		if err = cursor.Decode(dataExport); err != nil {
+			return nil, err
+		}
+		dataExports = append(dataExports, dataExport)
+	}
+
+	return dataExports, nil
+}
+
+// Save new data export
+func SaveOneDataExport(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	dataExport *models.DataExportModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var (
+		collection = dbConn.Collection(dataExportCollection)
+		insRes     *mongo.InsertOneResult
+		insertedID primitive.ObjectID
+		ok         bool
+	)
+
+	if insRes, err = collection.InsertOne(ctx, dataExport, opts...); err != nil {
+		return err
+	}
+	if insertedID, ok = insRes.InsertedID.(primitive.ObjectID); !ok {
+		return errors.New("unable to assert inserted uid")
+	}
+	if dataExport.UID != insertedID {
+		return errors.New("inserted uid is not same with database")
+	}
+
+	return nil
+}
+
+// Update data export
+func UpdateOneDataExport(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	dataExport *models.DataExportModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var collection = dbConn.Collection(dataExportCollection)
+
+	_, err = collection.UpdateOne(
+		ctx, bson.M{"_id": dataExport.UID}, bson.M{"$set": dataExport}, opts...)
+
+	return err
+}
+
+// Delete multiple data exports
+func DeleteManyDataExports(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.DeleteOptions,
+) (err error) {
+	var collection = dbConn.Collection(dataExportCollection)
+
+	_, err = collection.DeleteMany(ctx, filter, opts...)
+
+	return err
+}
+
+// Upload the data export's archive, returning the stored file's id
+func UploadOneDataExportFile(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	fileName string,
+	source io.Reader,
+) (fileID primitive.ObjectID, err error) {
+	var bucket *gridfs.Bucket
+
+	if bucket, err = getDataExportBucket(dbConn, ctx); err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	return bucket.UploadFromStream(fileName, source)
+}
+
+// Open the data export's archive for reading, the caller must close it
+func OpenOneDataExportFile(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	fileID interface{},
+) (stream *gridfs.DownloadStream, err error) {
+	var bucket *gridfs.Bucket
+
+	if bucket, err = getDataExportBucket(dbConn, ctx); err != nil {
+		return nil, err
+	}
+
+	return bucket.OpenDownloadStream(fileID)
+}
+
+// Delete the data export's archive, ignoring the already deleted one
+func DeleteOneDataExportFile(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	fileID interface{},
+) (err error) {
+	var bucket *gridfs.Bucket
+
+	if bucket, err = getDataExportBucket(dbConn, ctx); err != nil {
+		return err
+	}
+	if err = bucket.Delete(fileID); err != nil && err != gridfs.ErrFileNotFound {
+		return err
+	}
+
+	return nil
+}
+
+func getDataExportBucket(
+	dbConn *mongo.Database,
+	ctx context.Context,
+) (bucket *gridfs.Bucket, err error) {
+	if bucket, err = gridfs.NewBucket(
+		dbConn, options.GridFSBucket().SetName(dataExportBucket),
+	); err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = bucket.SetWriteDeadline(deadline); err != nil {
+			return nil, err
+		}
+		if err = bucket.SetReadDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	return bucket, nil
+}
@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+const invitationCollection = "invitations"
+
+// Get single invitation
+func ReadOneInvitation(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (invitation *models.InvitationModel, err error) {
+	var (
+		collection  = dbConn.Collection(invitationCollection)
+		_invitation models.InvitationModel
+	)
+
+	if err = collection.FindOne(ctx, filter, opts...).Decode(&_invitation); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &_invitation, nil
+}
+
+// Get multiple invitations
+func ReadManyInvitations(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (invitations []*models.InvitationModel, err error) {
+	var (
+		collection = dbConn.Collection(invitationCollection)
+		invitation *models.InvitationModel
+		cursor     *mongo.Cursor
+	)
+
+	if cursor, err = collection.Find(ctx, filter, opts...); err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		invitation = &models.InvitationModel{}
+		if err = cursor.Decode(invitation); err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	return invitations, nil
+}
+
+// Count total invitations
+func CountInvitations(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.CountOptions,
+) (count int64, err error) {
+	var collection = dbConn.Collection(invitationCollection)
+
+	return collection.CountDocuments(
+		ctx, filter, opts...)
+}
+
+// Save new invitation
+func SaveOneInvitation(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	invitation *models.InvitationModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var (
+		collection = dbConn.Collection(invitationCollection)
+		insRes     *mongo.InsertOneResult
+		insertedID primitive.ObjectID
+		ok         bool
+	)
+
+	if insRes, err = collection.InsertOne(ctx, invitation, opts...); err != nil {
+		return err
+	}
+	if insertedID, ok = insRes.InsertedID.(primitive.ObjectID); !ok {
+		return errors.New("unable to assert inserted uid")
+	}
+	if invitation.UID != insertedID {
+		return errors.New("inserted uid is not same with database")
+	}
+
+	return nil
+}
+
+// Update invitation
+func UpdateOneInvitation(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	invitation *models.InvitationModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var collection = dbConn.Collection(invitationCollection)
+
+	_, err = collection.UpdateOne(
+		ctx, bson.M{"_id": invitation.UID}, bson.M{"$set": invitation}, opts...)
+
+	return err
+}
+
+// Atomically mark single matching invitation as accepted,
+// returning the invitation data before it was marked
+func AcceptOneInvitation(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	acceptedAt primitive.DateTime,
+) (invitation *models.InvitationModel, err error) {
+	var (
+		collection  = dbConn.Collection(invitationCollection)
+		_invitation models.InvitationModel
+	)
+
+	if err = collection.FindOneAndUpdate(
+		ctx, filter, bson.M{"$set": bson.M{
+			"acceptedat": acceptedAt,
+			"updatedat":  acceptedAt}},
+	).Decode(&_invitation); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &_invitation, nil
+}
@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+const loginAttemptCollection = "loginAttempts"
+
+// Get single login attempt
+func ReadOneLoginAttempt(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (attempt *models.LoginAttemptModel, err error) {
+	var (
+		collection = dbConn.Collection(loginAttemptCollection)
+		_attempt   models.LoginAttemptModel
+	)
+
+	if err = collection.FindOne(ctx, filter, opts...).Decode(&_attempt); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &_attempt, nil
+}
+
+// Get multiple login attempts
+func ReadManyLoginAttempts(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (attempts []*models.LoginAttemptModel, err error) {
+	var (
+		collection = dbConn.Collection(loginAttemptCollection)
+		attempt    *models.LoginAttemptModel
+		cursor     *mongo.Cursor
+	)
+
+	if cursor, err = collection.Find(ctx, filter, opts...); err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		attempt = &models.LoginAttemptModel{}
+		if err = cursor.Decode(attempt); err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	return attempts, nil
+}
+
+// Atomically count another failed attempt of the key,
+// restarting the count when both the previous failure
+// & the previous lockout end are older than resetBefore
+func FailOneLoginAttempt(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	key string,
+	failedAt primitive.DateTime,
+	resetBefore primitive.DateTime,
+	expiresAt primitive.DateTime,
+) (attempt *models.LoginAttemptModel, err error) {
+	var (
+		collection = dbConn.Collection(loginAttemptCollection)
+		_attempt   models.LoginAttemptModel
+	)
+
+	if err = collection.FindOneAndUpdate(
+		ctx,
+		bson.M{"key": key},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{
+			"key": key,
+			"failures": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{
+					bson.M{"$max": bson.A{
+						bson.M{"$ifNull": bson.A{"$lastfailedat", primitive.DateTime(0)}},
+						bson.M{"$ifNull": bson.A{"$lockeduntil", primitive.DateTime(0)}}}},
+					resetBefore}},
+				bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$failures", 0}}, 1}},
+				1}},
+			"lastfailedat": failedAt,
+			"lockeduntil":  bson.M{"$ifNull": bson.A{"$lockeduntil", primitive.DateTime(0)}},
+			"expiresat": bson.M{"$max": bson.A{
+				bson.M{"$ifNull": bson.A{"$lockeduntil", primitive.DateTime(0)}},
+				expiresAt}}}}}},
+		options.FindOneAndUpdate().
+			SetUpsert(true).
+			SetReturnDocument(options.After),
+	).Decode(&_attempt); err != nil {
+		return nil, err
+	}
+
+	return &_attempt, nil
+}
+
+// Lock the key until given time
+func LockOneLoginAttempt(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	key string,
+	lockedUntil primitive.DateTime,
+) (err error) {
+	var collection = dbConn.Collection(loginAttemptCollection)
+
+	_, err = collection.UpdateOne(
+		ctx,
+		bson.M{"key": key},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{
+			"lockeduntil": lockedUntil,
+			"expiresat":   bson.M{"$max": bson.A{"$expiresat", lockedUntil}}}}}})
+
+	return err
+}
+
+// Delete multiple login attempts
+func DeleteManyLoginAttempts(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.DeleteOptions,
+) (err error) {
+	var collection = dbConn.Collection(loginAttemptCollection)
+
+	_, err = collection.DeleteMany(ctx, filter, opts...)
+
+	return err
+}
@@ -120,3 +120,17 @@ func DeleteOneNotification(
 
 	return err
 }
+
+// Delete multiple notifications
+func DeleteManyNotifications(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.DeleteOptions,
+) (err error) {
+	var collection = dbConn.Collection(notificationCollection)
+
+	_, err = collection.DeleteMany(ctx, filter, opts...)
+
+	return err
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+const oidcStateCollection = "oidcStates"
+
+// Save new OIDC state
+func SaveOneOidcState(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	state *models.OidcStateModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var (
+		collection = dbConn.Collection(oidcStateCollection)
+		insRes     *mongo.InsertOneResult
+		insertedID primitive.ObjectID
+		ok         bool
+	)
+
+	if insRes, err = collection.InsertOne(ctx, state, opts...); err != nil {
+		return err
+	}
+	if insertedID, ok = insRes.InsertedID.(primitive.ObjectID); !ok {
+		return errors.New("unable to assert inserted uid")
+	}
+	if state.UID != insertedID {
+		return errors.New("inserted uid is not same with database")
+	}
+
+	return nil
+}
+
+// Atomically take out single matching OIDC state,
+// so the state can't be used twice
+func TakeOneOidcState(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+) (state *models.OidcStateModel, err error) {
+	var (
+		collection = dbConn.Collection(oidcStateCollection)
+		_state     models.OidcStateModel
+	)
+
+	if err = collection.FindOneAndDelete(ctx, filter).Decode(&_state); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &_state, nil
+}
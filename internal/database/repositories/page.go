@@ -61,6 +61,37 @@ func ReadOnePageContent(
 	return &_pageContent, nil
 }
 
+// Get multiple page contents
+func ReadManyPageContents(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (
+	pageContents []*models.PageContentModel,
+	err error,
+) {
+	var (
+		collection  = dbConn.Collection(pageContentCollection)
+		pageContent *models.PageContentModel
+		cursor      *mongo.Cursor
+	)
+
+	if cursor, err = collection.Find(ctx, filter, opts...); err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		pageContent = &models.PageContentModel{}
+		if err = cursor.Decode(pageContent); err != nil {
+			return nil, err
+		}
+		pageContents = append(pageContents, pageContent)
+	}
+
+	return pageContents, nil
+}
+
 // Get multiple pages
 func ReadManyPages(
 	dbConn *mongo.Database,
@@ -171,6 +202,39 @@ func UpdateOnePage(
 	return err
 }
 
+// Bulk update page's author
+func UpdateManyPageAuthor(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	user *models.UserModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var collection = dbConn.Collection(pageCollection)
+
+	_, err = collection.UpdateMany(ctx,
+		bson.M{"author._id": bson.M{"$eq": user.UID}},
+		bson.M{"$set": bson.M{"author": user.ToCommonModel()}}, opts...)
+
+	return err
+}
+
+// Bulk replace the author of the user's pages
+func ReassignManyPageAuthor(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	userUid primitive.ObjectID,
+	author models.UserCommonModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var collection = dbConn.Collection(pageCollection)
+
+	_, err = collection.UpdateMany(ctx,
+		bson.M{"author._id": bson.M{"$eq": userUid}},
+		bson.M{"$set": bson.M{"author": author}}, opts...)
+
+	return err
+}
+
 // Update page content
 func UpdateOnePageContent(
 	dbConn *mongo.Database,
@@ -215,3 +279,27 @@ func DeleteOnePageContent(
 
 	return err
 }
+
+// Atomically mark single matching scheduled page as published,
+// returning whether any page was published
+func PublishOneScheduledPage(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	publishedAt primitive.DateTime,
+) (published bool, err error) {
+	var (
+		collection = dbConn.Collection(pageCollection)
+		updRes     *mongo.UpdateResult
+	)
+
+	if updRes, err = collection.UpdateOne(
+		ctx, filter, bson.M{"$set": bson.M{
+			"publishat":   nil,
+			"publishedat": publishedAt}},
+	); err != nil {
+		return false, err
+	}
+
+	return updRes.ModifiedCount > 0, nil
+}
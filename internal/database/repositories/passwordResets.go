@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+const passwordResetCollection = "passwordResets"
+
+// Get single password reset
+func ReadOnePasswordReset(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (reset *models.PasswordResetModel, err error) {
+	var (
+		collection = dbConn.Collection(passwordResetCollection)
+		_reset     models.PasswordResetModel
+	)
+
+	if err = collection.FindOne(ctx, filter, opts...).Decode(&_reset); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &_reset, nil
+}
+
+// Save new password reset
+func SaveOnePasswordReset(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	reset *models.PasswordResetModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var (
+		collection = dbConn.Collection(passwordResetCollection)
+		insRes     *mongo.InsertOneResult
+		insertedID primitive.ObjectID
+		ok         bool
+	)
+
+	if insRes, err = collection.InsertOne(ctx, reset, opts...); err != nil {
+		return err
+	}
+	if insertedID, ok = insRes.InsertedID.(primitive.ObjectID); !ok {
+		return errors.New("unable to assert inserted uid")
+	}
+	if reset.UID != insertedID {
+		return errors.New("inserted uid is not same with database")
+	}
+
+	return nil
+}
+
+// Atomically mark single matching password reset as used,
+// returning the password reset data before it was marked
+func UseOnePasswordReset(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	usedAt primitive.DateTime,
+) (reset *models.PasswordResetModel, err error) {
+	var (
+		collection = dbConn.Collection(passwordResetCollection)
+		_reset     models.PasswordResetModel
+	)
+
+	if err = collection.FindOneAndUpdate(
+		ctx, filter, bson.M{"$set": bson.M{"usedat": usedAt}},
+	).Decode(&_reset); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &_reset, nil
+}
+
+// Delete multiple password resets
+func DeleteManyPasswordResets(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.DeleteOptions,
+) (err error) {
+	var collection = dbConn.Collection(passwordResetCollection)
+
+	_, err = collection.DeleteMany(ctx, filter, opts...)
+
+	return err
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+const personalTokenCollection = "personalTokens"
+
+// Get single personal token
+func ReadOnePersonalToken(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (token *models.PersonalTokenModel, err error) {
+	var (
+		collection = dbConn.Collection(personalTokenCollection)
+		_token     models.PersonalTokenModel
+	)
+
+	if err = collection.FindOne(ctx, filter, opts...).Decode(&_token); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &_token, nil
+}
+
+// Get multiple personal tokens
+func ReadManyPersonalTokens(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (tokens []*models.PersonalTokenModel, err error) {
+	var (
+		collection = dbConn.Collection(personalTokenCollection)
+		token      *models.PersonalTokenModel
+		cursor     *mongo.Cursor
+	)
+
+	if cursor, err = collection.Find(ctx, filter, opts...); err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		token = &models.PersonalTokenModel{}
+		if err = cursor.Decode(token); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+// Save new personal token
+func SaveOnePersonalToken(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	token *models.PersonalTokenModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var (
+		collection = dbConn.Collection(personalTokenCollection)
+		insRes     *mongo.InsertOneResult
+		insertedID primitive.ObjectID
+		ok         bool
+	)
+
+	if insRes, err = collection.InsertOne(ctx, token, opts...); err != nil {
+		return err
+	}
+	if insertedID, ok = insRes.InsertedID.(primitive.ObjectID); !ok {
+		return errors.New("unable to assert inserted uid")
+	}
+	if token.UID != insertedID {
+		return errors.New("inserted uid is not same with database")
+	}
+
+	return nil
+}
+
+// Update personal token
+func UpdateOnePersonalToken(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	token *models.PersonalTokenModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var collection = dbConn.Collection(personalTokenCollection)
+
+	_, err = collection.UpdateOne(
+		ctx, bson.M{"_id": token.UID}, bson.M{"$set": token}, opts...)
+
+	return err
+}
+
+// Delete personal token
+func DeleteOnePersonalToken(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	token *models.PersonalTokenModel,
+	opts ...*options.DeleteOptions,
+) (err error) {
+	var collection = dbConn.Collection(personalTokenCollection)
+
+	_, err = collection.DeleteOne(
+		ctx, bson.M{"_id": token.UID}, opts...)
+
+	return err
+}
+
+// Delete multiple personal tokens
+func DeleteManyPersonalTokens(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.DeleteOptions,
+) (err error) {
+	var collection = dbConn.Collection(personalTokenCollection)
+
+	_, err = collection.DeleteMany(ctx, filter, opts...)
+
+	return err
+}
@@ -64,6 +64,37 @@ func ReadOnePostContent(
 	return &_postContent, nil
 }
 
+// Get multiple post contents
+func ReadManyPostContents(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (
+	postContents []*models.PostContentModel,
+	err error,
+) {
+	var (
+		collection  = dbConn.Collection(postContentCollection)
+		postContent *models.PostContentModel
+		cursor      *mongo.Cursor
+	)
+
+	if cursor, err = collection.Find(ctx, filter, opts...); err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		postContent = &models.PostContentModel{}
+		if err = cursor.Decode(postContent); err != nil {
+			return nil, err
+		}
+		postContents = append(postContents, postContent)
+	}
+
+	return postContents, nil
+}
+
 // Get multiple posts
 func ReadManyPosts(
 	dbConn *mongo.Database,
@@ -92,6 +123,36 @@ func ReadManyPosts(
 	return posts, nil
 }
 
+// Get the UIDs of the posts resulted by the aggregation pipeline, in its order
+func AggregatePostUids(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	pipeline interface{},
+	opts ...*options.AggregateOptions,
+) (postUids []primitive.ObjectID, err error) {
+	var (
+		collection = dbConn.Collection(postCollection)
+		cursor     *mongo.Cursor
+		result     struct {
+			UID primitive.ObjectID `bson:"_id"`
+		}
+	)
+
+	if cursor, err = collection.Aggregate(ctx, pipeline, opts...); err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	postUids = []primitive.ObjectID{}
+	for cursor.Next(ctx) {
+		if err = cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		postUids = append(postUids, result.UID)
+	}
+
+	return postUids, nil
+}
+
 // Count total posts
 func CountPosts(
 	dbConn *mongo.Database,
@@ -190,6 +251,48 @@ func UpdateManyPostAuthor(
 	return err
 }
 
+// Bulk update the user's data credited in the posts' contributors,
+// replacing the user when the given one is another user
+func UpdateManyPostContributor(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	userUid primitive.ObjectID,
+	user models.UserCommonModel,
+) (err error) {
+	var collection = dbConn.Collection(postCollection)
+
+	_, err = collection.UpdateMany(ctx,
+		bson.M{"contributors._id": bson.M{"$eq": userUid}},
+		bson.M{"$set": bson.M{
+			"contributors.$[contributor]._id":       user.UID,
+			"contributors.$[contributor].username":  user.Username,
+			"contributors.$[contributor].email":     user.Email,
+			"contributors.$[contributor].firstname": user.FirstName,
+			"contributors.$[contributor].lastname":  user.LastName,
+			"contributors.$[contributor].avatar":    user.Avatar}},
+		options.Update().SetArrayFilters(options.ArrayFilters{
+			Filters: []interface{}{bson.M{"contributor._id": userUid}}}))
+
+	return err
+}
+
+// Bulk replace the author of the user's posts
+func ReassignManyPostAuthor(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	userUid primitive.ObjectID,
+	author models.UserCommonModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var collection = dbConn.Collection(postCollection)
+
+	_, err = collection.UpdateMany(ctx,
+		bson.M{"author._id": bson.M{"$eq": userUid}},
+		bson.M{"$set": bson.M{"author": author}}, opts...)
+
+	return err
+}
+
 // Update post content
 func UpdateOnePostContent(
 	dbConn *mongo.Database,
@@ -234,3 +337,38 @@ func DeleteOnePostContent(
 
 	return err
 }
+
+// Atomically mark single matching scheduled post as published
+// with the transition from its current status appended,
+// returning whether any post was published
+func PublishOneScheduledPost(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	publishedAt primitive.DateTime,
+	transition models.PostTransitionModel,
+) (published bool, err error) {
+	var (
+		collection = dbConn.Collection(postCollection)
+		updRes     *mongo.UpdateResult
+	)
+
+	if updRes, err = collection.UpdateOne(
+		ctx, filter, mongo.Pipeline{{{Key: "$set", Value: bson.M{
+			"publishat":   nil,
+			"publishedat": publishedAt,
+			"status":      transition.To,
+			"transitions": bson.M{"$concatArrays": bson.A{
+				bson.M{"$ifNull": bson.A{"$transitions", bson.A{}}},
+				bson.A{bson.M{
+					"from":      bson.M{"$ifNull": bson.A{"$status", models.PostStatusDraft}},
+					"to":        bson.M{"$literal": transition.To},
+					"note":      bson.M{"$literal": transition.Note},
+					"actor":     bson.M{"$literal": transition.Actor},
+					"createdat": bson.M{"$literal": transition.CreatedAt}}}}}}}}},
+	); err != nil {
+		return false, err
+	}
+
+	return updRes.ModifiedCount > 0, nil
+}
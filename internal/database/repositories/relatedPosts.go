@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+const relatedPostsCollection = "relatedPosts"
+
+// Get single cached related posts
+func ReadOneRelatedPosts(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (related *models.RelatedPostsModel, err error) {
+	var (
+		collection = dbConn.Collection(relatedPostsCollection)
+		_related   models.RelatedPostsModel
+	)
+
+	if err = collection.FindOne(ctx, filter, opts...).Decode(&_related); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &_related, nil
+}
+
+// Save the cached related posts, replacing the previous one of the post
+func ReplaceOneRelatedPosts(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	related *models.RelatedPostsModel,
+) (err error) {
+	var collection = dbConn.Collection(relatedPostsCollection)
+
+	_, err = collection.ReplaceOne(ctx,
+		bson.M{"_id": related.UID}, related,
+		options.Replace().SetUpsert(true))
+
+	return err
+}
+
+// Delete multiple cached related posts
+func DeleteManyRelatedPosts(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.DeleteOptions,
+) (err error) {
+	var collection = dbConn.Collection(relatedPostsCollection)
+
+	_, err = collection.DeleteMany(ctx, filter, opts...)
+
+	return err
+}
@@ -0,0 +1,262 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+const (
+	postRevisionCollection = "postRevisions"
+	pageRevisionCollection = "pageRevisions"
+)
+
+// Get single post revision
+func ReadOnePostRevision(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (revision *models.PostRevisionModel, err error) {
+	var (
+		collection = dbConn.Collection(postRevisionCollection)
+		_revision  models.PostRevisionModel
+	)
+
+	if err = collection.FindOne(ctx, filter, opts...).Decode(&_revision); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &_revision, nil
+}
+
+// Get multiple post revisions
+func ReadManyPostRevisions(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (revisions []*models.PostRevisionModel, err error) {
+	var (
+		collection = dbConn.Collection(postRevisionCollection)
+		revision   *models.PostRevisionModel
+		cursor     *mongo.Cursor
+	)
+
+	if cursor, err = collection.Find(ctx, filter, opts...); err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		revision = &models.PostRevisionModel{}
+		if err = cursor.Decode(revision); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+// Count total post revisions
+func CountPostRevisions(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.CountOptions,
+) (count int64, err error) {
+	var collection = dbConn.Collection(postRevisionCollection)
+
+	return collection.CountDocuments(
+		ctx, filter, opts...)
+}
+
+// Save new post revision
+func SaveOnePostRevision(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	revision *models.PostRevisionModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var (
+		collection = dbConn.Collection(postRevisionCollection)
+		insRes     *mongo.InsertOneResult
+		insertedID primitive.ObjectID
+		ok         bool
+	)
+
+	if insRes, err = collection.InsertOne(ctx, revision, opts...); err != nil {
+		return err
+	}
+	if insertedID, ok = insRes.InsertedID.(primitive.ObjectID); !ok {
+		return errors.New("unable to assert inserted uid")
+	}
+	if revision.UID != insertedID {
+		return errors.New("inserted uid is not same with database")
+	}
+
+	return nil
+}
+
+// Bulk update the user's data credited as the post revisions' editor,
+// replacing the user when the given one is another user
+func UpdateManyPostRevisionEditor(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	userUid primitive.ObjectID,
+	editor models.UserCommonModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var collection = dbConn.Collection(postRevisionCollection)
+
+	_, err = collection.UpdateMany(ctx,
+		bson.M{"editor._id": bson.M{"$eq": userUid}},
+		bson.M{"$set": bson.M{"editor": editor}}, opts...)
+
+	return err
+}
+
+// Delete multiple post revisions
+func DeleteManyPostRevisions(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.DeleteOptions,
+) (err error) {
+	var collection = dbConn.Collection(postRevisionCollection)
+
+	_, err = collection.DeleteMany(ctx, filter, opts...)
+
+	return err
+}
+
+// Get single page revision
+func ReadOnePageRevision(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (revision *models.PageRevisionModel, err error) {
+	var (
+		collection = dbConn.Collection(pageRevisionCollection)
+		_revision  models.PageRevisionModel
+	)
+
+	if err = collection.FindOne(ctx, filter, opts...).Decode(&_revision); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &_revision, nil
+}
+
+// Get multiple page revisions
+func ReadManyPageRevisions(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (revisions []*models.PageRevisionModel, err error) {
+	var (
+		collection = dbConn.Collection(pageRevisionCollection)
+		revision   *models.PageRevisionModel
+		cursor     *mongo.Cursor
+	)
+
+	if cursor, err = collection.Find(ctx, filter, opts...); err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		revision = &models.PageRevisionModel{}
+		if err = cursor.Decode(revision); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+// Count total page revisions
+func CountPageRevisions(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.CountOptions,
+) (count int64, err error) {
+	var collection = dbConn.Collection(pageRevisionCollection)
+
+	return collection.CountDocuments(
+		ctx, filter, opts...)
+}
+
+// Save new page revision
+func SaveOnePageRevision(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	revision *models.PageRevisionModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var (
+		collection = dbConn.Collection(pageRevisionCollection)
+		insRes     *mongo.InsertOneResult
+		insertedID primitive.ObjectID
+		ok         bool
+	)
+
+	if insRes, err = collection.InsertOne(ctx, revision, opts...); err != nil {
+		return err
+	}
+	if insertedID, ok = insRes.InsertedID.(primitive.ObjectID); !ok {
+		return errors.New("unable to assert inserted uid")
+	}
+	if revision.UID != insertedID {
+		return errors.New("inserted uid is not same with database")
+	}
+
+	return nil
+}
+
+// Bulk update the user's data credited as the page revisions' editor,
+// replacing the user when the given one is another user
+func UpdateManyPageRevisionEditor(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	userUid primitive.ObjectID,
+	editor models.UserCommonModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var collection = dbConn.Collection(pageRevisionCollection)
+
+	_, err = collection.UpdateMany(ctx,
+		bson.M{"editor._id": bson.M{"$eq": userUid}},
+		bson.M{"$set": bson.M{"editor": editor}}, opts...)
+
+	return err
+}
+
+// Delete multiple page revisions
+func DeleteManyPageRevisions(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.DeleteOptions,
+) (err error) {
+	var collection = dbConn.Collection(pageRevisionCollection)
+
+	_, err = collection.DeleteMany(ctx, filter, opts...)
+
+	return err
+}
@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+const roleCollection = "roles"
+
+// Get single role
+func ReadOneRole(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (role *models.RoleModel, err error) {
+	var (
+		collection = dbConn.Collection(roleCollection)
+		_role      models.RoleModel
+	)
+
+	if err = collection.FindOne(ctx, filter, opts...).Decode(&_role); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &_role, nil
+}
+
+// Get multiple roles
+func ReadManyRoles(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (roles []*models.RoleModel, err error) {
+	var (
+		collection = dbConn.Collection(roleCollection)
+		cursor     *mongo.Cursor
+		role       *models.RoleModel
+	)
+
+	if cursor, err = collection.Find(ctx, filter, opts...); err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		role = &models.RoleModel{}
+		if err = cursor.Decode(role); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+// Save new role
+func SaveOneRole(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	role *models.RoleModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var (
+		collection = dbConn.Collection(roleCollection)
+		insRes     *mongo.InsertOneResult
+		insertedID primitive.ObjectID
+		ok         bool
+	)
+
+	if insRes, err = collection.InsertOne(ctx, role, opts...); err != nil {
+		return err
+	}
+	if insertedID, ok = insRes.InsertedID.(primitive.ObjectID); !ok {
+		return errors.New("unable to assert inserted uid")
+	}
+	if role.UID != insertedID {
+		return errors.New("inserted uid is not same with database")
+	}
+
+	return nil
+}
+
+// Update role
+func UpdateOneRole(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	role *models.RoleModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var collection = dbConn.Collection(roleCollection)
+
+	_, err = collection.UpdateOne(
+		ctx, bson.M{"_id": role.UID}, bson.M{"$set": role}, opts...)
+
+	return err
+}
+
+// Delete role
+func DeleteOneRole(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	role *models.RoleModel,
+	opts ...*options.DeleteOptions,
+) (err error) {
+	var collection = dbConn.Collection(roleCollection)
+
+	_, err = collection.DeleteOne(
+		ctx, bson.M{"_id": role.UID}, opts...)
+
+	return err
+}
@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+const seriesCollection = "series"
+
+// Get single series
+func ReadOneSeries(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (series *models.SeriesModel, err error) {
+	var (
+		collection = dbConn.Collection(seriesCollection)
+		_series    models.SeriesModel
+	)
+
+	if err = collection.FindOne(ctx, filter, opts...).Decode(&_series); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &_series, nil
+}
+
+// Get multiple series
+func ReadManySeries(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (manySeries []*models.SeriesModel, err error) {
+	var (
+		collection = dbConn.Collection(seriesCollection)
+		cursor     *mongo.Cursor
+		series     *models.SeriesModel
+	)
+
+	if cursor, err = collection.Find(ctx, filter, opts...); err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		series = &models.SeriesModel{}
+		if err := cursor.Decode(series); err != nil {
+			return nil, err
+		}
+		manySeries = append(manySeries, series)
+	}
+
+	return manySeries, nil
+}
+
+// Count series
+func CountSeries(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.CountOptions,
+) (count int64, err error) {
+	var collection = dbConn.Collection(seriesCollection)
+
+	return collection.CountDocuments(
+		ctx, filter, opts...)
+}
+
+// Save new series
+func SaveOneSeries(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	series *models.SeriesModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var (
+		collection = dbConn.Collection(seriesCollection)
+		insRes     *mongo.InsertOneResult
+		insertedID primitive.ObjectID
+		ok         bool
+	)
+
+	if insRes, err = collection.InsertOne(ctx, series, opts...); err != nil {
+		return err
+	}
+	if insertedID, ok = insRes.InsertedID.(primitive.ObjectID); !ok {
+		return errors.New("unable to assert inserted uid")
+	}
+	if series.UID != insertedID {
+		return errors.New("inserted uid is not same with database")
+	}
+
+	return nil
+}
+
+// Update series
+func UpdateOneSeries(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	series *models.SeriesModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var collection = dbConn.Collection(seriesCollection)
+
+	_, err = collection.UpdateOne(
+		ctx, bson.M{"_id": series.UID}, bson.M{"$set": series}, opts...)
+
+	return err
+}
+
+// Insert the post into the series' posts at given position,
+// appended when the position is negative
+func PushSeriesPost(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	series *models.SeriesModel,
+	postUid primitive.ObjectID,
+	position int,
+	updatedAt interface{},
+) (err error) {
+	var (
+		collection = dbConn.Collection(seriesCollection)
+		push       = bson.M{"$each": bson.A{postUid}}
+	)
+
+	if position >= 0 {
+		push["$position"] = position
+	}
+	_, err = collection.UpdateOne(ctx,
+		bson.M{"_id": series.UID},
+		bson.M{
+			"$push": bson.M{"posts": push},
+			"$set":  bson.M{"updatedat": updatedAt}})
+
+	return err
+}
+
+// Remove the post from every series containing it
+func PullManySeriesPost(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	postUid primitive.ObjectID,
+	updatedAt interface{},
+) (err error) {
+	var collection = dbConn.Collection(seriesCollection)
+
+	_, err = collection.UpdateMany(ctx,
+		bson.M{"posts": bson.M{"$eq": postUid}},
+		bson.M{
+			"$pull": bson.M{"posts": postUid},
+			"$set":  bson.M{"updatedat": updatedAt}})
+
+	return err
+}
+
+// Delete series
+func DeleteOneSeries(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	series *models.SeriesModel,
+	opts ...*options.DeleteOptions,
+) (err error) {
+	var collection = dbConn.Collection(seriesCollection)
+
+	_, err = collection.DeleteOne(
+		ctx, bson.M{"_id": series.UID}, opts...)
+
+	return err
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+const sessionCollection = "sessions"
+
+// Get single session
+func ReadOneSession(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (session *models.SessionModel, err error) {
+	var (
+		collection = dbConn.Collection(sessionCollection)
+		_session   models.SessionModel
+	)
+
+	if err = collection.FindOne(ctx, filter, opts...).Decode(&_session); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &_session, nil
+}
+
+// Get multiple sessions
+func ReadManySessions(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (sessions []*models.SessionModel, err error) {
+	var (
+		collection = dbConn.Collection(sessionCollection)
+		session    *models.SessionModel
+		cursor     *mongo.Cursor
+	)
+
+	if cursor, err = collection.Find(ctx, filter, opts...); err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		session = &models.SessionModel{}
+		if err = cursor.Decode(session); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+// Save new session
+func SaveOneSession(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	session *models.SessionModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var (
+		collection = dbConn.Collection(sessionCollection)
+		insRes     *mongo.InsertOneResult
+		insertedID primitive.ObjectID
+		ok         bool
+	)
+
+	if insRes, err = collection.InsertOne(ctx, session, opts...); err != nil {
+		return err
+	}
+	if insertedID, ok = insRes.InsertedID.(primitive.ObjectID); !ok {
+		return errors.New("unable to assert inserted uid")
+	}
+	if session.UID != insertedID {
+		return errors.New("inserted uid is not same with database")
+	}
+
+	return nil
+}
+
+// Update session
+func UpdateOneSession(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	session *models.SessionModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var collection = dbConn.Collection(sessionCollection)
+
+	_, err = collection.UpdateOne(
+		ctx, bson.M{"_id": session.UID}, bson.M{"$set": session}, opts...)
+
+	return err
+}
+
+// Delete session
+func DeleteOneSession(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	session *models.SessionModel,
+	opts ...*options.DeleteOptions,
+) (err error) {
+	var collection = dbConn.Collection(sessionCollection)
+
+	_, err = collection.DeleteOne(
+		ctx, bson.M{"_id": session.UID}, opts...)
+
+	return err
+}
+
+// Delete multiple sessions
+func DeleteManySessions(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.DeleteOptions,
+) (err error) {
+	var collection = dbConn.Collection(sessionCollection)
+
+	_, err = collection.DeleteMany(ctx, filter, opts...)
+
+	return err
+}
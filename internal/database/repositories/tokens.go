@@ -120,3 +120,17 @@ func DeleteOneRevokedToken(
 
 	return err
 }
+
+// Delete multiple revoked tokens
+func DeleteManyRevokedTokens(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.DeleteOptions,
+) (err error) {
+	var collection = dbConn.Collection(revokedTokenCollection)
+
+	_, err = collection.DeleteMany(ctx, filter, opts...)
+
+	return err
+}
@@ -119,6 +119,45 @@ func UpdateOneUser(
 	return err
 }
 
+// Apply update document to single user matching the filter,
+// returning whether any user was matched
+func UpdateOneUserConditionally(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	update interface{},
+	opts ...*options.UpdateOptions,
+) (matched bool, err error) {
+	var (
+		collection = dbConn.Collection(userCollection)
+		updRes     *mongo.UpdateResult
+	)
+
+	if updRes, err = collection.UpdateOne(
+		ctx, filter, update, opts...,
+	); err != nil {
+		return false, err
+	}
+
+	return updRes.MatchedCount > 0, nil
+}
+
+// Apply update document to multiple users matching the filter
+func UpdateManyUsers(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	update interface{},
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var collection = dbConn.Collection(userCollection)
+
+	_, err = collection.UpdateMany(
+		ctx, filter, update, opts...)
+
+	return err
+}
+
 // Delete user
 func DeleteOneUser(
 	dbConn *mongo.Database,
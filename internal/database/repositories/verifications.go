@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+const emailVerificationCollection = "emailVerifications"
+
+// Get single email verification
+func ReadOneEmailVerification(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (verification *models.EmailVerificationModel, err error) {
+	var (
+		collection    = dbConn.Collection(emailVerificationCollection)
+		_verification models.EmailVerificationModel
+	)
+
+	if err = collection.FindOne(ctx, filter, opts...).Decode(&_verification); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &_verification, nil
+}
+
+// Save new email verification
+func SaveOneEmailVerification(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	verification *models.EmailVerificationModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var (
+		collection = dbConn.Collection(emailVerificationCollection)
+		insRes     *mongo.InsertOneResult
+		insertedID primitive.ObjectID
+		ok         bool
+	)
+
+	if insRes, err = collection.InsertOne(ctx, verification, opts...); err != nil {
+		return err
+	}
+	if insertedID, ok = insRes.InsertedID.(primitive.ObjectID); !ok {
+		return errors.New("unable to assert inserted uid")
+	}
+	if verification.UID != insertedID {
+		return errors.New("inserted uid is not same with database")
+	}
+
+	return nil
+}
+
+// Atomically mark single matching email verification as used,
+// returning the verification data before it was marked
+func UseOneEmailVerification(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	usedAt primitive.DateTime,
+) (verification *models.EmailVerificationModel, err error) {
+	var (
+		collection    = dbConn.Collection(emailVerificationCollection)
+		_verification models.EmailVerificationModel
+	)
+
+	if err = collection.FindOneAndUpdate(
+		ctx, filter, bson.M{"$set": bson.M{"usedat": usedAt}},
+	).Decode(&_verification); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &_verification, nil
+}
+
+// Delete multiple email verifications
+func DeleteManyEmailVerifications(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.DeleteOptions,
+) (err error) {
+	var collection = dbConn.Collection(emailVerificationCollection)
+
+	_, err = collection.DeleteMany(ctx, filter, opts...)
+
+	return err
+}
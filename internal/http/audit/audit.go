@@ -0,0 +1,180 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+const (
+	TargetTypeUser       = "user"
+	TargetTypePost       = "post"
+	TargetTypePage       = "page"
+	TargetTypeCategory   = "category"
+	TargetTypeSeries     = "series"
+	TargetTypeComment    = "comment"
+	TargetTypeInvitation = "invitation"
+	TargetTypeRoute      = "route"
+)
+
+// Record the action done by the authenticated user, meant to be deferred
+// by the handler so the outcome is taken from the written response.
+func Record(
+	ctx context.Context,
+	c *gin.Context,
+	svc *service.Service,
+	action string,
+	target models.AuditTargetModel,
+) {
+	var actor *models.UserModel
+
+	if user, err := authenticate.GetAuthenticatedUser(c); err == nil {
+		actor = user
+	} else if user, err := authenticate.GetRefreshedUser(c); err == nil {
+		actor = user
+	}
+
+	RecordActor(ctx, c, svc, action, actor, target)
+}
+
+// Record the action done by given actor, nil when it's unknown.
+// Failing to save the log never fails the action itself,
+// the error is only attached to the gin context.
+func RecordActor(
+	ctx context.Context,
+	c *gin.Context,
+	svc *service.Service,
+	action string,
+	actor *models.UserModel,
+	target models.AuditTargetModel,
+) {
+	var (
+		status   = c.Writer.Status()
+		auditLog = &models.AuditLogModel{
+			Action:    action,
+			Target:    target,
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+			Outcome:   models.AuditOutcomeSuccess,
+			Status:    status}
+	)
+
+	if actor != nil {
+		commonModel := actor.ToCommonModel()
+		auditLog.Actor = &commonModel
+	}
+	if impersonator, isImpersonating := authenticate.GetImpersonator(c); isImpersonating {
+		commonModel := impersonator.ToCommonModel()
+		auditLog.Impersonator = &commonModel
+	}
+	if status >= http.StatusBadRequest {
+		auditLog.Outcome = models.AuditOutcomeFailure
+	}
+	if err := svc.AuditLog.SaveOne(ctx, auditLog); err != nil {
+		c.Error(err)
+	}
+}
+
+// Record every request made while impersonating once it's handled,
+// meant to be used right after the authentication middleware.
+func RecordImpersonation(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+	return func(c *gin.Context) {
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+			user   *models.UserModel
+			err    error
+		)
+
+		c.Next()
+		if _, isImpersonating := authenticate.GetImpersonator(c); !isImpersonating {
+			return
+		}
+		if user, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			return
+		}
+		ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+		defer cancel()
+		RecordActor(ctx, c, svc, models.AuditActionImpersonatedRequest, user, RouteTarget(c))
+	}
+}
+
+func UserTarget(uid string, user *models.UserModel) (target models.AuditTargetModel) {
+	target = models.AuditTargetModel{Type: TargetTypeUser, UID: uid}
+	if user != nil {
+		target.UID = user.UID.Hex()
+		target.Name = user.Username
+	}
+
+	return target
+}
+
+func PostTarget(uid string, post *models.PostModel) (target models.AuditTargetModel) {
+	target = models.AuditTargetModel{Type: TargetTypePost, UID: uid}
+	if post != nil {
+		target.Name = post.Title
+	}
+
+	return target
+}
+
+func PageTarget(uid string, page *models.PageModel) (target models.AuditTargetModel) {
+	target = models.AuditTargetModel{Type: TargetTypePage, UID: uid}
+	if page != nil {
+		target.Name = page.Title
+	}
+
+	return target
+}
+
+func CategoryTarget(uid string, category *models.CategoryModel) (target models.AuditTargetModel) {
+	target = models.AuditTargetModel{Type: TargetTypeCategory, UID: uid}
+	if category != nil {
+		target.Name = category.Name
+	}
+
+	return target
+}
+
+func SeriesTarget(uid string, series *models.SeriesModel) (target models.AuditTargetModel) {
+	target = models.AuditTargetModel{Type: TargetTypeSeries, UID: uid}
+	if series != nil {
+		target.Name = series.Title
+	}
+
+	return target
+}
+
+func CommentTarget(uid string, comment *models.CommentModel) (target models.AuditTargetModel) {
+	target = models.AuditTargetModel{Type: TargetTypeComment, UID: uid}
+	if comment != nil {
+		target.Name = comment.Name
+	}
+
+	return target
+}
+
+func InvitationTarget(uid string, invitation *models.InvitationModel) (target models.AuditTargetModel) {
+	target = models.AuditTargetModel{Type: TargetTypeInvitation, UID: uid}
+	if invitation != nil {
+		target.UID = invitation.UID.Hex()
+		target.Name = invitation.Email
+	}
+
+	return target
+}
+
+func RouteTarget(c *gin.Context) (target models.AuditTargetModel) {
+	return models.AuditTargetModel{
+		Type: TargetTypeRoute,
+		Name: c.Request.Method + " " + c.Request.URL.Path}
+}
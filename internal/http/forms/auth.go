@@ -8,3 +8,8 @@ type SignInForm struct {
 type PasswordConfirmForm struct {
 	Password string `json:"password" binding:"required"`
 }
+
+type OidcCallbackForm struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+}
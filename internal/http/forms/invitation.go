@@ -0,0 +1,144 @@
+package forms
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/pkg/hash"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+type CreateInvitationForm struct {
+	Email     string `json:"email" binding:"required,email"`
+	FirstName string `json:"firstName" binding:"max=50"`
+	LastName  string `json:"lastName" binding:"max=50"`
+	Roles     []int  `json:"roles" binding:"required,min=1,dive,number"`
+
+	roles []*models.RoleModel
+}
+
+type AcceptInvitationForm struct {
+	Token           string `json:"token" binding:"required"`
+	FirstName       string `json:"firstName" binding:"max=50"`
+	LastName        string `json:"lastName" binding:"max=50"`
+	Username        string `json:"username" binding:"required,alphanum,min=5,max=16"`
+	Password        string `json:"password" binding:"required,min=8,max=32"`
+	PasswordConfirm string `json:"passwordConfirm" binding:"required,min=8,max=32"`
+
+	roles []*models.RoleModel
+}
+
+func (form *CreateInvitationForm) Validate(
+	svc *service.Service,
+	ctx context.Context,
+	inviter *models.UserModel,
+) (err error) {
+	var invitation *models.InvitationModel
+
+	if form.roles, err = getProperRoles(svc, ctx, inviter, form.Roles); err != nil {
+		return err
+	}
+	if err = checkEmail(svc, ctx, form.Email); err != nil {
+		return err
+	}
+	if invitation, err = svc.Invitation.GetOne(ctx, bson.M{
+		"$and": []bson.M{
+			{"email": bson.M{"$eq": form.Email}},
+			svc.Invitation.PendingFilter()}},
+	); err != nil {
+		return err
+	}
+	if invitation != nil {
+		return errors.New("email already invited")
+	}
+
+	return nil
+}
+
+func (form *CreateInvitationForm) ToInvitationModel(
+	inviter *models.UserModel,
+) (invitation *models.InvitationModel) {
+
+	return &models.InvitationModel{
+		Email:     form.Email,
+		FirstName: form.FirstName,
+		LastName:  form.LastName,
+		Roles:     getRoles(form.roles, primitive.NewDateTimeFromTime(time.Now())),
+		InvitedBy: inviter.ToCommonModel()}
+}
+
+// The invited roles are checked again against the inviter's current roles,
+// since they may have changed after the invitation was sent.
+// The invited roles deleted in the meantime aren't granted.
+func (form *AcceptInvitationForm) Validate(
+	svc *service.Service,
+	ctx context.Context,
+	invitation *models.InvitationModel,
+) (err error) {
+	var (
+		inviter *models.UserModel
+		levels  = []int{}
+	)
+
+	if strings.Compare(form.Password, form.PasswordConfirm) != 0 {
+		return errors.New("password confirm not same")
+	}
+	if err = checkUsername(svc, ctx, form.Username); err != nil {
+		return err
+	}
+	if inviter, err = svc.User.GetOne(ctx, bson.M{"$and": []bson.M{
+		{"_id": bson.M{"$eq": invitation.InvitedBy.UID}},
+		{"deletedat": bson.M{"$eq": primitive.Null{}}}}},
+	); err != nil {
+		return err
+	}
+	if inviter == nil {
+		return errors.New("the inviter can no longer grant the invited roles")
+	}
+	for _, role := range invitation.Roles {
+		levels = append(levels, role.Level)
+	}
+	if form.roles, err = svc.Role.GetManyByLevels(ctx, levels); err != nil {
+		return err
+	}
+
+	return checkGrantableRoles(svc, ctx, inviter, form.roles)
+}
+
+// The email is taken as verified since the token was sent there.
+func (form *AcceptInvitationForm) ToUserModel(
+	invitation *models.InvitationModel,
+) (user *models.UserModel, err error) {
+	var (
+		now       = primitive.NewDateTimeFromTime(time.Now())
+		password  string
+		firstName = invitation.FirstName
+		lastName  = invitation.LastName
+	)
+
+	if password, err = hash.Make(form.Password); err != nil {
+		return nil, err
+	}
+	if form.FirstName != "" {
+		firstName = form.FirstName
+	}
+	if form.LastName != "" {
+		lastName = form.LastName
+	}
+
+	return &models.UserModel{
+		FirstName:  firstName,
+		LastName:   lastName,
+		Username:   form.Username,
+		Email:      invitation.Email,
+		Password:   password,
+		Roles:      getRoles(form.roles, now),
+		VerifiedAt: now,
+	}, nil
+}
@@ -5,7 +5,7 @@ import (
 	"strings"
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
-	"github.com/misterabdul/goblog-server/pkg/hash"
+	"github.com/misterabdul/goblog-server/internal/pkg/hash"
 )
 
 type UpdateMePasswordForm struct {
@@ -12,6 +12,17 @@ type UpdateMeForm struct {
 	LastName  string `json:"lastname" binding:"omitempty,max=50"`
 	Username  string `json:"username" binding:"omitempty,min=5,max=16"`
 	Email     string `json:"email" binding:"omitempty,email"`
+	// The profile fields are cleared when they're given empty.
+	Bio     *string `json:"bio" binding:"omitempty,max=1000"`
+	Avatar  *string `json:"avatar" binding:"omitempty,max=255,eq=|url,eq=|startswith=https://|startswith=http://"`
+	Website *string `json:"website" binding:"omitempty,max=255,eq=|url,eq=|startswith=https://|startswith=http://"`
+
+	Socials []UpdateMeSocialForm `json:"socials" binding:"omitempty,max=10,dive"`
+}
+
+type UpdateMeSocialForm struct {
+	Network string `json:"network" binding:"required,alphanum,max=32"`
+	URL     string `json:"url" binding:"required,url,startswith=https://|startswith=http://,max=255"`
 }
 
 func (form *UpdateMeForm) Validate(
@@ -44,6 +55,23 @@ func (form *UpdateMeForm) ToUserModel(
 	if len(form.Email) > 0 {
 		me.Email = form.Email
 	}
+	if form.Bio != nil {
+		me.Bio = *form.Bio
+	}
+	if form.Avatar != nil {
+		me.Avatar = *form.Avatar
+	}
+	if form.Website != nil {
+		me.Website = *form.Website
+	}
+	if form.Socials != nil {
+		me.Socials = []models.UserSocial{}
+		for _, social := range form.Socials {
+			me.Socials = append(me.Socials, models.UserSocial{
+				Network: social.Network,
+				URL:     social.URL})
+		}
+	}
 
 	return me
 }
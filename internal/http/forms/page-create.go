@@ -14,10 +14,11 @@ import (
 )
 
 type CreatePageForm struct {
-	Slug       string `json:"slug" binding:"required,max=100"`
-	Title      string `json:"title" binding:"required,max=100"`
-	Content    string `json:"content" binding:"required"`
-	PublishNow bool   `json:"publishNow" binding:"omitempty"`
+	Slug       string     `json:"slug" binding:"required,max=100"`
+	Title      string     `json:"title" binding:"required,max=100"`
+	Content    string     `json:"content" binding:"required"`
+	PublishNow bool       `json:"publishNow" binding:"omitempty"`
+	PublishAt  *time.Time `json:"publishAt" binding:"omitempty"`
 }
 
 func (form *CreatePageForm) Validate(
@@ -26,6 +27,9 @@ func (form *CreatePageForm) Validate(
 ) (err error) {
 	var parsedUrl *url.URL
 
+	if err = checkPublishAt(form.PublishAt, form.PublishNow); err != nil {
+		return err
+	}
 	if parsedUrl, err = url.ParseRequestURI(form.Slug); err != nil {
 		return err
 	}
@@ -53,17 +57,17 @@ func (form *CreatePageForm) ToPageModel(author *models.UserModel) (
 	}
 
 	return &models.PageModel{
-			UID:         pageId,
-			Slug:        form.Slug,
-			Title:       form.Title,
-			Author:      author.ToCommonModel(),
-			PublishedAt: publishedAt,
-			CreatedAt:   now,
-			UpdatedAt:   now,
-			DeletedAt:   nil,
-		}, &models.PageContentModel{
-			UID:     pageId,
-			Content: form.Content}, nil
+		UID:         pageId,
+		Slug:        form.Slug,
+		Title:       form.Title,
+		Author:      author.ToCommonModel(),
+		PublishedAt: publishedAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		DeletedAt:   nil,
+	}, &models.PageContentModel{
+		UID:     pageId,
+		Content: form.Content}, nil
 }
 
 func checkPageSlug(
@@ -13,10 +13,11 @@ import (
 )
 
 type UpdatePageForm struct {
-	Slug       string `json:"slug" binding:"omitempty,max=100"`
-	Title      string `json:"title" binding:"omitempty,max=100"`
-	Content    string `json:"content" binding:"omitempty"`
-	PublishNow bool   `json:"publishNow" binding:"omitempty"`
+	Slug       string     `json:"slug" binding:"omitempty,max=100"`
+	Title      string     `json:"title" binding:"omitempty,max=100"`
+	Content    string     `json:"content" binding:"omitempty"`
+	PublishNow bool       `json:"publishNow" binding:"omitempty"`
+	PublishAt  *time.Time `json:"publishAt" binding:"omitempty"`
 }
 
 func (form *UpdatePageForm) Validate(
@@ -24,6 +25,12 @@ func (form *UpdatePageForm) Validate(
 	ctx context.Context,
 	page *models.PageModel,
 ) (err error) {
+	if err = checkPublishAt(form.PublishAt, form.PublishNow); err != nil {
+		return err
+	}
+	if form.PublishAt != nil && page.PublishedAt != nil {
+		return errors.New("page already published")
+	}
 	if err = checkUpdatePageSlug(svc, ctx, page, form.Slug); err != nil {
 		return err
 	}
@@ -53,6 +60,7 @@ func (form *UpdatePageForm) ToPageModel(
 		pageContent.Content = form.Content
 	}
 	if form.PublishNow {
+		page.PublishAt = nil
 		page.PublishedAt = now
 	}
 	page.UpdatedAt = now
@@ -0,0 +1,40 @@
+package forms
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/pkg/hash"
+)
+
+type ForgotPasswordForm struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordForm struct {
+	Token           string `json:"token" binding:"required"`
+	Password        string `json:"password" binding:"required,min=8,max=32"`
+	PasswordConfirm string `json:"passwordConfirm" binding:"required,min=8,max=32"`
+}
+
+func (form *ResetPasswordForm) Validate() (err error) {
+	if strings.Compare(form.Password, form.PasswordConfirm) != 0 {
+		return errors.New("password confirm not same")
+	}
+
+	return nil
+}
+
+func (form *ResetPasswordForm) ToUserModel(
+	user *models.UserModel,
+) (updatedUser *models.UserModel, err error) {
+	var password string
+
+	if password, err = hash.Make(form.Password); err != nil {
+		return nil, err
+	}
+	user.Password = password
+
+	return user, nil
+}
@@ -0,0 +1,57 @@
+package forms
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+type CreatePersonalTokenForm struct {
+	Name      string   `json:"name" binding:"required,max=100"`
+	ExpiresIn int      `json:"expiresIn" binding:"required,min=1,max=366"`
+	Scopes    []string `json:"scopes" binding:"required,min=1,dive,required"`
+}
+
+func (form *CreatePersonalTokenForm) Validate() (err error) {
+	for _, scope := range form.Scopes {
+		if !contains(models.PersonalTokenScopes, scope) {
+			return errors.New("unknown scope: " + scope)
+		}
+	}
+
+	return nil
+}
+
+func (form *CreatePersonalTokenForm) ToPersonalTokenModel(
+	owner *models.UserModel,
+) (model *models.PersonalTokenModel) {
+	var (
+		scopes    []string
+		expiresAt = time.Now().Add(time.Duration(form.ExpiresIn) * 24 * time.Hour)
+	)
+
+	for _, scope := range form.Scopes {
+		if !contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return &models.PersonalTokenModel{
+		Name:      form.Name,
+		Scopes:    scopes,
+		Owner:     owner.ToCommonModel(),
+		ExpiresAt: primitive.NewDateTimeFromTime(expiresAt)}
+}
+
+func contains(values []string, value string) (exist bool) {
+	for _, _value := range values {
+		if _value == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,91 @@
+package forms
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+type PostContributorForm struct {
+	User string `json:"user" binding:"required,len=24"`
+	Role string `json:"role" binding:"required,oneof=co-author editor"`
+}
+
+type UpdatePostContributorsForm struct {
+	Contributors []PostContributorForm `json:"contributors" binding:"omitempty,max=10,dive"`
+
+	realUsers map[primitive.ObjectID]*models.UserModel
+}
+
+func (form *UpdatePostContributorsForm) Validate(
+	svc *service.Service,
+	ctx context.Context,
+	target *models.PostModel,
+) (err error) {
+	var (
+		userUids  []primitive.ObjectID
+		userUid   primitive.ObjectID
+		users     []*models.UserModel
+		userExist = map[primitive.ObjectID]bool{}
+	)
+
+	for _, contributor := range form.Contributors {
+		if userUid, err = primitive.ObjectIDFromHex(contributor.User); err != nil {
+			return err
+		}
+		if userUid == target.Author.UID {
+			return errors.New("the author is already credited")
+		}
+		if userExist[userUid] {
+			return errors.New("the contributors must be unique")
+		}
+		userExist[userUid] = true
+		userUids = append(userUids, userUid)
+	}
+	form.realUsers = map[primitive.ObjectID]*models.UserModel{}
+	if len(userUids) == 0 {
+		return nil
+	}
+	if users, err = svc.User.GetMany(ctx, bson.M{
+		"$and": []bson.M{
+			{"deletedat": bson.M{"$eq": primitive.Null{}}},
+			{"_id": bson.M{"$in": userUids}}}},
+	); err != nil {
+		return err
+	}
+	if len(users) != len(userUids) {
+		return errors.New("couldn't find some of the contributors")
+	}
+	for _, user := range users {
+		form.realUsers[user.UID] = user
+	}
+
+	return nil
+}
+
+func (form *UpdatePostContributorsForm) ToContributors() (
+	contributors []models.PostContributorModel,
+	err error,
+) {
+	var userUid primitive.ObjectID
+
+	if form.realUsers == nil {
+		return nil, errors.New("validate the form first")
+	}
+	contributors = []models.PostContributorModel{}
+	for _, contributor := range form.Contributors {
+		if userUid, err = primitive.ObjectIDFromHex(contributor.User); err != nil {
+			return nil, err
+		}
+		contributors = append(contributors, models.PostContributorModel{
+			UserCommonModel: form.realUsers[userUid].ToCommonModel(),
+			Role:            contributor.Role})
+	}
+
+	return contributors, nil
+}
@@ -13,14 +13,15 @@ import (
 )
 
 type CreatePostForm struct {
-	Slug               string   `json:"slug" binding:"required,alphanum,max=100"`
-	Title              string   `json:"title" binding:"required,max=100"`
-	Description        string   `json:"description" binding:"omitempty,max=255"`
-	FeaturingImagePath string   `json:"featuringImagePath" binding:"omitempty,url"`
-	Categories         []string `json:"categories" binding:"required,dive,len=24"`
-	Tags               []string `json:"tags" binding:"omitempty,dive,alphanum,max=32"`
-	Content            string   `json:"content" binding:"required"`
-	PublishNow         bool     `json:"publishNow" binding:"omitempty"`
+	Slug               string     `json:"slug" binding:"required,alphanum,max=100"`
+	Title              string     `json:"title" binding:"required,max=100"`
+	Description        string     `json:"description" binding:"omitempty,max=255"`
+	FeaturingImagePath string     `json:"featuringImagePath" binding:"omitempty,url"`
+	Categories         []string   `json:"categories" binding:"required,dive,len=24"`
+	Tags               []string   `json:"tags" binding:"omitempty,dive,alphanum,max=32"`
+	Content            string     `json:"content" binding:"required"`
+	PublishNow         bool       `json:"publishNow" binding:"omitempty"`
+	PublishAt          *time.Time `json:"publishAt" binding:"omitempty"`
 
 	realCategories []*models.CategoryModel
 }
@@ -29,6 +30,9 @@ func (form *CreatePostForm) Validate(
 	svc *service.Service,
 	ctx context.Context,
 ) (err error) {
+	if err = checkPublishAt(form.PublishAt, form.PublishNow); err != nil {
+		return err
+	}
 	if err = checkPostSlug(svc, ctx, form.Slug); err != nil {
 		return err
 	}
@@ -65,21 +69,26 @@ func (form *CreatePostForm) ToPostModel(author *models.UserModel) (
 	}
 
 	return &models.PostModel{
-			UID:                postId,
-			Slug:               form.Slug,
-			Title:              form.Title,
-			Description:        form.Description,
-			FeaturingImagePath: form.FeaturingImagePath,
-			Categories:         categories,
-			Tags:               form.Tags,
-			Author:             author.ToCommonModel(),
-			PublishedAt:        publishedAt,
-			CreatedAt:          now,
-			UpdatedAt:          now,
-			DeletedAt:          nil,
-		}, &models.PostContentModel{
-			UID:     postId,
-			Content: form.Content}, nil
+		UID:                postId,
+		Slug:               form.Slug,
+		Title:              form.Title,
+		Description:        form.Description,
+		FeaturingImagePath: form.FeaturingImagePath,
+		Categories:         categories,
+		Tags:               form.Tags,
+		Author:             author.ToCommonModel(),
+		Contributors: []models.PostContributorModel{{
+			UserCommonModel: author.ToCommonModel(),
+			Role:            models.PostContributorRoleAuthor}},
+		Status:      models.PostStatusDraft,
+		Transitions: []models.PostTransitionModel{},
+		PublishedAt: publishedAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		DeletedAt:   nil,
+	}, &models.PostContentModel{
+		UID:     postId,
+		Content: form.Content}, nil
 }
 
 func checkPostSlug(
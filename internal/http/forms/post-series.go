@@ -0,0 +1,56 @@
+package forms
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// The position starts from 1, the post is appended to the series without it.
+type AttachPostSeriesForm struct {
+	Series   string `json:"series" binding:"required,len=24"`
+	Position int    `json:"position" binding:"omitempty,min=1"`
+
+	realSeries *models.SeriesModel
+}
+
+func (form *AttachPostSeriesForm) Validate(
+	svc *service.Service,
+	ctx context.Context,
+) (err error) {
+	var seriesUid primitive.ObjectID
+
+	if seriesUid, err = primitive.ObjectIDFromHex(form.Series); err != nil {
+		return err
+	}
+	if form.realSeries, err = svc.Series.GetOne(ctx, bson.M{
+		"$and": []bson.M{
+			{"deletedat": bson.M{"$eq": primitive.Null{}}},
+			{"_id": bson.M{"$eq": seriesUid}}}},
+	); err != nil {
+		return err
+	}
+	if form.realSeries == nil {
+		return errors.New("couldn't find the series")
+	}
+
+	return nil
+}
+
+// Get the series & the post's index in it, negative to append.
+func (form *AttachPostSeriesForm) ToSeriesPosition() (
+	series *models.SeriesModel,
+	position int,
+	err error,
+) {
+	if form.realSeries == nil {
+		return nil, 0, errors.New("validate the form first")
+	}
+
+	return form.realSeries, form.Position - 1, nil
+}
@@ -13,14 +13,15 @@ import (
 )
 
 type UpdatePostForm struct {
-	Slug               string   `json:"slug" binding:"omitempty,alphanum,max=100"`
-	Title              string   `json:"title" binding:"omitempty,max=100"`
-	Description        string   `json:"description" binding:"omitempty,max=255"`
-	FeaturingImagePath string   `json:"featuringImagePath" binding:"omitempty,url"`
-	Categories         []string `json:"categories" binding:"omitempty,dive,len=24"`
-	Tags               []string `json:"tags" binding:"omitempty,dive,max=32"`
-	Content            string   `json:"content" binding:"omitempty"`
-	PublishNow         bool     `json:"publishNow" binding:"omitempty"`
+	Slug               string     `json:"slug" binding:"omitempty,alphanum,max=100"`
+	Title              string     `json:"title" binding:"omitempty,max=100"`
+	Description        string     `json:"description" binding:"omitempty,max=255"`
+	FeaturingImagePath string     `json:"featuringImagePath" binding:"omitempty,url"`
+	Categories         []string   `json:"categories" binding:"omitempty,dive,len=24"`
+	Tags               []string   `json:"tags" binding:"omitempty,dive,max=32"`
+	Content            string     `json:"content" binding:"omitempty"`
+	PublishNow         bool       `json:"publishNow" binding:"omitempty"`
+	PublishAt          *time.Time `json:"publishAt" binding:"omitempty"`
 
 	realCategories []*models.CategoryModel
 }
@@ -30,6 +31,12 @@ func (form *UpdatePostForm) Validate(
 	ctx context.Context,
 	target *models.PostModel,
 ) (err error) {
+	if err = checkPublishAt(form.PublishAt, form.PublishNow); err != nil {
+		return err
+	}
+	if form.PublishAt != nil && target.PublishedAt != nil {
+		return errors.New("post already published")
+	}
 	if err = checkUpdatePostSlug(svc, ctx, form.Slug, target); err != nil {
 		return err
 	}
@@ -81,6 +88,7 @@ func (form *UpdatePostForm) ToPostModel(
 		postContent.Content = form.Content
 	}
 	if form.PublishNow {
+		post.PublishAt = nil
 		post.PublishedAt = now
 	}
 	post.UpdatedAt = now
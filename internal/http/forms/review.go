@@ -0,0 +1,9 @@
+package forms
+
+type PostReviewForm struct {
+	Note string `json:"note" binding:"omitempty,max=1000"`
+}
+
+type PostChangesRequestForm struct {
+	Note string `json:"note" binding:"required,max=1000"`
+}
@@ -0,0 +1,88 @@
+package forms
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+type CreateRoleForm struct {
+	Name        string   `json:"name" binding:"required,alphanum,max=32"`
+	Description string   `json:"description" binding:"max=255"`
+	Permissions []string `json:"permissions" binding:"required,dive,max=64"`
+}
+
+func (form *CreateRoleForm) Validate(
+	svc *service.Service,
+	ctx context.Context,
+) (err error) {
+	if err = checkPermissions(form.Permissions); err != nil {
+		return err
+	}
+	if err = checkRoleName(svc, ctx, form.Name, nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (form *CreateRoleForm) ToRoleModel() (model *models.RoleModel) {
+	return &models.RoleModel{
+		Name:        form.Name,
+		Description: form.Description,
+		Permissions: uniquePermissions(form.Permissions)}
+}
+
+func checkPermissions(formPermissions []string) (err error) {
+	var permissions = models.GetGrantablePermissions()
+
+	for _, permission := range formPermissions {
+		if contains(models.SuperAdminPermissions, permission) {
+			return errors.New("superadmin only permission: " + permission)
+		}
+		if !contains(permissions, permission) {
+			return errors.New("unknown permission: " + permission)
+		}
+	}
+
+	return nil
+}
+
+func uniquePermissions(formPermissions []string) (permissions []string) {
+	permissions = []string{}
+	for _, permission := range formPermissions {
+		if !contains(permissions, permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+
+	return permissions
+}
+
+func checkRoleName(
+	svc *service.Service,
+	ctx context.Context,
+	formName string,
+	target *models.RoleModel,
+) (err error) {
+	var (
+		roles  []*models.RoleModel
+		filter = []bson.M{{"name": bson.M{"$eq": formName}}}
+	)
+
+	if target != nil {
+		filter = append(filter, bson.M{"_id": bson.M{"$ne": target.UID}})
+	}
+	if roles, err = svc.Role.GetMany(ctx, bson.M{"$and": filter}); err != nil {
+		return err
+	}
+	if len(roles) > 0 {
+		return errors.New("role name exists")
+	}
+
+	return nil
+}
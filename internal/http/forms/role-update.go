@@ -0,0 +1,54 @@
+package forms
+
+import (
+	"context"
+	"errors"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+type UpdateRoleForm struct {
+	Name        string   `json:"name" binding:"omitempty,alphanum,max=32"`
+	Description string   `json:"description" binding:"omitempty,max=255"`
+	Permissions []string `json:"permissions" binding:"omitempty,dive,max=64"`
+}
+
+func (form *UpdateRoleForm) Validate(
+	svc *service.Service,
+	ctx context.Context,
+	target *models.RoleModel,
+) (err error) {
+	if target.Level == models.RoleLevelSuperAdmin {
+		return errors.New("cannot update the superadmin role")
+	}
+	if len(form.Name) > 0 && form.Name != target.Name {
+		if target.System {
+			return errors.New("cannot rename built-in role")
+		}
+		if err = checkRoleName(svc, ctx, form.Name, target); err != nil {
+			return err
+		}
+	}
+	if err = checkPermissions(form.Permissions); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (form *UpdateRoleForm) ToRoleModel(
+	role *models.RoleModel,
+) (updatedRole *models.RoleModel) {
+	if len(form.Name) > 0 {
+		role.Name = form.Name
+	}
+	if len(form.Description) > 0 {
+		role.Description = form.Description
+	}
+	if form.Permissions != nil {
+		role.Permissions = uniquePermissions(form.Permissions)
+	}
+
+	return role
+}
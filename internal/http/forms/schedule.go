@@ -0,0 +1,29 @@
+package forms
+
+import (
+	"errors"
+	"time"
+)
+
+type SchedulePublishForm struct {
+	PublishAt time.Time `json:"publishAt" binding:"required"`
+}
+
+func (form *SchedulePublishForm) Validate() (err error) {
+
+	return checkPublishAt(&form.PublishAt, false)
+}
+
+func checkPublishAt(publishAt *time.Time, publishNow bool) (err error) {
+	if publishAt == nil {
+		return nil
+	}
+	if publishNow {
+		return errors.New("can't publish now & schedule at the same time")
+	}
+	if !publishAt.After(time.Now()) {
+		return errors.New("publish time must be in the future")
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package forms
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+type CreateSeriesForm struct {
+	Slug        string   `json:"slug" binding:"required,alphanum,max=100"`
+	Title       string   `json:"title" binding:"required,max=100"`
+	Description string   `json:"description" binding:"omitempty,max=255"`
+	Posts       []string `json:"posts" binding:"omitempty,max=100,dive,len=24"`
+
+	realPosts []primitive.ObjectID
+}
+
+func (form *CreateSeriesForm) Validate(
+	svc *service.Service,
+	ctx context.Context,
+) (err error) {
+	if err = checkSeriesSlug(svc, ctx, form.Slug); err != nil {
+		return err
+	}
+	if form.realPosts, err = findSeriesPosts(svc, ctx, form.Posts); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (form *CreateSeriesForm) ToSeriesModel() (model *models.SeriesModel) {
+	return &models.SeriesModel{
+		UID:         primitive.NewObjectID(),
+		Slug:        form.Slug,
+		Title:       form.Title,
+		Description: form.Description,
+		Posts:       form.realPosts}
+}
+
+func checkSeriesSlug(
+	svc *service.Service,
+	ctx context.Context,
+	formSlug string,
+) (err error) {
+	var manySeries []*models.SeriesModel
+
+	if manySeries, err = svc.Series.GetMany(ctx, bson.M{
+		"slug": bson.M{"$eq": formSlug},
+	}); err != nil {
+		return err
+	}
+	if len(manySeries) > 0 {
+		return errors.New("slug exists")
+	}
+
+	return nil
+}
+
+// Resolve the series' posts keeping their order,
+// every post must be unique & not trashed
+func findSeriesPosts(
+	svc *service.Service,
+	ctx context.Context,
+	formPosts []string,
+) (postUids []primitive.ObjectID, err error) {
+	var (
+		postUid   primitive.ObjectID
+		postExist = map[primitive.ObjectID]bool{}
+		count     int64
+	)
+
+	postUids = []primitive.ObjectID{}
+	for _, formPost := range formPosts {
+		if postUid, err = primitive.ObjectIDFromHex(formPost); err != nil {
+			return nil, err
+		}
+		if postExist[postUid] {
+			return nil, errors.New("the posts must be unique")
+		}
+		postExist[postUid] = true
+		postUids = append(postUids, postUid)
+	}
+	if len(postUids) == 0 {
+		return postUids, nil
+	}
+	if count, err = svc.Post.Count(ctx, bson.M{
+		"$and": []bson.M{
+			{"deletedat": bson.M{"$eq": primitive.Null{}}},
+			{"_id": bson.M{"$in": postUids}}}},
+	); err != nil {
+		return nil, err
+	}
+	if count != int64(len(postUids)) {
+		return nil, errors.New("couldn't find some of the posts")
+	}
+
+	return postUids, nil
+}
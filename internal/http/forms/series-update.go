@@ -0,0 +1,82 @@
+package forms
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// The posts replace the series' parts & their order when given.
+type UpdateSeriesForm struct {
+	Slug        string    `json:"slug" binding:"omitempty,alphanum,max=100"`
+	Title       string    `json:"title" binding:"omitempty,max=100"`
+	Description string    `json:"description" binding:"omitempty,max=255"`
+	Posts       *[]string `json:"posts" binding:"omitempty,max=100,dive,len=24"`
+
+	realPosts []primitive.ObjectID
+}
+
+func (form *UpdateSeriesForm) Validate(
+	svc *service.Service,
+	ctx context.Context,
+	target *models.SeriesModel,
+) (err error) {
+	if len(form.Slug) > 0 {
+		if err = checkUpdateSeriesSlug(svc, ctx, form.Slug, target); err != nil {
+			return err
+		}
+	}
+	if form.Posts != nil {
+		if form.realPosts, err = findSeriesPosts(svc, ctx, *form.Posts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (form *UpdateSeriesForm) ToSeriesModel(
+	series *models.SeriesModel,
+) (updatedSeries *models.SeriesModel) {
+	if len(form.Slug) > 0 {
+		series.Slug = form.Slug
+	}
+	if len(form.Title) > 0 {
+		series.Title = form.Title
+	}
+	if len(form.Description) > 0 {
+		series.Description = form.Description
+	}
+	if form.realPosts != nil {
+		series.Posts = form.realPosts
+	}
+
+	return series
+}
+
+func checkUpdateSeriesSlug(
+	svc *service.Service,
+	ctx context.Context,
+	formSlug string,
+	target *models.SeriesModel,
+) (err error) {
+	var manySeries []*models.SeriesModel
+
+	if manySeries, err = svc.Series.GetMany(ctx, bson.M{
+		"$and": []bson.M{
+			{"_id": bson.M{"$ne": target.UID}},
+			{"slug": bson.M{"$eq": formSlug}}},
+	}); err != nil {
+		return err
+	}
+	if len(manySeries) > 0 {
+		return errors.New("slug exists")
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package forms
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/pkg/hash"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+type SignUpForm struct {
+	FirstName       string `json:"firstName" binding:"max=50"`
+	LastName        string `json:"lastName" binding:"max=50"`
+	Username        string `json:"username" binding:"required,alphanum,min=5,max=16"`
+	Email           string `json:"email" binding:"required,email"`
+	Password        string `json:"password" binding:"required,min=8,max=32"`
+	PasswordConfirm string `json:"passwordConfirm" binding:"required,min=8,max=32"`
+}
+
+type VerifyEmailForm struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ResendEmailVerificationForm struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+func (form *SignUpForm) Validate(
+	svc *service.Service,
+	ctx context.Context,
+) (err error) {
+	if strings.Compare(form.Password, form.PasswordConfirm) != 0 {
+		return errors.New("password confirm not same")
+	}
+	if err = checkUsername(svc, ctx, form.Username); err != nil {
+		return err
+	}
+	if err = checkEmail(svc, ctx, form.Email); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (form *SignUpForm) ToUserModel() (user *models.UserModel, err error) {
+	var (
+		now      = primitive.NewDateTimeFromTime(time.Now())
+		password string
+	)
+
+	if password, err = hash.Make(form.Password); err != nil {
+		return nil, err
+	}
+	return &models.UserModel{
+		UID:        primitive.NewObjectID(),
+		FirstName:  form.FirstName,
+		LastName:   form.LastName,
+		Username:   form.Username,
+		Email:      form.Email,
+		Password:   password,
+		Roles:      []models.UserRole{},
+		VerifiedAt: nil,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		DeletedAt:  nil,
+	}, nil
+}
@@ -0,0 +1,10 @@
+package forms
+
+type TwoFactorCodeForm struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type SignInTwoFactorForm struct {
+	MfaToken string `json:"mfaToken" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
@@ -3,6 +3,7 @@ package forms
 import (
 	"context"
 	"errors"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,8 +11,8 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/pkg/hash"
 	"github.com/misterabdul/goblog-server/internal/service"
-	"github.com/misterabdul/goblog-server/pkg/hash"
 )
 
 type CreateUserForm struct {
@@ -22,6 +23,8 @@ type CreateUserForm struct {
 	Password        string `json:"password" binding:"required,min=8,max=32"`
 	PasswordConfirm string `json:"passwordConfirm" binding:"required,min=8,max=32"`
 	Roles           []int  `json:"roles" binding:"omitempty,dive,number"`
+
+	roles []*models.RoleModel
 }
 
 func (form *CreateUserForm) Validate(
@@ -29,7 +32,7 @@ func (form *CreateUserForm) Validate(
 	ctx context.Context,
 	creator *models.UserModel,
 ) (err error) {
-	if err = isProperRoles(form.Roles); err != nil {
+	if form.roles, err = getProperRoles(svc, ctx, creator, form.Roles); err != nil {
 		return err
 	}
 	if strings.Compare(form.Password, form.PasswordConfirm) != 0 {
@@ -55,34 +58,91 @@ func (form *CreateUserForm) ToUserModel() (user *models.UserModel, err error) {
 		return nil, err
 	}
 	return &models.UserModel{
-		UID:       primitive.NewObjectID(),
-		FirstName: form.FirstName,
-		LastName:  form.LastName,
-		Username:  form.Username,
-		Email:     form.Email,
-		Password:  password,
-		Roles:     getRoles(form.Roles, now),
-		CreatedAt: now,
-		UpdatedAt: now,
-		DeletedAt: nil,
+		UID:        primitive.NewObjectID(),
+		FirstName:  form.FirstName,
+		LastName:   form.LastName,
+		Username:   form.Username,
+		Email:      form.Email,
+		Password:   password,
+		Roles:      getRoles(form.roles, now),
+		VerifiedAt: now,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		DeletedAt:  nil,
 	}, nil
 }
 
-func isProperRoles(createdRoles []int) (err error) {
-	var createdRoleLevel = 3
+// Get the roles of given levels which can be granted by the creator.
+func getProperRoles(
+	svc *service.Service,
+	ctx context.Context,
+	creator *models.UserModel,
+	levels []int,
+) (roles []*models.RoleModel, err error) {
+	if len(levels) == 0 {
+		return nil, nil
+	}
+	if roles, err = svc.Role.GetManyByLevels(ctx, levels); err != nil {
+		return nil, err
+	}
+	for _, level := range levels {
+		if !containsRole(roles, level) {
+			return nil, errors.New("unknown role: " + strconv.Itoa(level))
+		}
+	}
+	if err = checkGrantableRoles(svc, ctx, creator, roles); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// Only the roles below the granter's level, whose permissions are all
+// granted to the granter too, can be granted. The admin roles must be
+// granted by adminizing unless the granter is the SuperAdmin.
+func checkGrantableRoles(
+	svc *service.Service,
+	ctx context.Context,
+	granter *models.UserModel,
+	roles []*models.RoleModel,
+) (err error) {
+	var (
+		granterLevel, hasRole = granter.GetTopRoleLevel()
+		granterPermissions    []string
+	)
 
-	for _, createdRole := range createdRoles {
-		if createdRole < createdRoleLevel {
-			createdRoleLevel = createdRole
+	for _, role := range roles {
+		if role.Level <= models.RoleLevelAdmin && granterLevel != models.RoleLevelSuperAdmin {
+			return errors.New("cannot create admin role, you must adminize them later")
+		}
+		if !hasRole || role.Level <= granterLevel {
+			return errors.New("cannot grant role at or above your level: " + strconv.Itoa(role.Level))
 		}
 	}
-	if createdRoleLevel <= 1 {
-		return errors.New("cannot create admin role, you must adminize them later")
+	if granterPermissions, err = svc.Role.GetPermissions(ctx, granter); err != nil {
+		return err
+	}
+	for _, role := range roles {
+		for _, permission := range role.Permissions {
+			if !contains(granterPermissions, permission) {
+				return errors.New("cannot grant role with permission you don't have: " + role.Name)
+			}
+		}
 	}
 
 	return nil
 }
 
+func containsRole(roles []*models.RoleModel, level int) (exist bool) {
+	for _, role := range roles {
+		if role.Level == level {
+			return true
+		}
+	}
+
+	return false
+}
+
 func checkUsername(
 	svc *service.Service,
 	ctx context.Context,
@@ -121,46 +181,14 @@ func checkEmail(
 	return nil
 }
 
-func getRoles(formRoles []int, now primitive.DateTime) (roles []models.UserRole) {
-	var roleExists bool
-
+func getRoles(formRoles []*models.RoleModel, now primitive.DateTime) (roles []models.UserRole) {
 	roles = []models.UserRole{}
-	for _, roleNumber := range formRoles {
-		roleExists = false
-		for _, role := range roles {
-			if roleNumber == role.Level {
-				roleExists = true
-				break
-			}
-		}
-		if !roleExists {
-			switch roleNumber {
-			case 0:
-				roles = append(roles, models.UserRole{
-					Level: 0,
-					Name:  "SuperAdmin",
-					Since: now,
-				})
-			case 1:
-				roles = append(roles, models.UserRole{
-					Level: 1,
-					Name:  "Admin",
-					Since: now,
-				})
-			case 2:
-				roles = append(roles, models.UserRole{
-					Level: 2,
-					Name:  "Editor",
-					Since: now,
-				})
-			case 3:
-				roles = append(roles, models.UserRole{
-					Level: 3,
-					Name:  "Writer",
-					Since: now,
-				})
-			}
-		}
+	for _, role := range formRoles {
+		roles = append(roles, models.UserRole{
+			Level: role.Level,
+			Name:  role.Name,
+			Since: now,
+		})
 	}
 
 	return roles
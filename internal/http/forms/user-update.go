@@ -10,8 +10,8 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/pkg/hash"
 	"github.com/misterabdul/goblog-server/internal/service"
-	"github.com/misterabdul/goblog-server/pkg/hash"
 )
 
 type UpdateUserForm struct {
@@ -22,6 +22,8 @@ type UpdateUserForm struct {
 	Password        string `json:"password" binding:"omitempty,min=8,max=32"`
 	PasswordConfirm string `json:"passwordConfirm" binding:"omitempty,min=8,max=32"`
 	Roles           []int  `json:"roles" binding:"omitempty,dive,number"`
+
+	roles []*models.RoleModel
 }
 
 func (form *UpdateUserForm) Validate(
@@ -30,7 +32,7 @@ func (form *UpdateUserForm) Validate(
 	creator *models.UserModel,
 	target *models.UserModel,
 ) (err error) {
-	if err = isProperRoles(form.Roles); err != nil {
+	if form.roles, err = getProperRoles(svc, ctx, creator, form.Roles); err != nil {
 		return err
 	}
 	if strings.Compare(form.Password, form.PasswordConfirm) != 0 {
@@ -77,7 +79,7 @@ func (form *UpdateUserForm) ToUserModel(
 		user.Password = password
 	}
 	if len(form.Roles) > 0 {
-		user.Roles = getRoles(form.Roles, now)
+		user.Roles = getRoles(form.roles, now)
 	}
 
 	return user, nil
@@ -0,0 +1,171 @@
+package audits
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	internalGin "github.com/misterabdul/goblog-server/internal/pkg/gin"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// @Tags        Audit Log (SuperAdmin)
+// @Summary     Get Audit Logs
+// @Description Get the audit logs of authentication & administrative actions, newest first.
+// @Router      /v1/auth/superadmin/audit-logs [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       show    query    int    false "Number of data to be shown."
+// @Param       page    query    int    false "Selected page of data."
+// @Param       order   query    string false "Selected field to order data with."
+// @Param       asc     query    string false "Ascending or descending, e.g.: ?asc=false."
+// @Param       action  query    string false "Action, e.g.: ?action=auth.signin."
+// @Param       actor   query    string false "Actor's UID."
+// @Param       target  query    string false "Target's UID."
+// @Param       type    query    string false "Target's type, e.g.: ?type=post."
+// @Param       outcome query    string false "Outcome, either success or failure."
+// @Param       ip      query    string false "Client IP."
+// @Param       from    query    string false "Lower bound of the creation time, in RFC3339."
+// @Param       to      query    string false "Upper bound of the creation time, in RFC3339."
+// @Success     200     {object} object{data=[]object{uid=string,action=string,actor=object{uid=string,username=string,email=string,firstName=string,lastName=string},target=object{type=string,uid=string,name=string},ip=string,userAgent=string,outcome=string,status=int,createdAt=time.Time}}
+// @Success     204
+// @Failure     400     {object} object{message=string}
+// @Failure     401     {object} object{message=string}
+// @Failure     500     {object} object{message=string}
+func GetAuditLogs(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			auditLogs   []*models.AuditLogModel
+			filter      bson.M
+			err         error
+		)
+
+		defer cancel()
+		if filter, err = readQueryParams(c); err != nil {
+			responses.IncorrectAuditLogFilter(c, err)
+			return
+		}
+		if auditLogs, err = svc.AuditLog.GetMany(ctx, filter,
+			internalGin.GetFindOptions(c),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if len(auditLogs) == 0 {
+			responses.NoContent(c)
+			return
+		}
+
+		responses.AuditLogs(c, auditLogs)
+	}
+}
+
+// @Tags        Audit Log (SuperAdmin)
+// @Summary     Get Audit Logs Stats
+// @Description Get audit logs's stats.
+// @Router      /v1/auth/superadmin/audit-logs/stats [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       show    query    int    false "Number of data to be shown."
+// @Param       page    query    int    false "Selected page of data."
+// @Param       action  query    string false "Action, e.g.: ?action=auth.signin."
+// @Param       actor   query    string false "Actor's UID."
+// @Param       target  query    string false "Target's UID."
+// @Param       type    query    string false "Target's type, e.g.: ?type=post."
+// @Param       outcome query    string false "Outcome, either success or failure."
+// @Param       ip      query    string false "Client IP."
+// @Param       from    query    string false "Lower bound of the creation time, in RFC3339."
+// @Param       to      query    string false "Upper bound of the creation time, in RFC3339."
+// @Success     200     {object} object{data=object{currentPage=int,totalPages=int,itemsPerPage=int,totalItems=int}}
+// @Failure     400     {object} object{message=string}
+// @Failure     401     {object} object{message=string}
+// @Failure     500     {object} object{message=string}
+func GetAuditLogsStats(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			count       int64
+			filter      bson.M
+			err         error
+		)
+
+		defer cancel()
+		if filter, err = readQueryParams(c); err != nil {
+			responses.IncorrectAuditLogFilter(c, err)
+			return
+		}
+		if count, err = svc.AuditLog.Count(ctx, filter,
+			internalGin.GetCountOptions(c),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.ResourceStats(c, count)
+	}
+}
+
+func readQueryParams(c *gin.Context) (filter bson.M, err error) {
+	var (
+		actorUid primitive.ObjectID
+		from     time.Time
+		to       time.Time
+		created  = bson.M{}
+	)
+
+	filter = bson.M{}
+	if action := c.Query("action"); action != "" {
+		filter["action"] = bson.M{"$eq": action}
+	}
+	if actor := c.Query("actor"); actor != "" {
+		if actorUid, err = primitive.ObjectIDFromHex(actor); err != nil {
+			return nil, err
+		}
+		filter["actor._id"] = bson.M{"$eq": actorUid}
+	}
+	if target := c.Query("target"); target != "" {
+		filter["target.uid"] = bson.M{"$eq": target}
+	}
+	if targetType := c.Query("type"); targetType != "" {
+		filter["target.type"] = bson.M{"$eq": targetType}
+	}
+	if outcome := c.Query("outcome"); outcome != "" {
+		filter["outcome"] = bson.M{"$eq": outcome}
+	}
+	if ip := c.Query("ip"); ip != "" {
+		filter["ip"] = bson.M{"$eq": ip}
+	}
+	if from_s := c.Query("from"); from_s != "" {
+		if from, err = time.Parse(time.RFC3339, from_s); err != nil {
+			return nil, err
+		}
+		created["$gte"] = primitive.NewDateTimeFromTime(from)
+	}
+	if to_s := c.Query("to"); to_s != "" {
+		if to, err = time.Parse(time.RFC3339, to_s); err != nil {
+			return nil, err
+		}
+		created["$lte"] = primitive.NewDateTimeFromTime(to)
+	}
+	if len(created) > 0 {
+		filter["createdat"] = created
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,91 @@
+package authentications
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// @Tags        Authentication (Admin)
+// @Summary     Get Lockouts
+// @Description Get the accounts & client IPs currently locked out of signing in.
+// @Router      /v1/auth/admin/lockouts [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Success     200 {object} object{data=[]object{uid=string,key=string,failures=int,lastFailedAt=time.Time,lockedUntil=time.Time}}
+// @Failure     401 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func GetLockouts(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			attempts    []*models.LoginAttemptModel
+			err         error
+		)
+
+		defer cancel()
+		if attempts, err = svc.LoginAttempt.GetLocked(ctx); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.Lockouts(c, attempts)
+	}
+}
+
+// @Tags        Authentication (Admin)
+// @Summary     Delete Lockout
+// @Description Clear the failed sign in attempts & the lockout of an account or client IP.
+// @Router      /v1/auth/admin/lockout/{uid} [delete]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path string true "Lockout's UID"
+// @Success     204
+// @Failure     400 {object} object{message=string}
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func DeleteLockout(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel     = context.WithTimeout(context.Background(), maxCtxDuration)
+			lockoutUid      primitive.ObjectID
+			lockoutUidParam = c.Param("lockout")
+			cleared         bool
+			err             error
+		)
+
+		defer cancel()
+		if lockoutUid, err = primitive.ObjectIDFromHex(lockoutUidParam); err != nil {
+			responses.IncorrectLockoutId(c, err)
+			return
+		}
+		if cleared, err = svc.LoginAttempt.ClearOne(ctx, lockoutUid); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if !cleared {
+			responses.NotFound(c, errors.New("lockout not found"))
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
@@ -0,0 +1,410 @@
+package authentications
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+	"github.com/misterabdul/goblog-server/internal/http/requests"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	internalHash "github.com/misterabdul/goblog-server/internal/pkg/hash"
+	internalJwt "github.com/misterabdul/goblog-server/internal/pkg/jwt"
+	internalOidc "github.com/misterabdul/goblog-server/internal/pkg/oidc"
+	"github.com/misterabdul/goblog-server/internal/service"
+	"github.com/misterabdul/goblog-server/pkg/crypto"
+	"github.com/misterabdul/goblog-server/pkg/hash"
+	"github.com/misterabdul/goblog-server/pkg/jwt"
+	"github.com/misterabdul/goblog-server/pkg/oidc"
+)
+
+// @Tags        Authentication
+// @Summary     Get OIDC Providers
+// @Description Get the names of the configured OpenID Connect sign in providers.
+// @Router      /v1/oidc/providers [get]
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Success     200 {object} object{data=[]string}
+func GetOidcProviders() (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var names = []string{}
+
+		for name := range internalOidc.GetProviders() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		responses.OidcProviders(c, names)
+	}
+}
+
+// @Tags        Authentication
+// @Summary     Authorize OIDC
+// @Description Start signing in with the OpenID Connect provider, redirect the user to the returned authorization URL.
+// @Router      /v1/oidc/{provider}/authorize [get]
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       provider path     string true "Provider's name"
+// @Success     200      {object} object{data=object{authorizationUrl=string,state=string,expiresIn=int}}
+// @Failure     404      {object} object{message=string}
+// @Failure     500      {object} object{message=string}
+func AuthorizeOidc(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel   = context.WithTimeout(context.Background(), maxCtxDuration)
+			provider      *oidc.Provider
+			providerParam = c.Param("provider")
+			stateModel    *models.OidcStateModel
+			state         string
+			authURL       string
+			ok            bool
+			err           error
+		)
+
+		defer cancel()
+		if provider, ok = internalOidc.GetProvider(providerParam); !ok {
+			responses.UnknownOidcProvider(c, errors.New("provider not found"))
+			return
+		}
+		if stateModel, state, err = createOidcState(provider); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if authURL, err = provider.AuthCodeURL(
+			ctx, state, stateModel.Nonce, stateModel.CodeVerifier,
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = svc.OidcState.SaveOne(ctx, stateModel); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.OidcAuthorization(c, authURL, state, stateModel.ExpiresAt.Time())
+	}
+}
+
+// @Tags        Authentication
+// @Summary     Sign In OIDC
+// @Description Finish signing in with the code & state the OpenID Connect provider redirected back with. The user is linked by the provider's verified email or created, accounts with two-factor authentication get a token for the second step instead.
+// @Router      /v1/oidc/{provider}/callback [post]
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       provider path     string                          true "Provider's name"
+// @Param       form     body     object{code=string,state=string} true "OIDC callback form"
+// @Header      200      {string} Set-Cookie
+// @Success     200      {object} object{data=object{tokenType=string,accessToken=string,mfaToken=string,expiresIn=int}}
+// @Failure     400      {object} object{message=string}
+// @Failure     401      {object} object{message=string}
+// @Failure     403      {object} object{message=string}
+// @Failure     404      {object} object{message=string}
+// @Failure     409      {object} object{message=string}
+// @Failure     422      {object} object{message=string}
+// @Failure     500      {object} object{message=string}
+func SignInOidc(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel   = context.WithTimeout(context.Background(), maxCtxDuration)
+			input         *forms.OidcCallbackForm
+			provider      *oidc.Provider
+			providerParam = c.Param("provider")
+			stateModel    *models.OidcStateModel
+			token         *oidc.Token
+			idClaims      *oidc.IDTokenClaims
+			user          *models.UserModel
+			accessClaims  *jwt.CustomClaims
+			refreshClaims *jwt.CustomClaims
+			mfaClaims     *jwt.CustomClaims
+			accessToken   string
+			refreshToken  string
+			mfaToken      string
+			ok            bool
+			action        = models.AuditActionSignIn
+			actor         *models.UserModel
+			err           error
+		)
+
+		defer cancel()
+		defer func() {
+			audit.RecordActor(ctx, c, svc, action, actor, audit.UserTarget("", user))
+		}()
+		if provider, ok = internalOidc.GetProvider(providerParam); !ok {
+			responses.UnknownOidcProvider(c, errors.New("provider not found"))
+			return
+		}
+		if input, err = requests.GetOidcCallbackForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if stateModel, err = svc.OidcState.UseOne(
+			ctx, provider.Name, hash.HashToken(input.State),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if stateModel == nil {
+			responses.InvalidOidcState(c, errors.New("oidc state not found"))
+			return
+		}
+		if token, err = provider.Exchange(ctx, input.Code, stateModel.CodeVerifier); err != nil {
+			responses.OidcSignInFailed(c, err)
+			return
+		}
+		if idClaims, err = provider.VerifyIDToken(ctx, token.IDToken, stateModel.Nonce); err != nil {
+			responses.OidcSignInFailed(c, err)
+			return
+		}
+		if user, err = svc.User.GetOne(ctx, bson.M{
+			"identities": bson.M{"$elemMatch": bson.M{
+				"provider": bson.M{"$eq": provider.Name},
+				"subject":  bson.M{"$eq": idClaims.Subject}}},
+		}); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if user == nil {
+			if !idClaims.EmailVerified || idClaims.Email == "" {
+				responses.OidcUnverifiedEmail(c, errors.New("unverified provider email"))
+				return
+			}
+			if user, err = svc.User.GetOne(ctx, bson.M{
+				"email": bson.M{"$eq": idClaims.Email},
+			}); err != nil {
+				responses.InternalServerError(c, err)
+				return
+			}
+			if user != nil && (user.DeletedAt != nil || user.VerifiedAt == nil) {
+				responses.OidcAccountConflict(c, errors.New("unverified or trashed account"))
+				return
+			}
+			if user == nil {
+				if user, err = createOidcUser(ctx, svc, idClaims); err != nil {
+					responses.InternalServerError(c, err)
+					return
+				}
+			}
+			if err = svc.User.LinkIdentity(ctx, user, models.UserIdentity{
+				Provider: provider.Name,
+				Subject:  idClaims.Subject,
+				Email:    idClaims.Email,
+			}); err != nil {
+				responses.InternalServerError(c, err)
+				return
+			}
+		}
+		if user.DeletedAt != nil {
+			responses.OidcSignInFailed(c, errors.New("trashed user"))
+			return
+		}
+		actor = user
+		if user.TwoFactor.EnabledAt != nil {
+			if mfaClaims, mfaToken, err = internalJwt.IssueMfaPendingToken(user); err != nil {
+				responses.InternalServerError(c, err)
+				return
+			}
+			action = models.AuditActionSignInPending
+			responses.TwoFactorRequired(c, mfaToken, mfaClaims)
+			return
+		}
+		if refreshClaims, refreshToken, err = internalJwt.IssueRefreshToken(user, ""); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if accessClaims, accessToken, err = internalJwt.IssueAccessToken(
+			user, refreshClaims.FamilyID,
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = saveSession(ctx, svc, c, refreshClaims, user); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.SignedIn(
+			c,
+			accessToken,
+			accessClaims,
+			refreshToken,
+			refreshClaims)
+	}
+}
+
+func createOidcState(provider *oidc.Provider) (
+	model *models.OidcStateModel,
+	state string,
+	err error,
+) {
+	var (
+		stateHash    string
+		nonce        string
+		codeVerifier string
+		duration_s   string
+		duration     int
+		ok           bool
+	)
+
+	if duration_s, ok = os.LookupEnv("OIDC_STATE_DURATION"); !ok {
+		duration_s = "10"
+	}
+	if duration, err = strconv.Atoi(duration_s); err != nil {
+		duration = 10
+	}
+	if state, stateHash, err = hash.MakeToken(32); err != nil {
+		return nil, "", err
+	}
+	if nonce, err = crypto.GenerateRandomStringURLSafe(32); err != nil {
+		return nil, "", err
+	}
+	if codeVerifier, err = oidc.NewCodeVerifier(); err != nil {
+		return nil, "", err
+	}
+	model = &models.OidcStateModel{
+		StateHash:    stateHash,
+		Provider:     provider.Name,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt: primitive.NewDateTimeFromTime(
+			time.Now().Add(time.Duration(duration) * time.Minute)),
+	}
+
+	return model, state, nil
+}
+
+// Create new verified user of the provider's identity,
+// with unusable random password until it's reset.
+func createOidcUser(
+	ctx context.Context,
+	svc *service.Service,
+	idClaims *oidc.IDTokenClaims,
+) (user *models.UserModel, err error) {
+	var (
+		now       = primitive.NewDateTimeFromTime(time.Now())
+		username  string
+		password  string
+		firstName = idClaims.GivenName
+		lastName  = idClaims.FamilyName
+	)
+
+	if username, err = getOidcUsername(ctx, svc, idClaims); err != nil {
+		return nil, err
+	}
+	if password, err = crypto.GenerateRandomStringURLSafe(32); err != nil {
+		return nil, err
+	}
+	if password, err = internalHash.Make(password); err != nil {
+		return nil, err
+	}
+	if firstName == "" && lastName == "" {
+		firstName = idClaims.Name
+	}
+	user = &models.UserModel{
+		UID:        primitive.NewObjectID(),
+		FirstName:  truncate(firstName, 50),
+		LastName:   truncate(lastName, 50),
+		Username:   username,
+		Email:      idClaims.Email,
+		Password:   password,
+		Roles:      []models.UserRole{},
+		VerifiedAt: now,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		DeletedAt:  nil}
+	if err = svc.User.SaveOne(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Get unused username derived from the provider's preferred username
+// or the email's local part, following the sign up's username rules.
+func getOidcUsername(
+	ctx context.Context,
+	svc *service.Service,
+	idClaims *oidc.IDTokenClaims,
+) (username string, err error) {
+	var (
+		base     string
+		suffix   string
+		existing *models.UserModel
+		source   = idClaims.PreferredUsername
+	)
+
+	if source == "" {
+		source = strings.SplitN(idClaims.Email, "@", 2)[0]
+	}
+	for _, char := range strings.ToLower(source) {
+		if (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9') {
+			base += string(char)
+		}
+	}
+	if len(base) < 5 {
+		base = "user" + base
+	}
+	base = truncate(base, 11)
+	for attempt := 0; attempt < 10; attempt++ {
+		username = base
+		if attempt > 0 || len(base) < 5 {
+			if suffix, err = getRandomDigits(5); err != nil {
+				return "", err
+			}
+			username = base + suffix
+		}
+		if existing, err = svc.User.GetOne(ctx, bson.M{
+			"username": bson.M{"$eq": username},
+		}); err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return username, nil
+		}
+	}
+
+	return "", errors.New("unable to find unused username")
+}
+
+func getRandomDigits(length int) (digits string, err error) {
+	var randomBytes []byte
+
+	if randomBytes, err = crypto.GenerateRandomBytes(length); err != nil {
+		return "", err
+	}
+	for _, randomByte := range randomBytes {
+		digits += strconv.Itoa(int(randomByte) % 10)
+	}
+
+	return digits, nil
+}
+
+func truncate(value string, length int) (truncated string) {
+	var runes = []rune(value)
+
+	if len(runes) > length {
+		return string(runes[:length])
+	}
+
+	return value
+}
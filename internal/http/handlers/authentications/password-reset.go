@@ -0,0 +1,163 @@
+package authentications
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+	"github.com/misterabdul/goblog-server/internal/http/requests"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/queue"
+	"github.com/misterabdul/goblog-server/internal/queue/client"
+	"github.com/misterabdul/goblog-server/internal/queue/payloads"
+	"github.com/misterabdul/goblog-server/internal/service"
+	"github.com/misterabdul/goblog-server/pkg/hash"
+)
+
+// @Tags        Authentication
+// @Summary     Forgot Password
+// @Description Send a one-time password reset link to the account's email.
+// @Router      /v1/password/forgot [post]
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       form body     object{email=string} true "Forgot password form"
+// @Success     204
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func ForgotPassword(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			queueClient = client.GetClient()
+			form        *forms.ForgotPasswordForm
+			user        *models.UserModel
+			reset       *models.PasswordResetModel
+			token       string
+			err         error
+		)
+
+		defer cancel()
+		defer queueClient.Disconnect()
+		if form, err = requests.GetForgotPasswordForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if user, err = svc.User.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"email": bson.M{"$eq": form.Email}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		// Don't let the caller know whether the email is registered.
+		if user == nil {
+			responses.NoContent(c)
+			return
+		}
+		if reset, token, err = createPasswordReset(user); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = svc.PasswordReset.SaveOne(ctx, reset); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = queueClient.NewTask(
+			queue.SendPasswordReset,
+			payloads.NewSendPasswordResetPayload(*user, token),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
+
+// @Tags        Authentication
+// @Summary     Reset Password
+// @Description Set a new password using the token from the password reset email, signing out all sessions.
+// @Router      /v1/password/reset [post]
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       form body     object{token=string,password=string,passwordConfirm=string} true "Reset password form"
+// @Success     204
+// @Failure     400  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func ResetPassword(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			form        *forms.ResetPasswordForm
+			reset       *models.PasswordResetModel
+			user        *models.UserModel
+			err         error
+		)
+
+		defer cancel()
+		if form, err = requests.GetResetPasswordForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if err = form.Validate(); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if reset, err = svc.PasswordReset.UseOne(
+			ctx, hash.HashToken(form.Token),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if reset == nil {
+			responses.InvalidPasswordResetToken(c, errors.New("password reset not found"))
+			return
+		}
+		if user, err = svc.User.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"_id": bson.M{"$eq": reset.Owner.UID}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if user == nil {
+			responses.InvalidPasswordResetToken(c, errors.New("user not found"))
+			return
+		}
+		if user, err = form.ToUserModel(user); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = svc.User.UpdateOne(ctx, user); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = svc.User.RevokeTokens(ctx, user); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
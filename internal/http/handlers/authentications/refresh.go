@@ -2,11 +2,13 @@ package authentications
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
 	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
 	"github.com/misterabdul/goblog-server/internal/http/responses"
 	internalJwt "github.com/misterabdul/goblog-server/internal/pkg/jwt"
@@ -16,7 +18,7 @@ import (
 
 // @Tags        Authentication
 // @Summary     Refresh
-// @Description Request new access token using refresh token.
+// @Description Request new access token using refresh token, the refresh token is rotated on each request.
 // @Router      /v1/refresh [post]
 // @Produce     application/json
 // @Produce     application/msgpack
@@ -39,10 +41,14 @@ func Refresh(
 			newAccessToken   string
 			newRefreshClaims *jwt.CustomClaims
 			newRefreshToken  string
+			rotated          bool
 			err              error
 		)
 
 		defer cancel()
+		defer func() {
+			audit.Record(ctx, c, svc, models.AuditActionRefresh, audit.UserTarget("", me))
+		}()
 		if oldRefreshClaims, err = authenticate.GetRefreshClaims(c); err != nil {
 			responses.Unauthenticated(c, err)
 			return
@@ -51,17 +57,33 @@ func Refresh(
 			responses.Unauthenticated(c, err)
 			return
 		}
-		if err = noteRevokeToken(ctx, svc, oldRefreshClaims, me); err != nil {
+		if rotated, err = rotateRefreshToken(ctx, svc, oldRefreshClaims, me); err != nil {
 			responses.InternalServerError(c, err)
 			return
 		}
-		if newAccessClaims, newAccessToken, err = internalJwt.
-			IssueAccessToken(me); err != nil {
+		// Lost the race against another refresh of the same token,
+		// it's an already rotated token being reused.
+		if !rotated {
+			if err = revokeReusedRefreshToken(ctx, svc, oldRefreshClaims, me); err != nil {
+				responses.InternalServerError(c, err)
+				return
+			}
+			responses.Unauthenticated(c, errors.New("refresh token reused"))
+			return
+		}
+		if newRefreshClaims, newRefreshToken, err = internalJwt.IssueRefreshToken(
+			me, internalJwt.GetRefreshTokenFamily(oldRefreshClaims),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if newAccessClaims, newAccessToken, err = internalJwt.IssueAccessToken(
+			me, newRefreshClaims.FamilyID,
+		); err != nil {
 			responses.InternalServerError(c, err)
 			return
 		}
-		if newRefreshClaims, newRefreshToken, err = internalJwt.
-			IssueRefreshToken(me); err != nil {
+		if err = saveSession(ctx, svc, c, newRefreshClaims, me); err != nil {
 			responses.InternalServerError(c, err)
 			return
 		}
@@ -9,18 +9,19 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
 	"github.com/misterabdul/goblog-server/internal/http/forms"
 	"github.com/misterabdul/goblog-server/internal/http/requests"
 	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/pkg/hash"
 	internalJwt "github.com/misterabdul/goblog-server/internal/pkg/jwt"
 	"github.com/misterabdul/goblog-server/internal/service"
-	"github.com/misterabdul/goblog-server/pkg/hash"
 	"github.com/misterabdul/goblog-server/pkg/jwt"
 )
 
 // @Tags        Authentication
 // @Summary     Sign In
-// @Description Do the signing in request.
+// @Description Do the signing in request, accounts with two-factor authentication get a token for the second step instead.
 // @Router      /v1/signin [post]
 // @Accept      application/json
 // @Accept      application/msgpack
@@ -28,9 +29,11 @@ import (
 // @Produce     application/msgpack
 // @Param       form body     object{username=string,password=string} true "Login form"
 // @Header      200  {string} Set-Cookie
-// @Success     200  {object} object{data=object{tokenType=string,accessToken=string}}
+// @Success     200  {object} object{data=object{tokenType=string,accessToken=string,mfaToken=string,expiresIn=int}}
 // @Failure     401  {object} object{message=string}
+// @Failure     403  {object} object{message=string}
 // @Failure     422  {object} object{message=string}
+// @Failure     429  {object} object{message=string}
 // @Failure     500  {object} object{message=string}
 func SignIn(
 	maxCtxDuration time.Duration,
@@ -44,12 +47,22 @@ func SignIn(
 			user          *models.UserModel
 			accessClaims  *jwt.CustomClaims
 			refreshClaims *jwt.CustomClaims
+			mfaClaims     *jwt.CustomClaims
 			accessToken   string
 			refreshToken  string
+			mfaToken      string
+			attemptKeys   []string
+			retryAfter    time.Duration
+			rehashed      string
+			action        = models.AuditActionSignIn
+			actor         *models.UserModel
 			err           error
 		)
 
 		defer cancel()
+		defer func() {
+			audit.RecordActor(ctx, c, svc, action, actor, signInAuditTarget(input, user))
+		}()
 		if input, err = requests.GetSignInForm(c); err != nil {
 			responses.FormIncorrect(c, err)
 			return
@@ -62,19 +75,70 @@ func SignIn(
 			responses.InternalServerError(c, err)
 			return
 		}
-		if user == nil {
-			responses.WrongSignIn(c, errors.New("incorrect username or email"))
+		if user != nil {
+			attemptKeys = signInAttemptKeys(svc, c, user.UID.Hex())
+		} else {
+			attemptKeys = signInAttemptKeys(svc, c, input.Username)
+		}
+		if retryAfter, err = svc.LoginAttempt.Check(ctx, attemptKeys...); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if retryAfter > 0 {
+			responses.SignInLocked(c, retryAfter, errors.New("too many failed attempts"))
+			return
+		}
+		if user == nil || !hash.Check(input.Password, user.Password) {
+			if retryAfter, err = svc.LoginAttempt.Fail(ctx, attemptKeys...); err != nil {
+				responses.InternalServerError(c, err)
+				return
+			}
+			if retryAfter > 0 {
+				responses.SignInLocked(c, retryAfter, errors.New("too many failed attempts"))
+				return
+			}
+			responses.WrongSignIn(c, errors.New("incorrect username, email or password"))
 			return
 		}
-		if !hash.Check(input.Password, user.Password) {
-			responses.WrongSignIn(c, errors.New("incorrect password"))
+		actor = user
+		if hash.NeedsRehash(user.Password) {
+			if rehashed, err = hash.Make(input.Password); err != nil {
+				responses.InternalServerError(c, err)
+				return
+			}
+			if err = svc.User.RehashPassword(ctx, user, rehashed); err != nil {
+				responses.InternalServerError(c, err)
+				return
+			}
+		}
+		if user.VerifiedAt == nil {
+			responses.UnverifiedSignIn(c, errors.New("unverified email"))
+			return
+		}
+		if user.TwoFactor.EnabledAt != nil {
+			if mfaClaims, mfaToken, err = internalJwt.IssueMfaPendingToken(user); err != nil {
+				responses.InternalServerError(c, err)
+				return
+			}
+			action = models.AuditActionSignInPending
+			responses.TwoFactorRequired(c, mfaToken, mfaClaims)
+			return
+		}
+		if err = svc.LoginAttempt.Clear(ctx, attemptKeys[0]); err != nil {
+			responses.InternalServerError(c, err)
 			return
 		}
-		if accessClaims, accessToken, err = internalJwt.IssueAccessToken(user); err != nil {
+		if refreshClaims, refreshToken, err = internalJwt.IssueRefreshToken(user, ""); err != nil {
 			responses.InternalServerError(c, err)
 			return
 		}
-		if refreshClaims, refreshToken, err = internalJwt.IssueRefreshToken(user); err != nil {
+		if accessClaims, accessToken, err = internalJwt.IssueAccessToken(
+			user, refreshClaims.FamilyID,
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = saveSession(ctx, svc, c, refreshClaims, user); err != nil {
 			responses.InternalServerError(c, err)
 			return
 		}
@@ -87,3 +151,29 @@ func SignIn(
 			refreshClaims)
 	}
 }
+
+// Keys of the failed attempts tracked for signing in to the account,
+// the account's key comes first.
+func signInAttemptKeys(
+	svc *service.Service,
+	c *gin.Context,
+	account string,
+) (keys []string) {
+
+	return []string{
+		svc.LoginAttempt.AccountKey(account),
+		svc.LoginAttempt.IPKey(c.ClientIP())}
+}
+
+// Audit target of signing in, the attempted account when there's no such user.
+func signInAuditTarget(
+	input *forms.SignInForm,
+	user *models.UserModel,
+) (target models.AuditTargetModel) {
+	target = audit.UserTarget("", user)
+	if user == nil && input != nil {
+		target.Name = input.Username
+	}
+
+	return target
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
 	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
 	"github.com/misterabdul/goblog-server/internal/http/responses"
 	"github.com/misterabdul/goblog-server/internal/service"
@@ -15,7 +16,7 @@ import (
 
 // @Tags        Authentication
 // @Summary     Sign Out
-// @Description Do the signing out request to revoke access token & refresh token.
+// @Description Do the signing out request to revoke the refresh token along with its whole token family.
 // @Router      /v1/signout [post]
 // @Security    BearerAuth
 // @Produce     application/json
@@ -38,6 +39,9 @@ func SignOut(
 		)
 
 		defer cancel()
+		defer func() {
+			audit.Record(ctx, c, svc, models.AuditActionSignOut, audit.UserTarget("", me))
+		}()
 		if me, err = authenticate.GetRefreshedUser(c); err != nil {
 			responses.Unauthenticated(c, err)
 			return
@@ -46,7 +50,7 @@ func SignOut(
 			responses.Unauthenticated(c, err)
 			return
 		}
-		if err = noteRevokeToken(ctx, svc, refreshClaims, me); err != nil {
+		if err = revokeRefreshTokenFamily(ctx, svc, refreshClaims, me); err != nil {
 			responses.InternalServerError(c, err)
 			return
 		}
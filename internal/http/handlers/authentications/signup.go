@@ -1,20 +1,219 @@
 package authentications
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+	"github.com/misterabdul/goblog-server/internal/http/requests"
 	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/queue"
+	"github.com/misterabdul/goblog-server/internal/queue/client"
+	"github.com/misterabdul/goblog-server/internal/queue/payloads"
 	"github.com/misterabdul/goblog-server/internal/service"
+	"github.com/misterabdul/goblog-server/pkg/hash"
 )
 
+// @Tags        Authentication
+// @Summary     Sign Up
+// @Description Register a new unverified account & send the verification email.
+// @Router      /v1/signup [post]
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       form body     object{firstName=string,lastName=string,username=string,email=string,password=string,passwordConfirm=string} true "Sign up form"
+// @Success     201  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
 func SignUp(
 	maxCtxDuration time.Duration,
 	svc *service.Service,
 ) (handler gin.HandlerFunc) {
 
 	return func(c *gin.Context) {
-		responses.NotImplemented(c, nil)
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			queueClient  = client.GetClient()
+			form         *forms.SignUpForm
+			user         *models.UserModel
+			verification *models.EmailVerificationModel
+			token        string
+			err          error
+		)
+
+		defer cancel()
+		defer queueClient.Disconnect()
+		if form, err = requests.GetSignUpForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if err = form.Validate(svc, ctx); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if user, err = form.ToUserModel(); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if verification, token, err = createEmailVerification(); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = svc.EmailVerification.SaveOneWithUser(ctx, user, verification); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = queueClient.NewTask(
+			queue.SendEmailVerification,
+			payloads.NewSendEmailVerificationPayload(*user, token),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.SignedUp(c)
+	}
+}
+
+// @Tags        Authentication
+// @Summary     Verify Email
+// @Description Activate the account by redeeming the token from the verification email.
+// @Router      /v1/signup/verify [post]
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       form body     object{token=string} true "Verify email form"
+// @Success     204
+// @Failure     400  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func VerifyEmail(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			form         *forms.VerifyEmailForm
+			verification *models.EmailVerificationModel
+			user         *models.UserModel
+			err          error
+		)
+
+		defer cancel()
+		if form, err = requests.GetVerifyEmailForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if verification, err = svc.EmailVerification.UseOne(
+			ctx, hash.HashToken(form.Token),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if verification == nil {
+			responses.InvalidVerificationToken(c, errors.New("verification not found"))
+			return
+		}
+		if user, err = svc.User.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"_id": bson.M{"$eq": verification.Owner.UID}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if user == nil {
+			responses.InvalidVerificationToken(c, errors.New("user not found"))
+			return
+		}
+		if user.VerifiedAt != nil {
+			responses.NoContent(c)
+			return
+		}
+		if err = svc.User.VerifyOne(ctx, user); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
+
+// @Tags        Authentication
+// @Summary     Resend Email Verification
+// @Description Send a new verification email for an unverified account.
+// @Router      /v1/signup/resend [post]
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       form body     object{email=string} true "Resend email verification form"
+// @Success     204
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func ResendEmailVerification(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			queueClient  = client.GetClient()
+			form         *forms.ResendEmailVerificationForm
+			user         *models.UserModel
+			verification *models.EmailVerificationModel
+			token        string
+			err          error
+		)
+
+		defer cancel()
+		defer queueClient.Disconnect()
+		if form, err = requests.GetResendEmailVerificationForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if user, err = svc.User.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"verifiedat": bson.M{"$eq": primitive.Null{}}},
+				{"email": bson.M{"$eq": form.Email}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		// Don't let the caller know whether the email is registered.
+		if user == nil {
+			responses.NoContent(c)
+			return
+		}
+		if verification, token, err = createEmailVerification(); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		verification.Owner = user.ToCommonModel()
+		if err = svc.EmailVerification.SaveOne(ctx, verification); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = queueClient.NewTask(
+			queue.SendEmailVerification,
+			payloads.NewSendEmailVerificationPayload(*user, token),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
 	}
 }
@@ -2,29 +2,130 @@ package authentications
 
 import (
 	"context"
+	"os"
+	"strconv"
 	"time"
 
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
+	internalJwt "github.com/misterabdul/goblog-server/internal/pkg/jwt"
 	"github.com/misterabdul/goblog-server/internal/service"
+	"github.com/misterabdul/goblog-server/pkg/hash"
 	"github.com/misterabdul/goblog-server/pkg/jwt"
 )
 
-func noteRevokeToken(
+func rotateRefreshToken(
 	ctx context.Context,
 	svc *service.Service,
 	refreshClaims *jwt.CustomClaims,
 	user *models.UserModel,
-) (err error) {
+) (rotated bool, err error) {
 	var revokeTokenData *models.RevokedTokenModel
 
 	if revokeTokenData, err = createRevokeModelFromClaims(refreshClaims); err != nil {
-		return err
+		return false, err
 	}
 	revokeTokenData.Owner = user.ToCommonModel()
+	revokeTokenData.FamilyID = internalJwt.GetRefreshTokenFamily(refreshClaims)
+
+	return svc.RevokedToken.RotateOne(ctx, revokeTokenData)
+}
+
+// Revoke the family of the reused refresh token, unless the token
+// is rotated within the reuse grace, e.g. by a concurrent refresh
+// from another tab.
+func revokeReusedRefreshToken(
+	ctx context.Context,
+	svc *service.Service,
+	refreshClaims *jwt.CustomClaims,
+	user *models.UserModel,
+) (err error) {
+	var (
+		tokenUid         primitive.ObjectID
+		revokedTokenData *models.RevokedTokenModel
+	)
+
+	if tokenUid, err = primitive.ObjectIDFromHex(refreshClaims.Id); err != nil {
+		return err
+	}
+	if revokedTokenData, err = svc.RevokedToken.GetOne(
+		ctx, bson.M{"_id": bson.M{"$eq": tokenUid}},
+	); err != nil {
+		return err
+	}
+	if revokedTokenData != nil &&
+		revokedTokenData.IsRotatedWithin(internalJwt.GetRefreshTokenReuseGrace(), time.Now()) {
+		return nil
+	}
 
-	return svc.RevokedToken.SaveOne(ctx, revokeTokenData)
+	return revokeRefreshTokenFamily(ctx, svc, refreshClaims, user)
+}
+
+func revokeRefreshTokenFamily(
+	ctx context.Context,
+	svc *service.Service,
+	refreshClaims *jwt.CustomClaims,
+	user *models.UserModel,
+) (err error) {
+	var (
+		familyID   = internalJwt.GetRefreshTokenFamily(refreshClaims)
+		sessionUid primitive.ObjectID
+	)
+
+	if err = svc.RevokedToken.RevokeFamily(
+		ctx,
+		user.ToCommonModel(),
+		familyID,
+		time.Now().Add(internalJwt.GetRefreshTokenDuration()),
+	); err != nil {
+		return err
+	}
+	if sessionUid, err = primitive.ObjectIDFromHex(familyID); err != nil {
+		return err
+	}
+
+	return svc.Session.DeleteMany(ctx, bson.M{"_id": sessionUid})
+}
+
+// Create or update the session of the newly issued refresh token.
+func saveSession(
+	ctx context.Context,
+	svc *service.Service,
+	c *gin.Context,
+	refreshClaims *jwt.CustomClaims,
+	user *models.UserModel,
+) (err error) {
+	var (
+		sessionUid primitive.ObjectID
+		session    *models.SessionModel
+		expiresAt  = primitive.NewDateTimeFromTime(time.Unix(refreshClaims.ExpiresAt, 0))
+	)
+
+	if sessionUid, err = primitive.ObjectIDFromHex(refreshClaims.FamilyID); err != nil {
+		return err
+	}
+	if session, err = svc.Session.GetOne(ctx, bson.M{"_id": sessionUid}); err != nil {
+		return err
+	}
+	if session == nil {
+		return svc.Session.SaveOne(ctx, &models.SessionModel{
+			UID:       sessionUid,
+			TokenID:   refreshClaims.Id,
+			Owner:     user.ToCommonModel(),
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+			ExpiresAt: expiresAt})
+	}
+	session.TokenID = refreshClaims.Id
+	session.IP = c.ClientIP()
+	session.UserAgent = c.Request.UserAgent()
+	session.ExpiresAt = expiresAt
+	session.LastUsedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	return svc.Session.UpdateOne(ctx, session)
 }
 
 func createRevokeModelFromClaims(
@@ -46,3 +147,64 @@ func createRevokeModelFromClaims(
 
 	return &_model, nil
 }
+
+func createEmailVerification() (
+	model *models.EmailVerificationModel,
+	token string,
+	err error,
+) {
+	var (
+		tokenHash  string
+		duration_s string
+		duration   int
+		ok         bool
+	)
+
+	if duration_s, ok = os.LookupEnv("EMAIL_VERIFICATION_DURATION"); !ok {
+		duration_s = "24"
+	}
+	if duration, err = strconv.Atoi(duration_s); err != nil {
+		duration = 24
+	}
+	if token, tokenHash, err = hash.MakeToken(32); err != nil {
+		return nil, "", err
+	}
+	model = &models.EmailVerificationModel{
+		TokenHash: tokenHash,
+		ExpiresAt: primitive.NewDateTimeFromTime(
+			time.Now().Add(time.Duration(duration) * time.Hour)),
+	}
+
+	return model, token, nil
+}
+
+func createPasswordReset(user *models.UserModel) (
+	model *models.PasswordResetModel,
+	token string,
+	err error,
+) {
+	var (
+		tokenHash  string
+		duration_s string
+		duration   int
+		ok         bool
+	)
+
+	if duration_s, ok = os.LookupEnv("PASSWORD_RESET_DURATION"); !ok {
+		duration_s = "30"
+	}
+	if duration, err = strconv.Atoi(duration_s); err != nil {
+		duration = 30
+	}
+	if token, tokenHash, err = hash.MakeToken(32); err != nil {
+		return nil, "", err
+	}
+	model = &models.PasswordResetModel{
+		TokenHash: tokenHash,
+		Owner:     user.ToCommonModel(),
+		ExpiresAt: primitive.NewDateTimeFromTime(
+			time.Now().Add(time.Duration(duration) * time.Minute)),
+	}
+
+	return model, token, nil
+}
@@ -0,0 +1,144 @@
+package authentications
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+	"github.com/misterabdul/goblog-server/internal/http/requests"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	internalJwt "github.com/misterabdul/goblog-server/internal/pkg/jwt"
+	"github.com/misterabdul/goblog-server/internal/service"
+	"github.com/misterabdul/goblog-server/pkg/jwt"
+)
+
+// @Tags        Authentication
+// @Summary     Sign In Two-Factor
+// @Description Finish signing in with the token from the first step & an authenticator or recovery code.
+// @Router      /v1/signin/2fa [post]
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       form body     object{mfaToken=string,code=string} true "Two-factor sign in form"
+// @Header      200  {string} Set-Cookie
+// @Success     200  {object} object{data=object{tokenType=string,accessToken=string}}
+// @Failure     401  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     429  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func SignInTwoFactor(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel   = context.WithTimeout(context.Background(), maxCtxDuration)
+			input         *forms.SignInTwoFactorForm
+			user          *models.UserModel
+			userUid       primitive.ObjectID
+			mfaClaims     *jwt.CustomClaims
+			accessClaims  *jwt.CustomClaims
+			refreshClaims *jwt.CustomClaims
+			accessToken   string
+			refreshToken  string
+			attemptKeys   []string
+			retryAfter    time.Duration
+			valid         bool
+			actor         *models.UserModel
+			err           error
+		)
+
+		defer cancel()
+		defer func() {
+			audit.RecordActor(ctx, c, svc, models.AuditActionSignIn, actor, audit.UserTarget("", user))
+		}()
+		if input, err = requests.GetSignInTwoFactorForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if mfaClaims, err = internalJwt.CheckMfaPendingToken(input.MfaToken); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if userUid, err = primitive.ObjectIDFromHex(mfaClaims.Subject); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if user, err = svc.User.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"_id": bson.M{"$eq": userUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if user == nil {
+			responses.Unauthenticated(c, errors.New("user not found"))
+			return
+		}
+		if mfaClaims.Version != user.TokenVersion {
+			responses.Unauthenticated(c, errors.New("outdated token version"))
+			return
+		}
+		attemptKeys = signInAttemptKeys(svc, c, user.UID.Hex())
+		if retryAfter, err = svc.LoginAttempt.Check(ctx, attemptKeys...); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if retryAfter > 0 {
+			responses.SignInLocked(c, retryAfter, errors.New("too many failed attempts"))
+			return
+		}
+		if valid, err = svc.User.UseTwoFactorCode(ctx, user, input.Code); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if !valid {
+			if retryAfter, err = svc.LoginAttempt.Fail(ctx, attemptKeys...); err != nil {
+				responses.InternalServerError(c, err)
+				return
+			}
+			if retryAfter > 0 {
+				responses.SignInLocked(c, retryAfter, errors.New("too many failed attempts"))
+				return
+			}
+			responses.WrongTwoFactorCode(c, errors.New("invalid two-factor code"))
+			return
+		}
+		actor = user
+		if err = svc.LoginAttempt.Clear(ctx, attemptKeys[0]); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if refreshClaims, refreshToken, err = internalJwt.IssueRefreshToken(user, ""); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if accessClaims, accessToken, err = internalJwt.IssueAccessToken(
+			user, refreshClaims.FamilyID,
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = saveSession(ctx, svc, c, refreshClaims, user); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.SignedIn(
+			c,
+			accessToken,
+			accessClaims,
+			refreshToken,
+			refreshClaims)
+	}
+}
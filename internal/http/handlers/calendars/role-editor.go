@@ -0,0 +1,105 @@
+package calendars
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+const maxCalendarDays = 92
+
+// @Tags        Calendar (Editor)
+// @Summary     Get Editorial Calendar
+// @Description Get the upcoming scheduled posts & pages, grouped by their publish day.
+// @Router      /v1/auth/editor/calendar [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       from query    string false "First day of the calendar, e.g.: ?from=2006-01-02, default to today."
+// @Param       days query    int    false "Number of days shown, default to 30, at most 92."
+// @Param       tz   query    string false "IANA time zone of the days, e.g.: ?tz=Asia/Jakarta, default to UTC."
+// @Success     200  {object} object{data=[]object{date=string,posts=[]object{uid=string,slug=string,title=string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},publishAt=time},pages=[]object{uid=string,slug=string,title=string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},publishAt=time}}}
+// @Failure     400  {object} object{message=string}
+// @Failure     401  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func GetEditorialCalendar(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			from        time.Time
+			days        int
+			filter      bson.M
+			posts       []*models.PostModel
+			pages       []*models.PageModel
+			err         error
+		)
+
+		defer cancel()
+		if from, days, err = readQueryParams(c); err != nil {
+			responses.IncorrectCalendarRange(c, err)
+			return
+		}
+		filter = bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"publishedat": bson.M{"$eq": primitive.Null{}}},
+				{"publishat": bson.M{
+					"$gte": primitive.NewDateTimeFromTime(from),
+					"$lt":  primitive.NewDateTimeFromTime(from.AddDate(0, 0, days))}}}}
+		if posts, err = svc.Post.GetMany(ctx, filter,
+			options.Find().SetSort(bson.M{"publishat": 1}),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if pages, err = svc.Page.GetMany(ctx, filter,
+			options.Find().SetSort(bson.M{"publishat": 1}),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.EditorialCalendar(c, from, days, posts, pages)
+	}
+}
+
+func readQueryParams(c *gin.Context) (from time.Time, days int, err error) {
+	var (
+		location *time.Location
+		now      time.Time
+	)
+
+	if location, err = time.LoadLocation(c.DefaultQuery("tz", "UTC")); err != nil {
+		return from, days, err
+	}
+	now = time.Now().In(location)
+	from = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	if from_s := c.Query("from"); from_s != "" {
+		if from, err = time.ParseInLocation("2006-01-02", from_s, location); err != nil {
+			return from, days, err
+		}
+	}
+	if days, err = strconv.Atoi(c.DefaultQuery("days", "30")); err != nil {
+		return from, days, err
+	}
+	if days < 1 || days > maxCalendarDays {
+		return from, days, errors.New("days must be between 1 and " +
+			strconv.Itoa(maxCalendarDays))
+	}
+
+	return from, days, nil
+}
@@ -10,6 +10,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
 	"github.com/misterabdul/goblog-server/internal/http/forms"
 	"github.com/misterabdul/goblog-server/internal/http/requests"
 	"github.com/misterabdul/goblog-server/internal/http/responses"
@@ -397,6 +398,9 @@ func DeleteCategory(
 		)
 
 		defer cancel()
+		defer func() {
+			audit.Record(ctx, c, svc, models.AuditActionDeleteCategory, audit.CategoryTarget(categoryUidParam, category))
+		}()
 		if categoryUid, err = primitive.ObjectIDFromHex(categoryUidParam); err != nil {
 			responses.IncorrectCategoryId(c, err)
 			return
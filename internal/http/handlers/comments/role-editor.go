@@ -10,6 +10,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
 	"github.com/misterabdul/goblog-server/internal/http/responses"
 	internalGin "github.com/misterabdul/goblog-server/internal/pkg/gin"
 	"github.com/misterabdul/goblog-server/internal/service"
@@ -416,6 +417,9 @@ func DeleteComment(
 		)
 
 		defer cancel()
+		defer func() {
+			audit.Record(ctx, c, svc, models.AuditActionDeleteComment, audit.CommentTarget(commentUidParam, comment))
+		}()
 		if commentUid, err = primitive.ObjectIDFromHex(commentUidParam); err != nil {
 			responses.IncorrectCommentId(c, err)
 			return
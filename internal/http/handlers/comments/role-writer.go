@@ -10,6 +10,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
 	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
 	"github.com/misterabdul/goblog-server/internal/http/responses"
 	internalGin "github.com/misterabdul/goblog-server/internal/pkg/gin"
@@ -462,6 +463,9 @@ func DeleteMyComment(
 		)
 
 		defer cancel()
+		defer func() {
+			audit.Record(ctx, c, svc, models.AuditActionDeleteComment, audit.CommentTarget(commentUidParam, comment))
+		}()
 		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
 			responses.Unauthenticated(c, err)
 			return
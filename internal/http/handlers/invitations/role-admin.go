@@ -0,0 +1,332 @@
+package invitations
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
+	"github.com/misterabdul/goblog-server/internal/http/requests"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	internalGin "github.com/misterabdul/goblog-server/internal/pkg/gin"
+	"github.com/misterabdul/goblog-server/internal/queue"
+	"github.com/misterabdul/goblog-server/internal/queue/client"
+	"github.com/misterabdul/goblog-server/internal/queue/payloads"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// @Tags        Invitation (Admin)
+// @Summary     Get Invitations
+// @Description Get staff invitations.
+// @Router      /v1/auth/admin/invitations [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       type  query    string false "Type of invitations: pending (default), accepted, revoked, expired or all."
+// @Param       show  query    int    false "Number of data to be shown."
+// @Param       page  query    int    false "Selected page of data."
+// @Param       order query    string false "Selected field to order data with."
+// @Param       asc   query    string false "Ascending or descending, e.g.: ?asc=false."
+// @Success     200   {object} object{data=[]object{uid=string,email=string,firstName=string,lastName=string,roles=[]object{level=int,name=string},status=string,invitedBy=object{uid=string,username=string,email=string,firstName=string,lastName=string},sentCount=int,expiresAt=time,acceptedAt=time,revokedAt=time,createdAt=time,updatedAt=time}}
+// @Success     204
+// @Failure     401   {object} object{message=string}
+// @Failure     500   {object} object{message=string}
+func GetInvitations(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			invitations []*models.InvitationModel
+			queryParams = readCommonQueryParams(c, svc)
+			err         error
+		)
+
+		defer cancel()
+		if invitations, err = svc.Invitation.GetMany(ctx, bson.M{
+			"$and": queryParams},
+			internalGin.GetFindOptions(c),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if len(invitations) == 0 {
+			responses.NoContent(c)
+			return
+		}
+
+		responses.Invitations(c, invitations)
+	}
+}
+
+// @Tags        Invitation (Admin)
+// @Summary     Get Invitations Stats
+// @Description Get staff invitations's stats.
+// @Router      /v1/auth/admin/invitations/stats [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       type  query    string false "Type of invitations: pending (default), accepted, revoked, expired or all."
+// @Param       show  query    int    false "Number of data to be shown."
+// @Param       page  query    int    false "Selected page of data."
+// @Param       order query    string false "Selected field to order data with."
+// @Param       asc   query    string false "Ascending or descending, e.g.: ?asc=false."
+// @Success     200   {object} object{data=object{currentPage=int,totalPages=int,itemsPerPage=int,totalItems=int}}
+// @Failure     401   {object} object{message=string}
+// @Failure     500   {object} object{message=string}
+func GetInvitationsStats(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			count       int64
+			queryParams = readCommonQueryParams(c, svc)
+			err         error
+		)
+
+		defer cancel()
+		if count, err = svc.Invitation.Count(ctx, bson.M{
+			"$and": queryParams},
+			internalGin.GetCountOptions(c),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.ResourceStats(c, count)
+	}
+}
+
+// @Tags        Invitation (Admin)
+// @Summary     Create Invitation
+// @Description Invite a new staff member by email with preassigned roles below the inviter's level & within the inviter's permissions, the admin roles must be granted by adminizing unless invited by the SuperAdmin.
+// @Router      /v1/auth/admin/invitation [post]
+// @Security    BearerAuth
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       form body     object{email=string,firstName=string,lastName=string,roles=[]int} true "Create invitation form"
+// @Success     201  {object} object{data=object{uid=string,email=string,firstName=string,lastName=string,roles=[]object{level=int,name=string},status=string,invitedBy=object{uid=string,username=string,email=string,firstName=string,lastName=string},sentCount=int,expiresAt=time,acceptedAt=time,revokedAt=time,createdAt=time,updatedAt=time}}
+// @Failure     401  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func CreateInvitation(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			queueClient = client.GetClient()
+			me          *models.UserModel
+			form        *forms.CreateInvitationForm
+			invitation  *models.InvitationModel
+			token       string
+			err         error
+		)
+
+		defer cancel()
+		defer queueClient.Disconnect()
+		defer func() {
+			audit.Record(ctx, c, svc, models.AuditActionInviteUser, audit.InvitationTarget("", invitation))
+		}()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if form, err = requests.GetCreateInvitationForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if err = form.Validate(svc, ctx, me); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		invitation = form.ToInvitationModel(me)
+		if token, err = svc.Invitation.CreateOne(ctx, invitation); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = queueClient.NewTask(
+			queue.SendInvitation,
+			payloads.NewSendInvitationPayload(*invitation, token),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.CreatedInvitation(c, invitation)
+	}
+}
+
+// @Tags        Invitation (Admin)
+// @Summary     Resend Invitation
+// @Description Send the invitation again with a new token & expiry, the previously sent token can no longer be used.
+// @Router      /v1/auth/admin/invitation/{uid}/resend [post]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path     string true "Invitation's UID"
+// @Success     200 {object} object{data=object{uid=string,email=string,firstName=string,lastName=string,roles=[]object{level=int,name=string},status=string,invitedBy=object{uid=string,username=string,email=string,firstName=string,lastName=string},sentCount=int,expiresAt=time,acceptedAt=time,revokedAt=time,createdAt=time,updatedAt=time}}
+// @Failure     400 {object} object{message=string}
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     409 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func ResendInvitation(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel        = context.WithTimeout(context.Background(), maxCtxDuration)
+			queueClient        = client.GetClient()
+			invitation         *models.InvitationModel
+			invitationUid      primitive.ObjectID
+			invitationUidParam = c.Param("invitation")
+			token              string
+			err                error
+		)
+
+		defer cancel()
+		defer queueClient.Disconnect()
+		defer func() {
+			audit.Record(ctx, c, svc, models.AuditActionResendInvite, audit.InvitationTarget(invitationUidParam, invitation))
+		}()
+		if invitationUid, err = primitive.ObjectIDFromHex(invitationUidParam); err != nil {
+			responses.IncorrectInvitationId(c, err)
+			return
+		}
+		if invitation, err = svc.Invitation.GetOne(ctx, bson.M{
+			"_id": bson.M{"$eq": invitationUid}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if invitation == nil {
+			responses.NotFound(c, errors.New("invitation not found"))
+			return
+		}
+		if invitation.AcceptedAt != nil || invitation.RevokedAt != nil {
+			responses.InvitationNotPending(c, errors.New("invitation already accepted or revoked"))
+			return
+		}
+		if token, err = svc.Invitation.RenewOne(ctx, invitation); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = queueClient.NewTask(
+			queue.SendInvitation,
+			payloads.NewSendInvitationPayload(*invitation, token),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.Invitation(c, invitation)
+	}
+}
+
+// @Tags        Invitation (Admin)
+// @Summary     Revoke Invitation
+// @Description Revoke a pending invitation, its token can no longer be accepted.
+// @Router      /v1/auth/admin/invitation/{uid} [delete]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path string true "Invitation's UID"
+// @Success     204
+// @Failure     400 {object} object{message=string}
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     409 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func RevokeInvitation(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel        = context.WithTimeout(context.Background(), maxCtxDuration)
+			invitation         *models.InvitationModel
+			invitationUid      primitive.ObjectID
+			invitationUidParam = c.Param("invitation")
+			err                error
+		)
+
+		defer cancel()
+		defer func() {
+			audit.Record(ctx, c, svc, models.AuditActionRevokeInvite, audit.InvitationTarget(invitationUidParam, invitation))
+		}()
+		if invitationUid, err = primitive.ObjectIDFromHex(invitationUidParam); err != nil {
+			responses.IncorrectInvitationId(c, err)
+			return
+		}
+		if invitation, err = svc.Invitation.GetOne(ctx, bson.M{
+			"_id": bson.M{"$eq": invitationUid}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if invitation == nil {
+			responses.NotFound(c, errors.New("invitation not found"))
+			return
+		}
+		if invitation.AcceptedAt != nil || invitation.RevokedAt != nil {
+			responses.InvitationNotPending(c, errors.New("invitation already accepted or revoked"))
+			return
+		}
+		if err = svc.Invitation.RevokeOne(ctx, invitation); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
+
+func readCommonQueryParams(c *gin.Context, svc *service.Service) []bson.M {
+	var (
+		typeParam  = c.DefaultQuery("type", "pending")
+		now        = primitive.NewDateTimeFromTime(time.Now())
+		extraQuery = []bson.M{}
+	)
+
+	switch true {
+	case typeParam == "all":
+		extraQuery = append(extraQuery, bson.M{})
+	case typeParam == "accepted":
+		extraQuery = append(extraQuery,
+			bson.M{"acceptedat": bson.M{"$ne": primitive.Null{}}})
+	case typeParam == "revoked":
+		extraQuery = append(extraQuery,
+			bson.M{"revokedat": bson.M{"$ne": primitive.Null{}}})
+	case typeParam == "expired":
+		extraQuery = append(extraQuery,
+			bson.M{"acceptedat": bson.M{"$eq": primitive.Null{}}},
+			bson.M{"revokedat": bson.M{"$eq": primitive.Null{}}},
+			bson.M{"expiresat": bson.M{"$lte": now}})
+	case typeParam == "pending":
+		fallthrough
+	default:
+		extraQuery = append(extraQuery, svc.Invitation.PendingFilter())
+	}
+
+	return extraQuery
+}
@@ -0,0 +1,98 @@
+package invitations
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+	"github.com/misterabdul/goblog-server/internal/http/requests"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// @Tags        Invitation
+// @Summary     Accept Invitation
+// @Description Accept the staff invitation using the token from the invitation email, creating the account with the invited roles. The roles are rejected when the inviter can no longer grant them.
+// @Router      /v1/invitation/accept [post]
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       form body     object{token=string,firstName=string,lastName=string,username=string,password=string,passwordConfirm=string} true "Accept invitation form"
+// @Success     200  {object} object{data=object{uid=string,username=string,email=string,firstName=string,lastName=string,roles=[]object{level=int,name=string,since=string},createdAt=time,updatedAt=time}}
+// @Failure     400  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func AcceptInvitation(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			form        *forms.AcceptInvitationForm
+			invitation  *models.InvitationModel
+			user        *models.UserModel
+			newUser     *models.UserModel
+			accepted    bool
+			err         error
+		)
+
+		defer cancel()
+		defer func() {
+			var actor *models.UserModel
+
+			if accepted {
+				actor = newUser
+			}
+			audit.RecordActor(ctx, c, svc, models.AuditActionAcceptInvite, actor, audit.InvitationTarget("", invitation))
+		}()
+		if form, err = requests.GetAcceptInvitationForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if invitation, err = svc.Invitation.GetOneByToken(ctx, form.Token); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if invitation == nil {
+			responses.InvalidInvitationToken(c, errors.New("invitation not found"))
+			return
+		}
+		if err = form.Validate(svc, ctx, invitation); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if user, err = svc.User.GetOne(ctx, bson.M{
+			"email": bson.M{"$eq": invitation.Email}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if user != nil {
+			responses.FormIncorrect(c, errors.New("email exists"))
+			return
+		}
+		if newUser, err = form.ToUserModel(invitation); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if accepted, err = svc.Invitation.AcceptOne(ctx, invitation, newUser); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if !accepted {
+			responses.InvalidInvitationToken(c, errors.New("invitation is no longer pending"))
+			return
+		}
+
+		responses.AuthorizedUser(c, newUser)
+	}
+}
@@ -0,0 +1,106 @@
+package me
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
+	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// @Tags        Me
+// @Summary     Delete Me
+// @Description Permanently delete my account & personal data, signing out all sessions. My posts & pages are either anonymized or reassigned to another user, depending on the server's policy.
+// @Router      /v1/auth/me [delete]
+// @Security    BearerAuth
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       form body     object{password=string} true "Password confirmation form"
+// @Success     204
+// @Failure     400  {object} object{message=string}
+// @Failure     401  {object} object{message=string}
+// @Failure     403  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func DeleteMe(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel     = context.WithTimeout(context.Background(), maxCtxDuration)
+			me              *models.UserModel
+			author          models.UserCommonModel
+			superAdminCount int64
+			err             error
+		)
+
+		defer cancel()
+		defer func() {
+			// Only the placeholder is logged, not the erased personal data.
+			var (
+				actor  *models.UserModel
+				target = audit.UserTarget("", nil)
+			)
+
+			if me != nil {
+				actor = &models.UserModel{UID: me.UID, FirstName: "Deleted", LastName: "User"}
+				target.UID = me.UID.Hex()
+			}
+			audit.RecordActor(ctx, c, svc, models.AuditActionDeleteMe, actor, target)
+		}()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		for _, role := range me.Roles {
+			if role.Level != models.RoleLevelSuperAdmin {
+				continue
+			}
+			if superAdminCount, err = svc.User.Count(ctx, bson.M{
+				"$and": []bson.M{
+					{"deletedat": bson.M{"$eq": primitive.Null{}}},
+					{"roles.level": bson.M{"$eq": models.RoleLevelSuperAdmin}}}},
+			); err != nil {
+				responses.InternalServerError(c, err)
+				return
+			}
+			if superAdminCount <= 1 {
+				responses.LastSuperAdmin(c)
+				return
+			}
+		}
+		if author, err = svc.User.GetSuccessorAuthor(ctx, me); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = svc.User.EraseOne(ctx, me, author); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = svc.DataExport.DeleteMany(ctx, bson.M{
+			"owner._id": bson.M{"$eq": me.UID}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = svc.LoginAttempt.Clear(
+			ctx, svc.LoginAttempt.AccountKey(me.UID.Hex()),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.SigningOut(c)
+	}
+}
@@ -0,0 +1,194 @@
+package me
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/queue"
+	"github.com/misterabdul/goblog-server/internal/queue/client"
+	"github.com/misterabdul/goblog-server/internal/queue/payloads"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// @Tags        Me
+// @Summary     Export Me
+// @Description Request an archive of my personal data: profile, posts & pages with their contents, comments on my posts & notifications. I'm notified once it's ready, the pending export is returned when there's one already.
+// @Router      /v1/auth/me/export [post]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Success     202 {object} object{data=object{uid=string,status=string,size=int,expiresAt=time,createdAt=time,finishedAt=time}}
+// @Failure     401 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func ExportMe(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			queueClient = client.GetClient()
+			me          *models.UserModel
+			dataExport  *models.DataExportModel
+			err         error
+		)
+
+		defer cancel()
+		defer queueClient.Disconnect()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if dataExport, err = svc.DataExport.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"owner._id": bson.M{"$eq": me.UID}},
+				{"status": bson.M{"$eq": models.DataExportStatusPending}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if dataExport != nil {
+			responses.AcceptedDataExport(c, dataExport)
+			return
+		}
+		if dataExport, err = svc.DataExport.CreateOne(ctx, me); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = queueClient.NewTask(
+			queue.ExportMe,
+			payloads.NewExportMePayload(*dataExport),
+		); err != nil {
+			svc.DataExport.FailOne(ctx, dataExport)
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.AcceptedDataExport(c, dataExport)
+	}
+}
+
+// @Tags        Me
+// @Summary     Get My Data Exports
+// @Description Get my requested data exports, leaving out the expired ones.
+// @Router      /v1/auth/me/exports [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Success     200 {object} object{data=[]object{uid=string,status=string,size=int,expiresAt=time,createdAt=time,finishedAt=time}}
+// @Success     204
+// @Failure     401 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func GetMyDataExports(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			now         = primitive.NewDateTimeFromTime(time.Now())
+			me          *models.UserModel
+			dataExports []*models.DataExportModel
+			err         error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if dataExports, err = svc.DataExport.GetMany(ctx, bson.M{
+			"$and": []bson.M{
+				{"owner._id": bson.M{"$eq": me.UID}},
+				{"expiresat": bson.M{"$not": bson.M{"$lte": now}}}}},
+			options.Find().SetSort(bson.M{"createdat": -1}),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if len(dataExports) == 0 {
+			responses.NoContent(c)
+			return
+		}
+
+		responses.MyDataExports(c, dataExports)
+	}
+}
+
+// @Tags        Me
+// @Summary     Download My Data Export
+// @Description Download the zip archive of one of my ready data exports.
+// @Router      /v1/auth/me/export/{uid}/download [get]
+// @Security    BearerAuth
+// @Produce     application/zip
+// @Param       uid path string true "Data export's UID"
+// @Success     200
+// @Failure     400 {object} object{message=string}
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     409 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func DownloadMyDataExport(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel        = context.WithTimeout(context.Background(), maxCtxDuration)
+			now                = primitive.NewDateTimeFromTime(time.Now())
+			me                 *models.UserModel
+			dataExport         *models.DataExportModel
+			dataExportUid      primitive.ObjectID
+			dataExportUidParam = c.Param("export")
+			archive            io.ReadCloser
+			err                error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if dataExportUid, err = primitive.ObjectIDFromHex(dataExportUidParam); err != nil {
+			responses.IncorrectDataExportId(c, err)
+			return
+		}
+		if dataExport, err = svc.DataExport.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"_id": bson.M{"$eq": dataExportUid}},
+				{"owner._id": bson.M{"$eq": me.UID}},
+				{"expiresat": bson.M{"$not": bson.M{"$lte": now}}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if dataExport == nil {
+			responses.NotFound(c, errors.New("data export not found"))
+			return
+		}
+		if dataExport.Status != models.DataExportStatusReady {
+			responses.DataExportNotReady(c)
+			return
+		}
+		if archive, err = svc.DataExport.OpenArchive(ctx, dataExport); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		defer archive.Close()
+
+		responses.DataExportArchive(c, dataExport, archive)
+	}
+}
@@ -0,0 +1,174 @@
+package me
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
+	"github.com/misterabdul/goblog-server/internal/http/requests"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// @Tags        Me
+// @Summary     Get My Personal Tokens
+// @Description Get my personal API tokens, without the tokens themselves.
+// @Router      /v1/auth/me/tokens [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Success     200 {object} object{data=[]object{uid=string,name=string,hint=string,scopes=[]string,expiresAt=time,lastUsedAt=time,createdAt=time}}
+// @Success     204
+// @Failure     401 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func GetMyPersonalTokens(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			me          *models.UserModel
+			tokens      []*models.PersonalTokenModel
+			err         error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if tokens, err = svc.PersonalToken.GetMany(ctx, bson.M{
+			"owner._id": bson.M{"$eq": me.UID}},
+			options.Find().SetSort(bson.M{"createdat": -1}),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if len(tokens) == 0 {
+			responses.NoContent(c)
+			return
+		}
+
+		responses.MyPersonalTokens(c, tokens)
+	}
+}
+
+// @Tags        Me
+// @Summary     Create My Personal Token
+// @Description Create a long-lived personal API token limited to the given scopes, the token is only shown once.
+// @Router      /v1/auth/me/tokens [post]
+// @Security    BearerAuth
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       form body     object{name=string,expiresIn=int,scopes=[]string} true "Personal token form, expiresIn in days"
+// @Success     201  {object} object{data=object{uid=string,name=string,token=string,hint=string,scopes=[]string,expiresAt=time,lastUsedAt=time,createdAt=time}}
+// @Failure     401  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func CreateMyPersonalToken(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			me          *models.UserModel
+			form        *forms.CreatePersonalTokenForm
+			token       *models.PersonalTokenModel
+			plainToken  string
+			err         error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if form, err = requests.GetCreatePersonalTokenForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if err = form.Validate(); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		token = form.ToPersonalTokenModel(me)
+		if plainToken, err = svc.PersonalToken.CreateOne(ctx, token); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.CreatedPersonalToken(c, token, plainToken)
+	}
+}
+
+// @Tags        Me
+// @Summary     Delete My Personal Token
+// @Description Revoke one of my personal API tokens.
+// @Router      /v1/auth/me/token/{uid} [delete]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path string true "Personal token's UID"
+// @Success     204
+// @Failure     400 {object} object{message=string}
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func DeleteMyPersonalToken(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel   = context.WithTimeout(context.Background(), maxCtxDuration)
+			me            *models.UserModel
+			token         *models.PersonalTokenModel
+			tokenUid      primitive.ObjectID
+			tokenUidParam = c.Param("token")
+			err           error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if tokenUid, err = primitive.ObjectIDFromHex(tokenUidParam); err != nil {
+			responses.IncorrectPersonalTokenId(c, err)
+			return
+		}
+		if token, err = svc.PersonalToken.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"owner._id": bson.M{"$eq": me.UID}},
+				{"_id": bson.M{"$eq": tokenUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if token == nil {
+			responses.NotFound(c, errors.New("personal token not found"))
+			return
+		}
+		if err = svc.PersonalToken.DeleteOne(ctx, token); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
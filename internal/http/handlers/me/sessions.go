@@ -0,0 +1,168 @@
+package me
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/service"
+	"github.com/misterabdul/goblog-server/pkg/jwt"
+)
+
+// @Tags        Me
+// @Summary     Get My Sessions
+// @Description Get the devices I'm currently signed in on.
+// @Router      /v1/auth/me/sessions [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Success     200 {object} object{data=[]object{uid=string,ip=string,userAgent=string,current=bool,lastUsedAt=time,expiresAt=time,createdAt=time}}
+// @Success     204
+// @Failure     401 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func GetMySessions(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			me          *models.UserModel
+			claims      *jwt.CustomClaims
+			sessions    []*models.SessionModel
+			err         error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if claims, err = authenticate.GetAuthenticatedClaim(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if sessions, err = svc.Session.GetMany(ctx, bson.M{
+			"owner._id": bson.M{"$eq": me.UID}},
+			options.Find().SetSort(bson.M{"lastusedat": -1}),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if len(sessions) == 0 {
+			responses.NoContent(c)
+			return
+		}
+
+		responses.MySessions(c, sessions, claims.FamilyID)
+	}
+}
+
+// @Tags        Me
+// @Summary     Delete My Session
+// @Description Sign out one of my sessions by revoking its refresh token.
+// @Router      /v1/auth/me/session/{uid} [delete]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path string true "Session's UID"
+// @Success     204
+// @Failure     400 {object} object{message=string}
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func DeleteMySession(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel     = context.WithTimeout(context.Background(), maxCtxDuration)
+			me              *models.UserModel
+			session         *models.SessionModel
+			sessionUid      primitive.ObjectID
+			sessionUidParam = c.Param("session")
+			err             error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if sessionUid, err = primitive.ObjectIDFromHex(sessionUidParam); err != nil {
+			responses.IncorrectSessionId(c, err)
+			return
+		}
+		if session, err = svc.Session.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"owner._id": bson.M{"$eq": me.UID}},
+				{"_id": bson.M{"$eq": sessionUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if session == nil {
+			responses.NotFound(c, errors.New("session not found"))
+			return
+		}
+		if err = svc.RevokedToken.RevokeFamily(
+			ctx, me.ToCommonModel(), session.UID.Hex(), session.ExpiresAt.Time(),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = svc.Session.DeleteOne(ctx, session); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
+
+// @Tags        Me
+// @Summary     Delete My Sessions
+// @Description Sign out everywhere by revoking all of my tokens, including the current one.
+// @Router      /v1/auth/me/sessions [delete]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Success     204
+// @Failure     401 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func DeleteMySessions(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			me          *models.UserModel
+			err         error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if err = svc.User.RevokeTokens(ctx, me); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
@@ -0,0 +1,218 @@
+package me
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
+	"github.com/misterabdul/goblog-server/internal/http/requests"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/service"
+	"github.com/misterabdul/goblog-server/pkg/totp"
+)
+
+// @Tags        Me
+// @Summary     Enroll Two-Factor
+// @Description Generate a new TOTP secret to be confirmed with a code from the authenticator app.
+// @Router      /v1/auth/me/2fa [post]
+// @Security    BearerAuth
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       form body     object{password=string} true "Password confirmation form"
+// @Success     200  {object} object{data=object{secret=string,uri=string}}
+// @Failure     400  {object} object{message=string}
+// @Failure     401  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func EnrollTwoFactor(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			me          *models.UserModel
+			issuer      string
+			secret      string
+			ok          bool
+			err         error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if me.TwoFactor.EnabledAt != nil {
+			responses.TwoFactorAlreadyEnabled(c, errors.New("two-factor already enabled"))
+			return
+		}
+		if secret, err = svc.User.EnrollTwoFactor(ctx, me); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if issuer, ok = os.LookupEnv("APP_NAME"); !ok {
+			issuer = "goblog"
+		}
+
+		responses.TwoFactorEnrollment(c, secret, totp.URI(issuer, me.Email, secret))
+	}
+}
+
+// @Tags        Me
+// @Summary     Confirm Two-Factor
+// @Description Enable two-factor authentication with a code of the enrolled secret, returning the one-time recovery codes.
+// @Router      /v1/auth/me/2fa/confirm [post]
+// @Security    BearerAuth
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       form body     object{code=string} true "Two-factor code form"
+// @Success     200  {object} object{data=object{recoveryCodes=[]string}}
+// @Failure     400  {object} object{message=string}
+// @Failure     401  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func ConfirmTwoFactor(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel   = context.WithTimeout(context.Background(), maxCtxDuration)
+			me            *models.UserModel
+			form          *forms.TwoFactorCodeForm
+			recoveryCodes []string
+			valid         bool
+			err           error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if form, err = requests.GetTwoFactorCodeForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if me.TwoFactor.EnabledAt != nil {
+			responses.TwoFactorAlreadyEnabled(c, errors.New("two-factor already enabled"))
+			return
+		}
+		if recoveryCodes, valid, err = svc.User.ConfirmTwoFactor(
+			ctx, me, form.Code,
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if !valid {
+			responses.InvalidTwoFactorCode(c, errors.New("invalid two-factor code"))
+			return
+		}
+
+		responses.TwoFactorRecoveryCodes(c, recoveryCodes)
+	}
+}
+
+// @Tags        Me
+// @Summary     Regenerate Two-Factor Recovery Codes
+// @Description Replace all of my recovery codes with new ones.
+// @Router      /v1/auth/me/2fa/recovery-codes [post]
+// @Security    BearerAuth
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       form body     object{password=string} true "Password confirmation form"
+// @Success     200  {object} object{data=object{recoveryCodes=[]string}}
+// @Failure     400  {object} object{message=string}
+// @Failure     401  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func RegenerateTwoFactorRecoveryCodes(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel   = context.WithTimeout(context.Background(), maxCtxDuration)
+			me            *models.UserModel
+			recoveryCodes []string
+			err           error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if me.TwoFactor.EnabledAt == nil {
+			responses.TwoFactorNotEnabled(c, errors.New("two-factor not enabled"))
+			return
+		}
+		if recoveryCodes, err = svc.User.RegenerateRecoveryCodes(ctx, me); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.TwoFactorRecoveryCodes(c, recoveryCodes)
+	}
+}
+
+// @Tags        Me
+// @Summary     Disable Two-Factor
+// @Description Disable my two-factor authentication.
+// @Router      /v1/auth/me/2fa [delete]
+// @Security    BearerAuth
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       form body object{password=string} true "Password confirmation form"
+// @Success     204
+// @Failure     400 {object} object{message=string}
+// @Failure     401 {object} object{message=string}
+// @Failure     422 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func DisableTwoFactor(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			me          *models.UserModel
+			err         error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if me.TwoFactor.EnabledAt == nil && me.TwoFactor.PendingSecret == "" {
+			responses.TwoFactorNotEnabled(c, errors.New("two-factor not enabled"))
+			return
+		}
+		if err = svc.User.ResetTwoFactor(ctx, me); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
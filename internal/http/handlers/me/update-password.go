@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
 	"github.com/misterabdul/goblog-server/internal/http/forms"
 	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
 	"github.com/misterabdul/goblog-server/internal/http/requests"
@@ -16,7 +17,7 @@ import (
 
 // @Tags        Me
 // @Summary     Update Me Password
-// @Description Update my password.
+// @Description Update my password, signing out all of my sessions.
 // @Router      /v1/auth/me/password [put]
 // @Router      /v1/auth/me/password [patch]
 // @Security    BearerAuth
@@ -44,6 +45,9 @@ func UpdateMePassword(
 		)
 
 		defer cancel()
+		defer func() {
+			audit.Record(ctx, c, svc, models.AuditActionUpdatePassword, audit.UserTarget("", me))
+		}()
 		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
 			responses.Unauthenticated(c, err)
 			return
@@ -64,6 +68,10 @@ func UpdateMePassword(
 			responses.InternalServerError(c, err)
 			return
 		}
+		if err = svc.User.RevokeTokens(ctx, updatedMe); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
 
 		responses.NoContent(c)
 	}
@@ -26,7 +26,7 @@ import (
 // @Accept      application/msgpack
 // @Produce     application/json
 // @Produce     application/msgpack
-// @Param       form body     object{firstname=string,lastname=string,username=string,email=string} true "Update me form"
+// @Param       form body     object{firstname=string,lastname=string,username=string,email=string,bio=string,avatar=string,website=string,socials=[]object{network=string,url=string}} true "Update me form"
 // @Success     201
 // @Failure     401  {object} object{message=string}
 // @Failure     422  {object} object{message=string}
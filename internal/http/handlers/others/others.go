@@ -4,6 +4,8 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/misterabdul/goblog-server/internal/http/responses"
+	internalJwt "github.com/misterabdul/goblog-server/internal/pkg/jwt"
+	"github.com/misterabdul/goblog-server/pkg/jwt"
 )
 
 func Ping() gin.HandlerFunc {
@@ -20,3 +22,27 @@ func NotFound() gin.HandlerFunc {
 		responses.NotFound(c, nil)
 	}
 }
+
+// @Tags        Authentication
+// @Summary     JSON Web Key Set
+// @Description Get the public keys for verifying the issued tokens.
+// @Router      /.well-known/jwks.json [get]
+// @Produce     application/json
+// @Success     200 {object} object{keys=[]object{kty=string,use=string,kid=string,alg=string}}
+// @Failure     500 {object} object{message=string}
+func JSONWebKeySet() gin.HandlerFunc {
+
+	return func(c *gin.Context) {
+		var (
+			keys *jwt.KeySet
+			err  error
+		)
+
+		if keys, err = internalJwt.GetKeySet(); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.JSONWebKeySet(c, keys.JSONWebKeys())
+	}
+}
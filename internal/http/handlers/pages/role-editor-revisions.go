@@ -0,0 +1,322 @@
+package pages
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	internalGin "github.com/misterabdul/goblog-server/internal/pkg/gin"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// @Tags        Page (Editor)
+// @Summary     Get Page Revisions
+// @Description Get the revisions of a page, newest first.
+// @Router      /v1/auth/editor/page/{uid}/revisions [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid  path     string true  "Page's UID"
+// @Param       show query    int    false "Number of data to be shown."
+// @Param       page query    int    false "Selected page of data."
+// @Success     200  {object} object{data=[]object{uid=string,number=int,slug=string,title=string,changedFields=[]string,editor=object{uid=string,username=string,email=string,firstName=string,lastName=string},restoredFrom=int,createdAt=time}}
+// @Failure     204
+// @Failure     400  {object} object{message=string}
+// @Failure     401  {object} object{message=string}
+// @Failure     404  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func GetPageRevisions(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			page         *models.PageModel
+			revisions    []*models.PageRevisionModel
+			pageUid      primitive.ObjectID
+			pageUidParam = c.Param("page")
+			err          error
+		)
+
+		defer cancel()
+		if pageUid, err = primitive.ObjectIDFromHex(pageUidParam); err != nil {
+			responses.IncorrectPageId(c, err)
+			return
+		}
+		if page, err = svc.Page.GetOne(ctx, bson.M{
+			"_id": bson.M{"$eq": pageUid}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if page == nil {
+			responses.NotFound(c, errors.New("page not found"))
+			return
+		}
+		if revisions, err = svc.Page.GetManyRevisions(ctx, page,
+			internalGin.CreateFindOptions(
+				int(*internalGin.GetShowQuery(c)),
+				int(*internalGin.GetPageQuery(c)),
+				"number", false),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if len(revisions) == 0 {
+			responses.NoContent(c)
+			return
+		}
+
+		responses.PageRevisions(c, revisions)
+	}
+}
+
+// @Tags        Page (Editor)
+// @Summary     Get Page Revision
+// @Description Get a revision of a page with its content.
+// @Router      /v1/auth/editor/page/{uid}/revision/{revision} [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid      path     string true "Page's UID"
+// @Param       revision path     string true "Revision's UID"
+// @Success     200      {object} object{data=object{uid=string,number=int,slug=string,title=string,content=string,changedFields=[]string,editor=object{uid=string,username=string,email=string,firstName=string,lastName=string},restoredFrom=int,createdAt=time}}
+// @Failure     400      {object} object{message=string}
+// @Failure     401      {object} object{message=string}
+// @Failure     404      {object} object{message=string}
+// @Failure     500      {object} object{message=string}
+func GetPageRevision(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel      = context.WithTimeout(context.Background(), maxCtxDuration)
+			page             *models.PageModel
+			revision         *models.PageRevisionModel
+			pageUid          primitive.ObjectID
+			pageUidParam     = c.Param("page")
+			revisionUid      primitive.ObjectID
+			revisionUidParam = c.Param("revision")
+			err              error
+		)
+
+		defer cancel()
+		if pageUid, err = primitive.ObjectIDFromHex(pageUidParam); err != nil {
+			responses.IncorrectPageId(c, err)
+			return
+		}
+		if revisionUid, err = primitive.ObjectIDFromHex(revisionUidParam); err != nil {
+			responses.IncorrectRevisionId(c, err)
+			return
+		}
+		if page, err = svc.Page.GetOne(ctx, bson.M{
+			"_id": bson.M{"$eq": pageUid}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if page == nil {
+			responses.NotFound(c, errors.New("page not found"))
+			return
+		}
+		if revision, err = svc.Page.GetOneRevision(ctx, page, revisionUid); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if revision == nil {
+			responses.NotFound(c, errors.New("revision not found"))
+			return
+		}
+
+		responses.PageRevision(c, revision)
+	}
+}
+
+// @Tags        Page (Editor)
+// @Summary     Diff Page Revisions
+// @Description Get the unified diff between two revisions of a page.
+// @Router      /v1/auth/editor/page/{uid}/revisions/diff [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid  path     string true "Page's UID"
+// @Param       from query    string true "Older revision's UID"
+// @Param       to   query    string true "Newer revision's UID"
+// @Success     200  {object} object{data=object{from=int,to=int,diff=string}}
+// @Failure     400  {object} object{message=string}
+// @Failure     401  {object} object{message=string}
+// @Failure     404  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func DiffPageRevisions(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			page         *models.PageModel
+			from         *models.PageRevisionModel
+			to           *models.PageRevisionModel
+			pageUid      primitive.ObjectID
+			pageUidParam = c.Param("page")
+			fromUid      primitive.ObjectID
+			toUid        primitive.ObjectID
+			err          error
+		)
+
+		defer cancel()
+		if pageUid, err = primitive.ObjectIDFromHex(pageUidParam); err != nil {
+			responses.IncorrectPageId(c, err)
+			return
+		}
+		if fromUid, err = primitive.ObjectIDFromHex(c.Query("from")); err != nil {
+			responses.IncorrectRevisionId(c, err)
+			return
+		}
+		if toUid, err = primitive.ObjectIDFromHex(c.Query("to")); err != nil {
+			responses.IncorrectRevisionId(c, err)
+			return
+		}
+		if page, err = svc.Page.GetOne(ctx, bson.M{
+			"_id": bson.M{"$eq": pageUid}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if page == nil {
+			responses.NotFound(c, errors.New("page not found"))
+			return
+		}
+		if from, err = svc.Page.GetOneRevision(ctx, page, fromUid); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if to, err = svc.Page.GetOneRevision(ctx, page, toUid); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if from == nil || to == nil {
+			responses.NotFound(c, errors.New("revision not found"))
+			return
+		}
+
+		responses.RevisionDiff(c, from.Number, to.Number, svc.Page.DiffRevisions(from, to))
+	}
+}
+
+// @Tags        Page (Editor)
+// @Summary     Restore Page Revision
+// @Description Bring a page back to the state of a revision, saved as a new revision.
+// @Router      /v1/auth/editor/page/{uid}/revision/{revision}/restore [put]
+// @Router      /v1/auth/editor/page/{uid}/revision/{revision}/restore [patch]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid      path string true "Page's UID"
+// @Param       revision path string true "Revision's UID"
+// @Success     204
+// @Failure     400 {object} object{message=string}
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     409 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func RestorePageRevision(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel      = context.WithTimeout(context.Background(), maxCtxDuration)
+			me               *models.UserModel
+			page             *models.PageModel
+			pageContent      *models.PageContentModel
+			revision         *models.PageRevisionModel
+			pageUid          primitive.ObjectID
+			pageUidParam     = c.Param("page")
+			revisionUid      primitive.ObjectID
+			revisionUidParam = c.Param("revision")
+			slugTaken        bool
+			err              error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if pageUid, err = primitive.ObjectIDFromHex(pageUidParam); err != nil {
+			responses.IncorrectPageId(c, err)
+			return
+		}
+		if revisionUid, err = primitive.ObjectIDFromHex(revisionUidParam); err != nil {
+			responses.IncorrectRevisionId(c, err)
+			return
+		}
+		if page, pageContent, err = svc.Page.GetOneWithContent(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"_id": bson.M{"$eq": pageUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if page == nil || pageContent == nil {
+			responses.NotFound(c, errors.New("page not found"))
+			return
+		}
+		if revision, err = svc.Page.GetOneRevision(ctx, page, revisionUid); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if revision == nil {
+			responses.NotFound(c, errors.New("revision not found"))
+			return
+		}
+		if slugTaken, err = isRevisionSlugTaken(svc, ctx, page, revision); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if slugTaken {
+			responses.RevisionSlugTaken(c, errors.New("slug exists"))
+			return
+		}
+		if err = svc.Page.RestoreRevisionOne(ctx, page, pageContent, revision, me); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
+
+func isRevisionSlugTaken(
+	svc *service.Service,
+	ctx context.Context,
+	page *models.PageModel,
+	revision *models.PageRevisionModel,
+) (taken bool, err error) {
+	var count int64
+
+	if count, err = svc.Page.Count(ctx, bson.M{
+		"$and": []bson.M{
+			{"_id": bson.M{"$ne": page.UID}},
+			{"slug": bson.M{"$eq": revision.Slug}}}},
+	); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
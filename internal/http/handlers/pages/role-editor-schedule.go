@@ -0,0 +1,146 @@
+package pages
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+	"github.com/misterabdul/goblog-server/internal/http/requests"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// @Tags        Page (Editor)
+// @Summary     Schedule Page
+// @Description Schedule an unpublished page to be published at the given time, replacing its previous schedule.
+// @Router      /v1/auth/editor/page/{uid}/schedule [put]
+// @Router      /v1/auth/editor/page/{uid}/schedule [patch]
+// @Security    BearerAuth
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid  path     string                 true "Page's UID"
+// @Param       form body     object{publishAt=time} true "Schedule publish form"
+// @Success     204
+// @Failure     400  {object} object{message=string}
+// @Failure     401  {object} object{message=string}
+// @Failure     404  {object} object{message=string}
+// @Failure     409  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func SchedulePage(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			page         *models.PageModel
+			pageUid      primitive.ObjectID
+			pageUidParam = c.Param("page")
+			form         *forms.SchedulePublishForm
+			err          error
+		)
+
+		defer cancel()
+		if pageUid, err = primitive.ObjectIDFromHex(pageUidParam); err != nil {
+			responses.IncorrectPageId(c, err)
+			return
+		}
+		if form, err = requests.GetSchedulePublishForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if err = form.Validate(); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if page, err = svc.Page.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"_id": bson.M{"$eq": pageUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if page == nil {
+			responses.NotFound(c, errors.New("page not found"))
+			return
+		}
+		if page.PublishedAt != nil {
+			responses.AlreadyPublished(c, errors.New("page already published"))
+			return
+		}
+		if err = svc.Page.ScheduleOne(ctx, page, form.PublishAt); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
+
+// @Tags        Page (Editor)
+// @Summary     Unschedule Page
+// @Description Cancel the publish schedule of a page if scheduled.
+// @Router      /v1/auth/editor/page/{uid}/schedule [delete]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path     string true "Page's UID"
+// @Success     204
+// @Failure     400 {object} object{message=string}
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func UnschedulePage(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			page         *models.PageModel
+			pageUid      primitive.ObjectID
+			pageUidParam = c.Param("page")
+			err          error
+		)
+
+		defer cancel()
+		if pageUid, err = primitive.ObjectIDFromHex(pageUidParam); err != nil {
+			responses.IncorrectPageId(c, err)
+			return
+		}
+		if page, err = svc.Page.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"_id": bson.M{"$eq": pageUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if page == nil {
+			responses.NotFound(c, errors.New("page not found"))
+			return
+		}
+		if page.PublishAt == nil {
+			responses.NoContent(c)
+			return
+		}
+		if err = svc.Page.UnscheduleOne(ctx, page); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
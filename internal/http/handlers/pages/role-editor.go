@@ -10,6 +10,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
 	"github.com/misterabdul/goblog-server/internal/http/forms"
 	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
 	"github.com/misterabdul/goblog-server/internal/http/requests"
@@ -26,7 +27,7 @@ import (
 // @Produce     application/json
 // @Produce     application/msgpack
 // @Param       uid path     string true "Page's UID or slug"
-// @Success     200 {object} object{data=object{uid=string,slug=string,title=string,content=string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},publishedAt=time,updatedAt=time,createdAt=time,deletedAt=time}}
+// @Success     200 {object} object{data=object{uid=string,slug=string,title=string,analytics=object{wordCount=int,readingTime=int,outline=[]object{level=int,text=string,anchor=string},imageCount=int,internalLinks=[]string,externalLinks=[]string,seoChecks=[]object{name=string,passed=bool,message=string}},content=string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},publishedAt=time,updatedAt=time,createdAt=time,deletedAt=time}}
 // @Failure     401 {object} object{message=string}
 // @Failure     404 {object} object{message=string}
 // @Failure     500 {object} object{message=string}
@@ -76,8 +77,8 @@ func GetPage(
 // @Param       page  query    int    false "Selected page of data."
 // @Param       order query    string false "Selected field to order data with."
 // @Param       asc   query    string false "Ascending or descending, e.g.: ?asc=false."
-// @Param       type  query    string false "Filter data by type, e.g.: ?type=trash, ?type=published, ?type=draft."
-// @Success     200   {object} object{data=[]object{uid=string,slug=string,title=string,content=string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},publishedAt=time,updatedAt=time,createdAt=time,deletedAt=time}}
+// @Param       type  query    string false "Filter data by type, e.g.: ?type=trash, ?type=published, ?type=scheduled, ?type=draft."
+// @Success     200   {object} object{data=[]object{uid=string,slug=string,title=string,readingTime=int,content=string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},publishedAt=time,updatedAt=time,createdAt=time,deletedAt=time}}
 // @Success     204
 // @Failure     401   {object} object{message=string}
 // @Failure     500   {object} object{message=string}
@@ -122,7 +123,7 @@ func GetPages(
 // @Param       page  query    int    false "Selected page of data."
 // @Param       order query    string false "Selected field to order data with."
 // @Param       asc   query    string false "Ascending or descending, e.g.: ?asc=false."
-// @Param       type  query    string false "Filter data by type, e.g.: ?type=trash, ?type=published, ?type=draft."
+// @Param       type  query    string false "Filter data by type, e.g.: ?type=trash, ?type=published, ?type=scheduled, ?type=draft."
 // @Success     200   {object} object{data=object{currentPage=int,totalPages=int,itemsPerPage=int,totalItems=int}}
 // @Failure     401   {object} object{message=string}
 // @Failure     500   {object} object{message=string}
@@ -161,8 +162,8 @@ func GetPagesStats(
 // @Accept      application/msgpack
 // @Produce     application/json
 // @Produce     application/msgpack
-// @Param       form body     object{slug=string,title=string,content=string,publishNow=boolean} true "Create page form"
-// @Success     200  {object} object{data=object{uid=string,slug=string,title=string,content=string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},publishedAt=time,updatedAt=time,createdAt=time,deletedAt=time}}
+// @Param       form body     object{slug=string,title=string,content=string,publishNow=boolean,publishAt=time} true "Create page form"
+// @Success     200  {object} object{data=object{uid=string,slug=string,title=string,analytics=object{wordCount=int,readingTime=int,outline=[]object{level=int,text=string,anchor=string},imageCount=int,internalLinks=[]string,externalLinks=[]string,seoChecks=[]object{name=string,passed=bool,message=string}},content=string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},publishedAt=time,updatedAt=time,createdAt=time,deletedAt=time}}
 // @Failure     401  {object} object{message=string}
 // @Failure     422  {object} object{message=string}
 // @Failure     500  {object} object{message=string}
@@ -202,6 +203,12 @@ func CreatePage(
 			responses.InternalServerError(c, err)
 			return
 		}
+		if form.PublishAt != nil {
+			if err = svc.Page.ScheduleOne(ctx, page, *form.PublishAt); err != nil {
+				responses.InternalServerError(c, err)
+				return
+			}
+		}
 
 		responses.MyPage(c, page, pageContent)
 	}
@@ -327,8 +334,8 @@ func DepublishPage(
 // @Accept      application/msgpack
 // @Produce     application/json
 // @Produce     application/msgpack
-// @Param       uid  path     string                                                             true "Page's UID or slug"
-// @Param       form body     object{slug=string,title=string,content=string,publishNow=boolean} true "Update page form"
+// @Param       uid  path     string                                                                            true "Page's UID or slug"
+// @Param       form body     object{slug=string,title=string,content=string,publishNow=boolean,publishAt=time} true "Update page form"
 // @Success     204
 // @Failure     401  {object} object{message=string}
 // @Failure     404  {object} object{message=string}
@@ -342,6 +349,7 @@ func UpdatePage(
 	return func(c *gin.Context) {
 		var (
 			ctx, cancel        = context.WithTimeout(context.Background(), maxCtxDuration)
+			me                 *models.UserModel
 			page               *models.PageModel
 			updatedPage        *models.PageModel
 			pageContent        *models.PageContentModel
@@ -353,6 +361,10 @@ func UpdatePage(
 		)
 
 		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
 		if pageUid, err = primitive.ObjectIDFromHex(pageUidParam); err != nil {
 			responses.IncorrectPageId(c, err)
 			return
@@ -383,10 +395,16 @@ func UpdatePage(
 			responses.InternalServerError(c, err)
 			return
 		}
-		if err = svc.Page.UpdateOneWithContent(ctx, updatedPage, updatedPageContent); err != nil {
+		if err = svc.Page.UpdateOneWithContent(ctx, updatedPage, updatedPageContent, me); err != nil {
 			responses.InternalServerError(c, err)
 			return
 		}
+		if form.PublishAt != nil {
+			if err = svc.Page.ScheduleOne(ctx, updatedPage, *form.PublishAt); err != nil {
+				responses.InternalServerError(c, err)
+				return
+			}
+		}
 
 		responses.NoContent(c)
 	}
@@ -529,6 +547,9 @@ func DeletePage(
 		)
 
 		defer cancel()
+		defer func() {
+			audit.Record(ctx, c, svc, models.AuditActionDeletePage, audit.PageTarget(pageUidParam, page))
+		}()
 		if pageUid, err = primitive.ObjectIDFromHex(pageUidParam); err != nil {
 			responses.IncorrectPageId(c, err)
 			return
@@ -566,10 +587,16 @@ func readCommonQueryParams(c *gin.Context) []bson.M {
 		extraQuery = append(extraQuery,
 			bson.M{"publishedat": bson.M{"$ne": primitive.Null{}}},
 			bson.M{"deletedat": bson.M{"$eq": primitive.Null{}}})
+	case typeQuery == "scheduled":
+		extraQuery = append(extraQuery,
+			bson.M{"publishat": bson.M{"$ne": primitive.Null{}}},
+			bson.M{"publishedat": bson.M{"$eq": primitive.Null{}}},
+			bson.M{"deletedat": bson.M{"$eq": primitive.Null{}}})
 	case typeQuery == "draft":
 		fallthrough
 	default:
 		extraQuery = append(extraQuery,
+			bson.M{"publishat": bson.M{"$eq": primitive.Null{}}},
 			bson.M{"publishedat": bson.M{"$eq": primitive.Null{}}},
 			bson.M{"deletedat": bson.M{"$eq": primitive.Null{}}})
 	}
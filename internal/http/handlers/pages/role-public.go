@@ -22,10 +22,11 @@ import (
 // @Router      /v1/page/{uid} [get]
 // @Produce     application/json
 // @Produce     application/msgpack
-// @Param       uid path     string true "Post's UID or slug"
-// @Success     200 {object} object{data=object{uid=string,slug=string,title=string,content=string,publishedAt=time}}
-// @Failure     404 {object} object{message=string}
-// @Failure     500 {object} object{message=string}
+// @Param       uid    path     string true  "Post's UID or slug"
+// @Param       format query    string false "Content format: markdown, html or both, e.g.: ?format=html."
+// @Success     200    {object} object{data=object{uid=string,slug=string,title=string,readingTime=int,content=string,html=string,tableOfContents=[]object{level=int,text=string,anchor=string},publishedAt=time}}
+// @Failure     404    {object} object{message=string}
+// @Failure     500    {object} object{message=string}
 func GetPublicPage(
 	maxCtxDuration time.Duration,
 	svc *service.Service,
@@ -70,10 +71,11 @@ func GetPublicPage(
 // @Router      /v1/page/{slug} [get]
 // @Produce     application/json
 // @Produce     application/msgpack
-// @Param       slug query    string false "The slug query."
-// @Success     200  {object} object{data=object{uid=string,slug=string,title=string,content=string,publishedAt=time}}
-// @Failure     404  {object} object{message=string}
-// @Failure     500  {object} object{message=string}
+// @Param       slug   query    string false "The slug query."
+// @Param       format query    string false "Content format: markdown, html or both, e.g.: ?format=html."
+// @Success     200    {object} object{data=object{uid=string,slug=string,title=string,readingTime=int,content=string,html=string,tableOfContents=[]object{level=int,text=string,anchor=string},publishedAt=time}}
+// @Failure     404    {object} object{message=string}
+// @Failure     500    {object} object{message=string}
 func GetPublicPageBySlug(
 	maxCtxDuration time.Duration,
 	svc *service.Service,
@@ -121,7 +123,7 @@ func GetPublicPageBySlug(
 // @Param       page  query    int    false "Selected page of data."
 // @Param       order query    string false "Selected field to order data with."
 // @Param       asc   query    string false "Ascending or descending, e.g.: ?asc=false."
-// @Success     200   {object} object{data=[]object{uid=string,slug=string,title=string,content=string,publishedAt=time}}
+// @Success     200   {object} object{data=[]object{uid=string,slug=string,title=string,readingTime=int,content=string,publishedAt=time}}
 // @Success     204
 // @Failure     404   {object} object{message=string}
 // @Failure     500   {object} object{message=string}
@@ -167,7 +169,7 @@ func GetPublicPages(
 // @Param       page  query    int    false "Selected page of data."
 // @Param       order query    string false "Selected field to order data with."
 // @Param       asc   query    string false "Ascending or descending, e.g.: ?asc=false."
-// @Success     200   {object} object{data=[]object{uid=string,slug=string,title=string,content=string,publishedAt=time}}
+// @Success     200   {object} object{data=[]object{uid=string,slug=string,title=string,readingTime=int,content=string,publishedAt=time}}
 // @Success     204
 // @Failure     404   {object} object{message=string}
 // @Failure     500   {object} object{message=string}
@@ -0,0 +1,89 @@
+package posts
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+	"github.com/misterabdul/goblog-server/internal/http/requests"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// @Tags        Post (Editor)
+// @Summary     Update Post Contributors
+// @Description Replace the co-authors & editor credits of a post, its author stays credited.
+// @Router      /v1/auth/editor/post/{uid}/contributors [put]
+// @Router      /v1/auth/editor/post/{uid}/contributors [patch]
+// @Security    BearerAuth
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid  path     string                                                 true "Post's UID"
+// @Param       form body     object{contributors=[]object{user=string,role=string}} true "Update post contributors form, the role is either co-author or editor"
+// @Success     204
+// @Failure     400  {object} object{message=string}
+// @Failure     401  {object} object{message=string}
+// @Failure     404  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func UpdatePostContributors(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			post         *models.PostModel
+			contributors []models.PostContributorModel
+			postUid      primitive.ObjectID
+			postUidParam = c.Param("post")
+			form         *forms.UpdatePostContributorsForm
+			err          error
+		)
+
+		defer cancel()
+		if postUid, err = primitive.ObjectIDFromHex(postUidParam); err != nil {
+			responses.IncorrectPostId(c, err)
+			return
+		}
+		if post, err = svc.Post.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"_id": bson.M{"$eq": postUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if post == nil {
+			responses.NotFound(c, errors.New("post not found"))
+			return
+		}
+		if form, err = requests.GetUpdatePostContributorsForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if err = form.Validate(svc, ctx, post); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if contributors, err = form.ToContributors(); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = svc.Post.UpdateOneContributors(ctx, post, contributors); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
@@ -0,0 +1,214 @@
+package posts
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
+	"github.com/misterabdul/goblog-server/internal/http/requests"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// @Tags        Post (Editor)
+// @Summary     Approve Post
+// @Description Approve a submitted post, letting its author publish it.
+// @Router      /v1/auth/editor/post/{uid}/approve [put]
+// @Router      /v1/auth/editor/post/{uid}/approve [patch]
+// @Security    BearerAuth
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid  path     string             true  "Post's UID"
+// @Param       form body     object{note=string} false "Approve post form"
+// @Success     204
+// @Failure     400  {object} object{message=string}
+// @Failure     401  {object} object{message=string}
+// @Failure     404  {object} object{message=string}
+// @Failure     409  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func ApprovePost(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			me           *models.UserModel
+			post         *models.PostModel
+			postUid      primitive.ObjectID
+			postUidParam = c.Param("post")
+			form         *forms.PostReviewForm
+			err          error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if postUid, err = primitive.ObjectIDFromHex(postUidParam); err != nil {
+			responses.IncorrectPostId(c, err)
+			return
+		}
+		if form, err = requests.GetPostReviewForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if post, err = svc.Post.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"_id": bson.M{"$eq": postUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if post == nil {
+			responses.NotFound(c, errors.New("post not found"))
+			return
+		}
+		if !post.CanTransitTo(models.PostStatusApproved) {
+			responses.IncorrectPostTransition(c, post, models.PostStatusApproved)
+			return
+		}
+		if err = svc.Post.TransitOne(
+			ctx, post, models.PostStatusApproved, me, form.Note,
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = notifyPostAuthor(ctx, svc, post, me,
+			"Your post is approved",
+			"\""+post.Title+"\" is approved & ready to be published.",
+			form.Note,
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
+
+// @Tags        Post (Editor)
+// @Summary     Request Post Changes
+// @Description Send a submitted or approved post back to its author with the requested changes, cancelling its schedule.
+// @Router      /v1/auth/editor/post/{uid}/request-changes [put]
+// @Router      /v1/auth/editor/post/{uid}/request-changes [patch]
+// @Security    BearerAuth
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid  path     string             true "Post's UID"
+// @Param       form body     object{note=string} true "Request post changes form"
+// @Success     204
+// @Failure     400  {object} object{message=string}
+// @Failure     401  {object} object{message=string}
+// @Failure     404  {object} object{message=string}
+// @Failure     409  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func RequestPostChanges(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			me           *models.UserModel
+			post         *models.PostModel
+			postUid      primitive.ObjectID
+			postUidParam = c.Param("post")
+			form         *forms.PostChangesRequestForm
+			err          error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if postUid, err = primitive.ObjectIDFromHex(postUidParam); err != nil {
+			responses.IncorrectPostId(c, err)
+			return
+		}
+		if form, err = requests.GetPostChangesRequestForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if post, err = svc.Post.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"_id": bson.M{"$eq": postUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if post == nil {
+			responses.NotFound(c, errors.New("post not found"))
+			return
+		}
+		if !post.CanTransitTo(models.PostStatusChangesRequested) {
+			responses.IncorrectPostTransition(c, post, models.PostStatusChangesRequested)
+			return
+		}
+		if err = svc.Post.TransitOne(
+			ctx, post, models.PostStatusChangesRequested, me, form.Note,
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if err = notifyPostAuthor(ctx, svc, post, me,
+			"Changes requested on your post",
+			"\""+post.Title+"\" needs some changes before it can be approved.",
+			form.Note,
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
+
+// Notify the post's author & co-authors of the review, except the reviewer
+func notifyPostAuthor(
+	ctx context.Context,
+	svc *service.Service,
+	post *models.PostModel,
+	reviewer *models.UserModel,
+	title string,
+	content string,
+	note string,
+) (err error) {
+	if len(note) > 0 {
+		content += " Note from " + reviewer.Username + ": " + note
+	}
+	for _, contributor := range post.GetContributors() {
+		if contributor.Role == models.PostContributorRoleEditor ||
+			contributor.UID == reviewer.UID {
+			continue
+		}
+		if err = svc.Notification.SaveOne(ctx, &models.NotificationModel{
+			Title:   title,
+			Content: content,
+			Owner:   contributor.UserCommonModel},
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
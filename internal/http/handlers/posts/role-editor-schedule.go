@@ -0,0 +1,152 @@
+package posts
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
+	"github.com/misterabdul/goblog-server/internal/http/requests"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// @Tags        Post (Editor)
+// @Summary     Schedule Post
+// @Description Schedule an unpublished post to be published at the given time, replacing its previous schedule & approving it under the review policy.
+// @Router      /v1/auth/editor/post/{uid}/schedule [put]
+// @Router      /v1/auth/editor/post/{uid}/schedule [patch]
+// @Security    BearerAuth
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid  path     string                 true "Post's UID"
+// @Param       form body     object{publishAt=time} true "Schedule publish form"
+// @Success     204
+// @Failure     400  {object} object{message=string}
+// @Failure     401  {object} object{message=string}
+// @Failure     404  {object} object{message=string}
+// @Failure     409  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func SchedulePost(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			me           *models.UserModel
+			post         *models.PostModel
+			postUid      primitive.ObjectID
+			postUidParam = c.Param("post")
+			form         *forms.SchedulePublishForm
+			err          error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if postUid, err = primitive.ObjectIDFromHex(postUidParam); err != nil {
+			responses.IncorrectPostId(c, err)
+			return
+		}
+		if form, err = requests.GetSchedulePublishForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if err = form.Validate(); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if post, err = svc.Post.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"_id": bson.M{"$eq": postUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if post == nil {
+			responses.NotFound(c, errors.New("post not found"))
+			return
+		}
+		if post.PublishedAt != nil {
+			responses.AlreadyPublished(c, errors.New("post already published"))
+			return
+		}
+		if err = svc.Post.ScheduleOne(ctx, post, form.PublishAt, me); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
+
+// @Tags        Post (Editor)
+// @Summary     Unschedule Post
+// @Description Cancel the publish schedule of a post if scheduled.
+// @Router      /v1/auth/editor/post/{uid}/schedule [delete]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path     string true "Post's UID"
+// @Success     204
+// @Failure     400 {object} object{message=string}
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func UnschedulePost(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			post         *models.PostModel
+			postUid      primitive.ObjectID
+			postUidParam = c.Param("post")
+			err          error
+		)
+
+		defer cancel()
+		if postUid, err = primitive.ObjectIDFromHex(postUidParam); err != nil {
+			responses.IncorrectPostId(c, err)
+			return
+		}
+		if post, err = svc.Post.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"_id": bson.M{"$eq": postUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if post == nil {
+			responses.NotFound(c, errors.New("post not found"))
+			return
+		}
+		if post.PublishAt == nil {
+			responses.NoContent(c)
+			return
+		}
+		if err = svc.Post.UnscheduleOne(ctx, post); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
@@ -10,7 +10,9 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
 	"github.com/misterabdul/goblog-server/internal/http/forms"
+	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
 	"github.com/misterabdul/goblog-server/internal/http/requests"
 	"github.com/misterabdul/goblog-server/internal/http/responses"
 	internalGin "github.com/misterabdul/goblog-server/internal/pkg/gin"
@@ -25,7 +27,7 @@ import (
 // @Produce     application/json
 // @Produce     application/msgpack
 // @Param       uid path     string true "Post's UID or slug"
-// @Success     200 {object} object{data=object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,publishedAt=time,updatedAt=time,createdAt=time,deletedAt=time}}
+// @Success     200 {object} object{data=object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,analytics=object{wordCount=int,readingTime=int,outline=[]object{level=int,text=string,anchor=string},imageCount=int,internalLinks=[]string,externalLinks=[]string,seoChecks=[]object{name=string,passed=bool,message=string}},publishedAt=time,updatedAt=time,createdAt=time,deletedAt=time}}
 // @Failure     401 {object} object{message=string}
 // @Failure     404 {object} object{message=string}
 // @Failure     500 {object} object{message=string}
@@ -75,8 +77,8 @@ func GetPost(
 // @Param       page  query    int    false "Selected page of data."
 // @Param       order query    string false "Selected field to order data with."
 // @Param       asc   query    string false "Ascending or descending, e.g.: ?asc=false."
-// @Param       type  query    string false "Filter data by type, e.g.: ?type=trash, ?type=published, ?type=draft."
-// @Success     200   {object} object{data=[]object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,publishedAt=time,updatedAt=time,createdAt=time,deletedAt=time}}
+// @Param       type  query    string false "Filter data by type, e.g.: ?type=trash, ?type=published, ?type=scheduled, ?type=draft, ?type=submitted, ?type=changes-requested, ?type=approved."
+// @Success     200   {object} object{data=[]object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,readingTime=int,publishedAt=time,updatedAt=time,createdAt=time,deletedAt=time}}
 // @Success     204
 // @Failure     401   {object} object{message=string}
 // @Failure     500   {object} object{message=string}
@@ -121,7 +123,7 @@ func GetPosts(
 // @Param       page  query    int    false "Selected page of data."
 // @Param       order query    string false "Selected field to order data with."
 // @Param       asc   query    string false "Ascending or descending, e.g.: ?asc=false."
-// @Param       type  query    string false "Filter data by type, e.g.: ?type=trash, ?type=published, ?type=draft."
+// @Param       type  query    string false "Filter data by type, e.g.: ?type=trash, ?type=published, ?type=scheduled, ?type=draft, ?type=submitted, ?type=changes-requested, ?type=approved."
 // @Success     200   {object} object{data=object{currentPage=int,totalPages=int,itemsPerPage=int,totalItems=int}}
 // @Failure     401   {object} object{message=string}
 // @Failure     500   {object} object{message=string}
@@ -169,6 +171,7 @@ func PublishPost(
 	return func(c *gin.Context) {
 		var (
 			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			me           *models.UserModel
 			post         *models.PostModel
 			postUid      primitive.ObjectID
 			postUidParam = c.Param("post")
@@ -176,6 +179,10 @@ func PublishPost(
 		)
 
 		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
 		if postUid, err = primitive.ObjectIDFromHex(postUidParam); err != nil {
 			responses.IncorrectPostId(c, err)
 			return
@@ -196,7 +203,7 @@ func PublishPost(
 			responses.NoContent(c)
 			return
 		}
-		if err = svc.Post.PublishOne(ctx, post); err != nil {
+		if err = svc.Post.PublishOne(ctx, post, me); err != nil {
 			responses.InternalServerError(c, err)
 			return
 		}
@@ -223,6 +230,7 @@ func DepublishPost(
 	return func(c *gin.Context) {
 		var (
 			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			me           *models.UserModel
 			post         *models.PostModel
 			postUid      primitive.ObjectID
 			postUidParam = c.Param("post")
@@ -230,6 +238,10 @@ func DepublishPost(
 		)
 
 		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
 		if postUid, err = primitive.ObjectIDFromHex(postUidParam); err != nil {
 			responses.IncorrectPostId(c, err)
 			return
@@ -250,7 +262,7 @@ func DepublishPost(
 			responses.NoContent(c)
 			return
 		}
-		if err = svc.Post.RestoreOne(ctx, post); err != nil {
+		if err = svc.Post.DepublishOne(ctx, post, me); err != nil {
 			responses.InternalServerError(c, err)
 			return
 		}
@@ -267,8 +279,8 @@ func DepublishPost(
 // @Security    BearerAuth
 // @Produce     application/json
 // @Produce     application/msgpack
-// @Param       uid  path string                                                                                                                                            true "Post's UID or slug"
-// @Param       form body object{slug=string,title=string,description=string,featuringImagePath=string,categories=[]string,tags=[]string,content=string,publishNow=boolean} true "Update post form"
+// @Param       uid  path string                                                                                                                                                           true "Post's UID or slug"
+// @Param       form body object{slug=string,title=string,description=string,featuringImagePath=string,categories=[]string,tags=[]string,content=string,publishNow=boolean,publishAt=time} true "Update post form"
 // @Success     204
 // @Failure     401 {object} object{message=string}
 // @Failure     404 {object} object{message=string}
@@ -282,6 +294,7 @@ func UpdatePost(
 	return func(c *gin.Context) {
 		var (
 			ctx, cancel        = context.WithTimeout(context.Background(), maxCtxDuration)
+			me                 *models.UserModel
 			post               *models.PostModel
 			updatedPost        *models.PostModel
 			postContent        *models.PostContentModel
@@ -293,6 +306,10 @@ func UpdatePost(
 		)
 
 		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
 		if postUid, err = primitive.ObjectIDFromHex(postUidParam); err != nil {
 			responses.IncorrectPostId(c, err)
 			return
@@ -321,10 +338,16 @@ func UpdatePost(
 			responses.InternalServerError(c, err)
 			return
 		}
-		if err = svc.Post.UpdateOneWithContent(ctx, updatedPost, updatedPostContent); err != nil {
+		if err = svc.Post.UpdateOneWithContent(ctx, updatedPost, updatedPostContent, me); err != nil {
 			responses.InternalServerError(c, err)
 			return
 		}
+		if form.PublishAt != nil {
+			if err = svc.Post.ScheduleOne(ctx, updatedPost, *form.PublishAt, me); err != nil {
+				responses.InternalServerError(c, err)
+				return
+			}
+		}
 
 		responses.NoContent(c)
 	}
@@ -470,6 +493,9 @@ func DeletePost(
 		)
 
 		defer cancel()
+		defer func() {
+			audit.Record(ctx, c, svc, models.AuditActionDeletePost, audit.PostTarget(postUidParam, post))
+		}()
 		if postUid, err = primitive.ObjectIDFromHex(postUidParam); err != nil {
 			responses.IncorrectPostId(c, err)
 			return
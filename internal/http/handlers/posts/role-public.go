@@ -21,10 +21,11 @@ import (
 // @Router      /v1/post/{uid} [get]
 // @Produce     application/json
 // @Produce     application/msgpack
-// @Param       uid path     string true "Post's UID or slug"
-// @Success     200 {object} object{data=object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,content=string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,publishedAt=time}}
-// @Failure     404 {object} object{message=string}
-// @Failure     500 {object} object{message=string}
+// @Param       uid    path     string true  "Post's UID or slug"
+// @Param       format query    string false "Content format: markdown, html or both, e.g.: ?format=html."
+// @Success     200    {object} object{data=object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,content=string,html=string,tableOfContents=[]object{level=int,text=string,anchor=string},author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,readingTime=int,publishedAt=time,series=object{uid=string,slug=string,title=string,description=string,position=int,total=int,previous=object{uid=string,slug=string,title=string},next=object{uid=string,slug=string,title=string}}}}
+// @Failure     404    {object} object{message=string}
+// @Failure     500    {object} object{message=string}
 func GetPublicPost(
 	maxCtxDuration time.Duration,
 	svc *service.Service,
@@ -35,6 +36,8 @@ func GetPublicPost(
 			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
 			post        *models.PostModel
 			postContent *models.PostContentModel
+			series      *models.SeriesModel
+			seriesParts []*models.PostModel
 			postUid     interface{}
 			postParam   = c.Param("post")
 			err         error
@@ -59,8 +62,76 @@ func GetPublicPost(
 			responses.NotFound(c, errors.New("post not found"))
 			return
 		}
+		if series, err = svc.Series.GetOneByPost(ctx, post.UID); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if series != nil {
+			if seriesParts, err = svc.Series.GetPublishedParts(ctx, series); err != nil {
+				responses.InternalServerError(c, err)
+				return
+			}
+		}
+
+		responses.PublicPost(c, post, postContent, series, seriesParts)
+	}
+}
+
+// @Tags        Post (Public)
+// @Summary     Get Public Related Posts
+// @Description Get the posts related to a post that available publicly, most relevant first.
+// @Router      /v1/post/{uid}/related [get]
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path     string true "Post's UID or slug"
+// @Success     200 {object} object{data=[]object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,readingTime=int,publishedAt=time}}
+// @Success     204
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func GetPublicRelatedPosts(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			post        *models.PostModel
+			posts       []*models.PostModel
+			postUid     interface{}
+			postParam   = c.Param("post")
+			err         error
+		)
+
+		defer cancel()
+		if postUid, err = primitive.ObjectIDFromHex(postParam); err != nil {
+			postUid = nil
+		}
+		if post, err = svc.Post.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"publishedat": bson.M{"$ne": primitive.Null{}}},
+				{"$or": []bson.M{
+					{"_id": bson.M{"$eq": postUid}},
+					{"slug": bson.M{"$eq": postParam}}}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if post == nil {
+			responses.NotFound(c, errors.New("post not found"))
+			return
+		}
+		if posts, err = svc.Post.GetManyRelated(ctx, post); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if len(posts) == 0 {
+			responses.NoContent(c)
+			return
+		}
 
-		responses.PublicPost(c, post, postContent)
+		responses.PublicPosts(c, posts)
 	}
 }
 
@@ -74,7 +145,7 @@ func GetPublicPost(
 // @Param       page  query    int    false "Selected page of data."
 // @Param       order query    string false "Selected field to order data with."
 // @Param       asc   query    string false "Ascending or descending, e.g.: ?asc=false."
-// @Success     200   {object} object{data=[]object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,content=string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,publishedAt=time}}
+// @Success     200   {object} object{data=[]object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,content=string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,readingTime=int,publishedAt=time}}
 // @Success     204
 // @Failure     404   {object} object{message=string}
 // @Failure     500   {object} object{message=string}
@@ -120,7 +191,7 @@ func GetPublicPosts(
 // @Param       page  query    int    false "Selected page of data."
 // @Param       order query    string false "Selected field to order data with."
 // @Param       asc   query    string false "Ascending or descending, e.g.: ?asc=false."
-// @Success     200   {object} object{data=[]object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,content=string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,publishedAt=time}}
+// @Success     200   {object} object{data=[]object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,content=string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,readingTime=int,publishedAt=time}}
 // @Success     204
 // @Failure     404   {object} object{message=string}
 // @Failure     500   {object} object{message=string}
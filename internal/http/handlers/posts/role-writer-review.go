@@ -0,0 +1,98 @@
+package posts
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
+	"github.com/misterabdul/goblog-server/internal/http/requests"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// @Tags        Post (Writer)
+// @Summary     Submit My Post
+// @Description Submit my draft post, or the one with requested changes, for the editor's review.
+// @Router      /v1/auth/writer/post/{uid}/submit [put]
+// @Router      /v1/auth/writer/post/{uid}/submit [patch]
+// @Security    BearerAuth
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid  path     string             true  "Post's UID"
+// @Param       form body     object{note=string} false "Submit post form"
+// @Success     204
+// @Failure     400  {object} object{message=string}
+// @Failure     401  {object} object{message=string}
+// @Failure     404  {object} object{message=string}
+// @Failure     409  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func SubmitMyPost(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			me           *models.UserModel
+			post         *models.PostModel
+			postUid      primitive.ObjectID
+			postUidParam = c.Param("post")
+			form         *forms.PostReviewForm
+			err          error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if postUid, err = primitive.ObjectIDFromHex(postUidParam); err != nil {
+			responses.IncorrectPostId(c, err)
+			return
+		}
+		if form, err = requests.GetPostReviewForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if post, err = svc.Post.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				service.PostAuthoredByFilter(me.UID),
+				{"_id": bson.M{"$eq": postUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if post == nil {
+			responses.NotFound(c, errors.New("post not found"))
+			return
+		}
+		if post.GetStatus() == models.PostStatusSubmitted {
+			responses.NoContent(c)
+			return
+		}
+		if !post.CanTransitTo(models.PostStatusSubmitted) {
+			responses.IncorrectPostTransition(c, post, models.PostStatusSubmitted)
+			return
+		}
+		if err = svc.Post.TransitOne(
+			ctx, post, models.PostStatusSubmitted, me, form.Note,
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
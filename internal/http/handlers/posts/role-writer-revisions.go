@@ -0,0 +1,344 @@
+package posts
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	internalGin "github.com/misterabdul/goblog-server/internal/pkg/gin"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// @Tags        Post (Writer)
+// @Summary     Get My Post Revisions
+// @Description Get the revisions of my post, newest first.
+// @Router      /v1/auth/writer/post/{uid}/revisions [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid  path     string true  "Post's UID"
+// @Param       show query    int    false "Number of data to be shown."
+// @Param       page query    int    false "Selected page of data."
+// @Success     200  {object} object{data=[]object{uid=string,number=int,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,changedFields=[]string,editor=object{uid=string,username=string,email=string,firstName=string,lastName=string},restoredFrom=int,createdAt=time}}
+// @Failure     204
+// @Failure     400  {object} object{message=string}
+// @Failure     401  {object} object{message=string}
+// @Failure     404  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func GetMyPostRevisions(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			me           *models.UserModel
+			post         *models.PostModel
+			revisions    []*models.PostRevisionModel
+			postUid      primitive.ObjectID
+			postUidParam = c.Param("post")
+			err          error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if postUid, err = primitive.ObjectIDFromHex(postUidParam); err != nil {
+			responses.IncorrectPostId(c, err)
+			return
+		}
+		if post, err = svc.Post.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				service.PostAuthoredByFilter(me.UID),
+				{"_id": bson.M{"$eq": postUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if post == nil {
+			responses.NotFound(c, errors.New("post not found"))
+			return
+		}
+		if revisions, err = svc.Post.GetManyRevisions(ctx, post,
+			internalGin.CreateFindOptions(
+				int(*internalGin.GetShowQuery(c)),
+				int(*internalGin.GetPageQuery(c)),
+				"number", false),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if len(revisions) == 0 {
+			responses.NoContent(c)
+			return
+		}
+
+		responses.PostRevisions(c, revisions)
+	}
+}
+
+// @Tags        Post (Writer)
+// @Summary     Get My Post Revision
+// @Description Get a revision of my post with its content.
+// @Router      /v1/auth/writer/post/{uid}/revision/{revision} [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid      path     string true "Post's UID"
+// @Param       revision path     string true "Revision's UID"
+// @Success     200      {object} object{data=object{uid=string,number=int,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,content=string,changedFields=[]string,editor=object{uid=string,username=string,email=string,firstName=string,lastName=string},restoredFrom=int,createdAt=time}}
+// @Failure     400      {object} object{message=string}
+// @Failure     401      {object} object{message=string}
+// @Failure     404      {object} object{message=string}
+// @Failure     500      {object} object{message=string}
+func GetMyPostRevision(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel      = context.WithTimeout(context.Background(), maxCtxDuration)
+			me               *models.UserModel
+			post             *models.PostModel
+			revision         *models.PostRevisionModel
+			postUid          primitive.ObjectID
+			postUidParam     = c.Param("post")
+			revisionUid      primitive.ObjectID
+			revisionUidParam = c.Param("revision")
+			err              error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if postUid, err = primitive.ObjectIDFromHex(postUidParam); err != nil {
+			responses.IncorrectPostId(c, err)
+			return
+		}
+		if revisionUid, err = primitive.ObjectIDFromHex(revisionUidParam); err != nil {
+			responses.IncorrectRevisionId(c, err)
+			return
+		}
+		if post, err = svc.Post.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				service.PostAuthoredByFilter(me.UID),
+				{"_id": bson.M{"$eq": postUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if post == nil {
+			responses.NotFound(c, errors.New("post not found"))
+			return
+		}
+		if revision, err = svc.Post.GetOneRevision(ctx, post, revisionUid); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if revision == nil {
+			responses.NotFound(c, errors.New("revision not found"))
+			return
+		}
+
+		responses.PostRevision(c, revision)
+	}
+}
+
+// @Tags        Post (Writer)
+// @Summary     Diff My Post Revisions
+// @Description Get the unified diff between two revisions of my post.
+// @Router      /v1/auth/writer/post/{uid}/revisions/diff [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid  path     string true "Post's UID"
+// @Param       from query    string true "Older revision's UID"
+// @Param       to   query    string true "Newer revision's UID"
+// @Success     200  {object} object{data=object{from=int,to=int,diff=string}}
+// @Failure     400  {object} object{message=string}
+// @Failure     401  {object} object{message=string}
+// @Failure     404  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func DiffMyPostRevisions(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			me           *models.UserModel
+			post         *models.PostModel
+			from         *models.PostRevisionModel
+			to           *models.PostRevisionModel
+			postUid      primitive.ObjectID
+			postUidParam = c.Param("post")
+			fromUid      primitive.ObjectID
+			toUid        primitive.ObjectID
+			err          error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if postUid, err = primitive.ObjectIDFromHex(postUidParam); err != nil {
+			responses.IncorrectPostId(c, err)
+			return
+		}
+		if fromUid, err = primitive.ObjectIDFromHex(c.Query("from")); err != nil {
+			responses.IncorrectRevisionId(c, err)
+			return
+		}
+		if toUid, err = primitive.ObjectIDFromHex(c.Query("to")); err != nil {
+			responses.IncorrectRevisionId(c, err)
+			return
+		}
+		if post, err = svc.Post.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				service.PostAuthoredByFilter(me.UID),
+				{"_id": bson.M{"$eq": postUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if post == nil {
+			responses.NotFound(c, errors.New("post not found"))
+			return
+		}
+		if from, err = svc.Post.GetOneRevision(ctx, post, fromUid); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if to, err = svc.Post.GetOneRevision(ctx, post, toUid); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if from == nil || to == nil {
+			responses.NotFound(c, errors.New("revision not found"))
+			return
+		}
+
+		responses.RevisionDiff(c, from.Number, to.Number, svc.Post.DiffRevisions(from, to))
+	}
+}
+
+// @Tags        Post (Writer)
+// @Summary     Restore My Post Revision
+// @Description Bring my post back to the state of a revision, saved as a new revision.
+// @Router      /v1/auth/writer/post/{uid}/revision/{revision}/restore [put]
+// @Router      /v1/auth/writer/post/{uid}/revision/{revision}/restore [patch]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid      path string true "Post's UID"
+// @Param       revision path string true "Revision's UID"
+// @Success     204
+// @Failure     400 {object} object{message=string}
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     409 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func RestoreMyPostRevision(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel      = context.WithTimeout(context.Background(), maxCtxDuration)
+			me               *models.UserModel
+			post             *models.PostModel
+			postContent      *models.PostContentModel
+			revision         *models.PostRevisionModel
+			postUid          primitive.ObjectID
+			postUidParam     = c.Param("post")
+			revisionUid      primitive.ObjectID
+			revisionUidParam = c.Param("revision")
+			slugTaken        bool
+			err              error
+		)
+
+		defer cancel()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if postUid, err = primitive.ObjectIDFromHex(postUidParam); err != nil {
+			responses.IncorrectPostId(c, err)
+			return
+		}
+		if revisionUid, err = primitive.ObjectIDFromHex(revisionUidParam); err != nil {
+			responses.IncorrectRevisionId(c, err)
+			return
+		}
+		if post, postContent, err = svc.Post.GetOneWithContent(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				service.PostAuthoredByFilter(me.UID),
+				{"_id": bson.M{"$eq": postUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if post == nil || postContent == nil {
+			responses.NotFound(c, errors.New("post not found"))
+			return
+		}
+		if revision, err = svc.Post.GetOneRevision(ctx, post, revisionUid); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if revision == nil {
+			responses.NotFound(c, errors.New("revision not found"))
+			return
+		}
+		if slugTaken, err = isRevisionSlugTaken(svc, ctx, post, revision); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if slugTaken {
+			responses.RevisionSlugTaken(c, errors.New("slug exists"))
+			return
+		}
+		if err = svc.Post.RestoreRevisionOne(ctx, post, postContent, revision, me); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
+
+func isRevisionSlugTaken(
+	svc *service.Service,
+	ctx context.Context,
+	post *models.PostModel,
+	revision *models.PostRevisionModel,
+) (taken bool, err error) {
+	var count int64
+
+	if count, err = svc.Post.Count(ctx, bson.M{
+		"$and": []bson.M{
+			{"_id": bson.M{"$ne": post.UID}},
+			{"slug": bson.M{"$eq": revision.Slug}}}},
+	); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -10,6 +10,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
 	"github.com/misterabdul/goblog-server/internal/http/forms"
 	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
 	"github.com/misterabdul/goblog-server/internal/http/requests"
@@ -20,13 +21,13 @@ import (
 
 // @Tags        Post (Writer)
 // @Summary     Get My Post
-// @Description Get my post.
+// @Description Get my post, or the one I co-author.
 // @Router      /v1/auth/writer/post/{uid} [get]
 // @Security    BearerAuth
 // @Produce     application/json
 // @Produce     application/msgpack
 // @Param       uid path     string true "Post's UID or slug"
-// @Success     200 {object} object{data=object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,publishedAt=time,updatedAt=time,createdAt=time,deletedAt=time}}
+// @Success     200 {object} object{data=object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,analytics=object{wordCount=int,readingTime=int,outline=[]object{level=int,text=string,anchor=string},imageCount=int,internalLinks=[]string,externalLinks=[]string,seoChecks=[]object{name=string,passed=bool,message=string}},publishedAt=time,updatedAt=time,createdAt=time,deletedAt=time}}
 // @Failure     401 {object} object{message=string}
 // @Failure     404 {object} object{message=string}
 // @Failure     500 {object} object{message=string}
@@ -57,7 +58,7 @@ func GetMyPost(
 		}
 		if post, postContent, err = svc.Post.GetOneWithContent(ctx, bson.M{
 			"$and": []bson.M{
-				{"author._id": bson.M{"$eq": me.UID}},
+				service.PostAuthoredByFilter(me.UID),
 				{"_id": bson.M{"$eq": postUid}}}},
 		); err != nil {
 			responses.InternalServerError(c, err)
@@ -74,7 +75,7 @@ func GetMyPost(
 
 // @Tags        Post (Writer)
 // @Summary     Get My Posts
-// @Description Get my posts.
+// @Description Get my posts, including the ones I co-author.
 // @Router      /v1/auth/writer/posts [get]
 // @Security    BearerAuth
 // @Produce     application/json
@@ -83,8 +84,8 @@ func GetMyPost(
 // @Param       page  query    int    false "Selected page of data."
 // @Param       order query    string false "Selected field to order data with."
 // @Param       asc   query    string false "Ascending or descending, e.g.: ?asc=false."
-// @Param       type  query    string false "Filter data by type, e.g.: ?type=trash, ?type=published, ?type=draft."
-// @Success     200   {object} object{data=[]object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,publishedAt=time,updatedAt=time,createdAt=time,deletedAt=time}}
+// @Param       type  query    string false "Filter data by type, e.g.: ?type=trash, ?type=published, ?type=scheduled, ?type=draft, ?type=submitted, ?type=changes-requested, ?type=approved."
+// @Success     200   {object} object{data=[]object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,readingTime=int,publishedAt=time,updatedAt=time,createdAt=time,deletedAt=time}}
 // @Failure     204
 // @Failure     401   {object} object{message=string}
 // @Failure     500   {object} object{message=string}
@@ -109,7 +110,7 @@ func GetMyPosts(
 		}
 		if posts, err = svc.Post.GetMany(ctx, bson.M{
 			"$and": append(queryParams,
-				bson.M{"author._id": bson.M{"$eq": me.UID}})},
+				service.PostAuthoredByFilter(me.UID))},
 			internalGin.GetFindOptionsPost(c),
 		); err != nil {
 			responses.InternalServerError(c, err)
@@ -126,7 +127,7 @@ func GetMyPosts(
 
 // @Tags        Post (Writer)
 // @Summary     Get My Posts Stats
-// @Description Get my posts's stats.
+// @Description Get my posts's stats, including the ones I co-author.
 // @Router      /v1/auth/writer/posts/stats [get]
 // @Security    BearerAuth
 // @Produce     application/json
@@ -135,7 +136,7 @@ func GetMyPosts(
 // @Param       page  query    int    false "Selected page of data."
 // @Param       order query    string false "Selected field to order data with."
 // @Param       asc   query    string false "Ascending or descending, e.g.: ?asc=false."
-// @Param       type  query    string false "Filter data by type, e.g.: ?type=trash, ?type=published, ?type=draft."
+// @Param       type  query    string false "Filter data by type, e.g.: ?type=trash, ?type=published, ?type=scheduled, ?type=draft, ?type=submitted, ?type=changes-requested, ?type=approved."
 // @Success     200   {object} object{data=object{currentPage=int,totalPages=int,itemsPerPage=int,totalItems=int}}
 // @Failure     401   {object} object{message=string}
 // @Failure     500   {object} object{message=string}
@@ -160,7 +161,7 @@ func GetMyPostsStats(
 		}
 		if count, err = svc.Post.Count(ctx, bson.M{
 			"$and": append(queryParams,
-				bson.M{"author._id": bson.M{"$eq": me.UID}})},
+				service.PostAuthoredByFilter(me.UID))},
 			internalGin.GetCountOptions(c),
 		); err != nil {
 			responses.InternalServerError(c, err)
@@ -180,9 +181,10 @@ func GetMyPostsStats(
 // @Accept      application/msgpack
 // @Produce     application/json
 // @Produce     application/msgpack
-// @Param       form body     object{slug=string,title=string,description=string,featuringImagePath=string,categories=[]string,tags=[]string,content=string,publishNow=boolean} true "Create post form"
-// @Success     200  {object} object{data=object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,publishedAt=time,updatedAt=time,createdAt=time,deletedAt=time}}
+// @Param       form body     object{slug=string,title=string,description=string,featuringImagePath=string,categories=[]string,tags=[]string,content=string,publishNow=boolean,publishAt=time} true "Create post form"
+// @Success     200  {object} object{data=object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,analytics=object{wordCount=int,readingTime=int,outline=[]object{level=int,text=string,anchor=string},imageCount=int,internalLinks=[]string,externalLinks=[]string,seoChecks=[]object{name=string,passed=bool,message=string}},publishedAt=time,updatedAt=time,createdAt=time,deletedAt=time}}
 // @Failure     401  {object} object{message=string}
+// @Failure     403  {object} object{message=string}
 // @Failure     422  {object} object{message=string}
 // @Failure     500  {object} object{message=string}
 func CreatePost(
@@ -197,6 +199,7 @@ func CreatePost(
 			post        *models.PostModel
 			postContent *models.PostContentModel
 			form        *forms.CreatePostForm
+			canPublish  bool
 			err         error
 		)
 
@@ -217,10 +220,26 @@ func CreatePost(
 			responses.InternalServerError(c, err)
 			return
 		}
+		if form.PublishNow || form.PublishAt != nil {
+			if canPublish, err = canPublishPost(ctx, svc, me, post); err != nil {
+				responses.InternalServerError(c, err)
+				return
+			}
+			if !canPublish {
+				responses.PostNotApproved(c)
+				return
+			}
+		}
 		if err = svc.Post.SaveOneWithContent(ctx, post, postContent); err != nil {
 			responses.InternalServerError(c, err)
 			return
 		}
+		if form.PublishAt != nil {
+			if err = svc.Post.ScheduleOne(ctx, post, *form.PublishAt, me); err != nil {
+				responses.InternalServerError(c, err)
+				return
+			}
+		}
 
 		responses.MyPost(c, post, postContent)
 	}
@@ -236,6 +255,7 @@ func CreatePost(
 // @Produce     application/msgpack
 // @Success     204
 // @Failure     401 {object} object{message=string}
+// @Failure     403 {object} object{message=string}
 // @Failure     500 {object} object{message=string}
 func PublishMyPost(
 	maxCtxDuration time.Duration,
@@ -278,7 +298,11 @@ func PublishMyPost(
 			responses.NoContent(c)
 			return
 		}
-		if err = svc.Post.PublishOne(ctx, post); err != nil {
+		if !svc.Post.IsPublishableByAuthor(post) {
+			responses.PostNotApproved(c)
+			return
+		}
+		if err = svc.Post.PublishOne(ctx, post, me); err != nil {
 			responses.InternalServerError(c, err)
 			return
 		}
@@ -339,7 +363,7 @@ func DepublishMyPost(
 			responses.NoContent(c)
 			return
 		}
-		if err = svc.Post.DepublishOne(ctx, post); err != nil {
+		if err = svc.Post.DepublishOne(ctx, post, me); err != nil {
 			responses.InternalServerError(c, err)
 			return
 		}
@@ -350,7 +374,7 @@ func DepublishMyPost(
 
 // @Tags        Post (Writer)
 // @Summary     Update My Post
-// @Description Update my post.
+// @Description Update my post, or the one I co-author. Updating the approved post moves it back to submitted, so it can't be published or scheduled by the same update.
 // @Router      /v1/auth/writer/post/{uid} [put]
 // @Router      /v1/auth/writer/post/{uid} [patch]
 // @Security    BearerAuth
@@ -358,10 +382,11 @@ func DepublishMyPost(
 // @Accept      application/msgpack
 // @Produce     application/json
 // @Produce     application/msgpack
-// @Param       uid  path     string                                                                                                                                            true "Post's UID or slug"
-// @Param       form body     object{slug=string,title=string,description=string,featuringImagePath=string,categories=[]string,tags=[]string,content=string,publishNow=boolean} true "Update post form"
+// @Param       uid  path     string                                                                                                                                                           true "Post's UID or slug"
+// @Param       form body     object{slug=string,title=string,description=string,featuringImagePath=string,categories=[]string,tags=[]string,content=string,publishNow=boolean,publishAt=time} true "Update post form"
 // @Success     204
 // @Failure     401  {object} object{message=string}
+// @Failure     403  {object} object{message=string}
 // @Failure     404  {object} object{message=string}
 // @Failure     422  {object} object{message=string}
 // @Failure     500  {object} object{message=string}
@@ -396,7 +421,7 @@ func UpdateMyPost(
 		if post, postContent, err = svc.Post.GetOneWithContent(ctx, bson.M{
 			"$and": []bson.M{
 				{"deletedat": bson.M{"$eq": primitive.Null{}}},
-				{"author._id": bson.M{"$eq": me.UID}},
+				service.PostAuthoredByFilter(me.UID),
 				{"_id": bson.M{"$eq": postUid}}}},
 		); err != nil {
 			responses.InternalServerError(c, err)
@@ -414,14 +439,31 @@ func UpdateMyPost(
 			responses.FormIncorrect(c, err)
 			return
 		}
+		if (form.PublishNow || form.PublishAt != nil) &&
+			(!svc.Post.IsPublishableByAuthor(post) || svc.Post.IsApprovalResetBy(post, me)) {
+			// The update moves the approved post back to submitted,
+			// it's published once the editor approves it again.
+			responses.PostNotApproved(c)
+			return
+		}
 		if updatedPost, updatedPostContent, err = form.ToPostModel(post, postContent); err != nil {
 			responses.FormIncorrect(c, err)
 			return
 		}
-		if err = svc.Post.UpdateOneWithContent(ctx, updatedPost, updatedPostContent); err != nil {
+		if err = svc.Post.UpdateOneWithContent(ctx, updatedPost, updatedPostContent, me); err != nil {
 			responses.InternalServerError(c, err)
 			return
 		}
+		if form.PublishAt != nil {
+			if err = svc.Post.ScheduleOne(ctx, updatedPost, *form.PublishAt, nil); err != nil {
+				if errors.Is(err, service.ErrPostNotApproved) {
+					responses.PostNotApproved(c)
+					return
+				}
+				responses.InternalServerError(c, err)
+				return
+			}
+		}
 
 		responses.NoContent(c)
 	}
@@ -573,6 +615,9 @@ func DeleteMyPost(
 		)
 
 		defer cancel()
+		defer func() {
+			audit.Record(ctx, c, svc, models.AuditActionDeletePost, audit.PostTarget(postUidParam, post))
+		}()
 		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
 			responses.Unauthenticated(c, err)
 			return
@@ -602,6 +647,21 @@ func DeleteMyPost(
 	}
 }
 
+// Check whether the user may publish the post, either as its author
+// allowed by the publish policy or by publishing any post
+func canPublishPost(
+	ctx context.Context,
+	svc *service.Service,
+	me *models.UserModel,
+	post *models.PostModel,
+) (canPublish bool, err error) {
+	if post.Author.UID == me.UID && svc.Post.IsPublishableByAuthor(post) {
+		return true, nil
+	}
+
+	return svc.Role.HasPermissions(ctx, me, models.PermissionPostPublishAny)
+}
+
 func readCommonQueryParams(c *gin.Context) []bson.M {
 	var (
 		typeQuery  = c.DefaultQuery("type", "draft")
@@ -616,10 +676,24 @@ func readCommonQueryParams(c *gin.Context) []bson.M {
 		extraQuery = append(extraQuery,
 			bson.M{"publishedat": bson.M{"$ne": primitive.Null{}}},
 			bson.M{"deletedat": bson.M{"$eq": primitive.Null{}}})
+	case typeQuery == "scheduled":
+		extraQuery = append(extraQuery,
+			bson.M{"publishat": bson.M{"$ne": primitive.Null{}}},
+			bson.M{"publishedat": bson.M{"$eq": primitive.Null{}}},
+			bson.M{"deletedat": bson.M{"$eq": primitive.Null{}}})
+	case typeQuery == models.PostStatusSubmitted,
+		typeQuery == models.PostStatusChangesRequested,
+		typeQuery == models.PostStatusApproved:
+		extraQuery = append(extraQuery,
+			bson.M{"status": bson.M{"$eq": typeQuery}},
+			bson.M{"publishedat": bson.M{"$eq": primitive.Null{}}},
+			bson.M{"deletedat": bson.M{"$eq": primitive.Null{}}})
 	case typeQuery == "draft":
 		fallthrough
 	default:
 		extraQuery = append(extraQuery,
+			bson.M{"status": bson.M{"$in": bson.A{models.PostStatusDraft, nil}}},
+			bson.M{"publishat": bson.M{"$eq": primitive.Null{}}},
 			bson.M{"publishedat": bson.M{"$eq": primitive.Null{}}},
 			bson.M{"deletedat": bson.M{"$eq": primitive.Null{}}})
 	}
@@ -0,0 +1,284 @@
+package roles
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+	"github.com/misterabdul/goblog-server/internal/http/requests"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// @Tags        Role (SuperAdmin)
+// @Summary     Get Permissions
+// @Description Get every permission available to the roles, the SuperAdmin's own permissions can't be granted to other roles.
+// @Router      /v1/auth/superadmin/permissions [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Success     200 {object} object{data=[]string}
+// @Failure     401 {object} object{message=string}
+func GetPermissions() (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		responses.Permissions(c, models.GetGrantablePermissions())
+	}
+}
+
+// @Tags        Role (SuperAdmin)
+// @Summary     Get Roles
+// @Description Get the built-in & custom roles, ordered by level.
+// @Router      /v1/auth/superadmin/roles [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Success     200 {object} object{data=[]object{uid=string,level=int,name=string,description=string,permissions=[]string,system=bool,createdAt=time,updatedAt=time}}
+// @Failure     401 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func GetRoles(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			roles       []*models.RoleModel
+			err         error
+		)
+
+		defer cancel()
+		if roles, err = svc.Role.GetMany(ctx, bson.M{},
+			options.Find().SetSort(bson.M{"level": 1}),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.Roles(c, roles)
+	}
+}
+
+// @Tags        Role (SuperAdmin)
+// @Summary     Get Role
+// @Description Get a role.
+// @Router      /v1/auth/superadmin/role/{uid} [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path     string true "Role's UID"
+// @Success     200 {object} object{data=object{uid=string,level=int,name=string,description=string,permissions=[]string,system=bool,createdAt=time,updatedAt=time}}
+// @Failure     400 {object} object{message=string}
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func GetRole(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			role         *models.RoleModel
+			roleUid      primitive.ObjectID
+			roleUidParam = c.Param("role")
+			err          error
+		)
+
+		defer cancel()
+		if roleUid, err = primitive.ObjectIDFromHex(roleUidParam); err != nil {
+			responses.IncorrectRoleId(c, err)
+			return
+		}
+		if role, err = svc.Role.GetOne(ctx, bson.M{
+			"_id": bson.M{"$eq": roleUid}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if role == nil {
+			responses.NotFound(c, errors.New("role not found"))
+			return
+		}
+
+		responses.Role(c, role)
+	}
+}
+
+// @Tags        Role (SuperAdmin)
+// @Summary     Create Role
+// @Description Create a new custom role, taking the next available level.
+// @Router      /v1/auth/superadmin/role [post]
+// @Security    BearerAuth
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       form body     object{name=string,description=string,permissions=[]string} true "Create role form"
+// @Success     200  {object} object{data=object{uid=string,level=int,name=string,description=string,permissions=[]string,system=bool,createdAt=time,updatedAt=time}}
+// @Failure     401  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func CreateRole(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			role        *models.RoleModel
+			form        *forms.CreateRoleForm
+			err         error
+		)
+
+		defer cancel()
+		if form, err = requests.GetCreateRoleForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if err = form.Validate(svc, ctx); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		role = form.ToRoleModel()
+		if err = svc.Role.SaveOne(ctx, role); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.Role(c, role)
+	}
+}
+
+// @Tags        Role (SuperAdmin)
+// @Summary     Update Role
+// @Description Update a role, the built-in roles can't be renamed & the SuperAdmin role can't be updated at all.
+// @Router      /v1/auth/superadmin/role/{uid} [put]
+// @Router      /v1/auth/superadmin/role/{uid} [patch]
+// @Security    BearerAuth
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid  path     string                                                 true "Role's UID"
+// @Param       form body     object{name=string,description=string,permissions=[]string} true "Update role form"
+// @Success     204
+// @Failure     400  {object} object{message=string}
+// @Failure     401  {object} object{message=string}
+// @Failure     404  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func UpdateRole(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			role         *models.RoleModel
+			roleUid      primitive.ObjectID
+			roleUidParam = c.Param("role")
+			updatedRole  *models.RoleModel
+			form         *forms.UpdateRoleForm
+			err          error
+		)
+
+		defer cancel()
+		if roleUid, err = primitive.ObjectIDFromHex(roleUidParam); err != nil {
+			responses.IncorrectRoleId(c, err)
+			return
+		}
+		if role, err = svc.Role.GetOne(ctx, bson.M{
+			"_id": bson.M{"$eq": roleUid}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if role == nil {
+			responses.NotFound(c, errors.New("role not found"))
+			return
+		}
+		if form, err = requests.GetUpdateRoleForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if err = form.Validate(svc, ctx, role); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		updatedRole = form.ToRoleModel(role)
+		if err = svc.Role.UpdateOne(ctx, updatedRole); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
+
+// @Tags        Role (SuperAdmin)
+// @Summary     Delete Role
+// @Description Permanently delete a custom role, revoking it from its users.
+// @Router      /v1/auth/superadmin/role/{uid} [delete]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path string true "Role's UID"
+// @Success     204
+// @Failure     400 {object} object{message=string}
+// @Failure     401 {object} object{message=string}
+// @Failure     403 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func DeleteRole(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			role         *models.RoleModel
+			roleUid      primitive.ObjectID
+			roleUidParam = c.Param("role")
+			err          error
+		)
+
+		defer cancel()
+		if roleUid, err = primitive.ObjectIDFromHex(roleUidParam); err != nil {
+			responses.IncorrectRoleId(c, err)
+			return
+		}
+		if role, err = svc.Role.GetOne(ctx, bson.M{
+			"_id": bson.M{"$eq": roleUid}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if role == nil {
+			responses.NotFound(c, errors.New("role not found"))
+			return
+		}
+		if role.System {
+			responses.SystemRole(c, errors.New("built-in role"))
+			return
+		}
+		if err = svc.Role.DeleteOne(ctx, role); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
@@ -0,0 +1,453 @@
+package series
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+	"github.com/misterabdul/goblog-server/internal/http/requests"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	internalGin "github.com/misterabdul/goblog-server/internal/pkg/gin"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// @Tags        Series (Editor)
+// @Summary     Get Series
+// @Description Get series.
+// @Router      /v1/auth/editor/series/{uid} [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path     string true "Series's UID"
+// @Success     200 {object} object{data=object{uid=string,slug=string,title=string,description=string,posts=[]string,updatedAt=time,createdAt=time}}
+// @Failure     400 {object} object{message=string}
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func GetSeries(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel    = context.WithTimeout(context.Background(), maxCtxDuration)
+			series         *models.SeriesModel
+			seriesUid      primitive.ObjectID
+			seriesUidParam = c.Param("series")
+			err            error
+		)
+
+		defer cancel()
+		if seriesUid, err = primitive.ObjectIDFromHex(seriesUidParam); err != nil {
+			responses.IncorrectSeriesId(c, err)
+			return
+		}
+		if series, err = svc.Series.GetOne(ctx, bson.M{
+			"_id": bson.M{"$eq": seriesUid}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if series == nil {
+			responses.NotFound(c, errors.New("series not found"))
+			return
+		}
+
+		responses.AuthorizedSeries(c, series)
+	}
+}
+
+// @Tags        Series (Editor)
+// @Summary     Get Series List
+// @Description Get list of series.
+// @Router      /v1/auth/editor/series [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       show  query    int     false "Number of data to be shown."
+// @Param       page  query    int     false "Selected page of data."
+// @Param       order query    string  false "Selected field to order data with."
+// @Param       asc   query    boolean false "Ascending or descending."
+// @Param       type  query    string  false "Filter data by type, e.g.: ?type=trash."
+// @Success     200   {object} object{data=[]object{uid=string,slug=string,title=string,description=string,posts=[]string,updatedAt=time,createdAt=time}}
+// @Success     204
+// @Failure     401   {object} object{message=string}
+// @Failure     500   {object} object{message=string}
+func GetSeriesList(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			manySeries  []*models.SeriesModel
+			queryParams = readCommonQueryParams(c)
+			err         error
+		)
+
+		defer cancel()
+		if manySeries, err = svc.Series.GetMany(ctx,
+			bson.M{"$and": queryParams},
+			internalGin.GetFindOptions(c),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if len(manySeries) == 0 {
+			responses.NoContent(c)
+			return
+		}
+
+		responses.AuthorizedManySeries(c, manySeries)
+	}
+}
+
+// @Tags        Series (Editor)
+// @Summary     Get Series List Stats
+// @Description Get series list's stats.
+// @Router      /v1/auth/editor/series/stats [get]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       show  query    int     false "Number of data to be shown."
+// @Param       page  query    int     false "Selected page of data."
+// @Param       order query    string  false "Selected field to order data with."
+// @Param       asc   query    boolean false "Ascending or descending."
+// @Param       type  query    string  false "Filter data by type, e.g.: ?type=trash."
+// @Success     200   {object} object{data=object{currentPage=int,totalPages=int,itemsPerPage=int,totalItems=int}}
+// @Failure     401   {object} object{message=string}
+// @Failure     500   {object} object{message=string}
+func GetSeriesListStats(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			count       int64
+			queryParams = readCommonQueryParams(c)
+			err         error
+		)
+
+		defer cancel()
+		if count, err = svc.Series.Count(ctx, bson.M{
+			"$and": queryParams},
+			internalGin.GetCountOptions(c),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.ResourceStats(c, count)
+	}
+}
+
+// @Tags        Series (Editor)
+// @Summary     Create Series
+// @Description Create a new series.
+// @Router      /v1/auth/editor/series [post]
+// @Security    BearerAuth
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       form body     object{slug=string,title=string,description=string,posts=[]string} true "Create series form"
+// @Success     200  {object} object{data=object{uid=string,slug=string,title=string,description=string,posts=[]string,updatedAt=time,createdAt=time}}
+// @Failure     401  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func CreateSeries(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			series      *models.SeriesModel
+			form        *forms.CreateSeriesForm
+			err         error
+		)
+
+		defer cancel()
+		if form, err = requests.GetCreateSeriesForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if err = form.Validate(svc, ctx); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		series = form.ToSeriesModel()
+		if err = svc.Series.SaveOne(ctx, series); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.AuthorizedSeries(c, series)
+	}
+}
+
+// @Tags        Series (Editor)
+// @Summary     Update Series
+// @Description Update a series.
+// @Router      /v1/auth/editor/series/{uid} [put]
+// @Router      /v1/auth/editor/series/{uid} [patch]
+// @Security    BearerAuth
+// @Accept      application/json
+// @Accept      application/msgpack
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid  path     string                                                             true "Series's UID"
+// @Param       form body     object{slug=string,title=string,description=string,posts=[]string} true "Update series form"
+// @Success     204
+// @Failure     401  {object} object{message=string}
+// @Failure     404  {object} object{message=string}
+// @Failure     422  {object} object{message=string}
+// @Failure     500  {object} object{message=string}
+func UpdateSeries(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel    = context.WithTimeout(context.Background(), maxCtxDuration)
+			series         *models.SeriesModel
+			updatedSeries  *models.SeriesModel
+			seriesUid      primitive.ObjectID
+			seriesUidParam = c.Param("series")
+			form           *forms.UpdateSeriesForm
+			err            error
+		)
+
+		defer cancel()
+		if seriesUid, err = primitive.ObjectIDFromHex(seriesUidParam); err != nil {
+			responses.IncorrectSeriesId(c, err)
+			return
+		}
+		if series, err = svc.Series.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"_id": bson.M{"$eq": seriesUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if series == nil {
+			responses.NotFound(c, errors.New("series not found"))
+			return
+		}
+		if form, err = requests.GetUpdateSeriesForm(c); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		if err = form.Validate(svc, ctx, series); err != nil {
+			responses.FormIncorrect(c, err)
+			return
+		}
+		updatedSeries = form.ToSeriesModel(series)
+		if err = svc.Series.UpdateOne(ctx, updatedSeries); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
+
+// @Tags        Series (Editor)
+// @Summary     Delete Series (Soft)
+// @Description Delete a series (soft-deleted).
+// @Router      /v1/auth/editor/series/{uid} [delete]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path     string true "Series's UID"
+// @Success     204
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func TrashSeries(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel    = context.WithTimeout(context.Background(), maxCtxDuration)
+			series         *models.SeriesModel
+			seriesUid      primitive.ObjectID
+			seriesUidParam = c.Param("series")
+			err            error
+		)
+
+		defer cancel()
+		if seriesUid, err = primitive.ObjectIDFromHex(seriesUidParam); err != nil {
+			responses.IncorrectSeriesId(c, err)
+			return
+		}
+		if series, err = svc.Series.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"_id": bson.M{"$eq": seriesUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if series == nil {
+			responses.NotFound(c, errors.New("series not found"))
+			return
+		}
+		if series.DeletedAt != nil {
+			responses.NoContent(c)
+			return
+		}
+		if err = svc.Series.TrashOne(ctx, series); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
+
+// @Tags        Series (Editor)
+// @Summary     Detrash Series
+// @Description Restore a deleted series (soft-deleted).
+// @Router      /v1/auth/editor/series/{uid}/detrash [put]
+// @Router      /v1/auth/editor/series/{uid}/detrash [patch]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path     string true "Series's UID"
+// @Success     204
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func DetrashSeries(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel    = context.WithTimeout(context.Background(), maxCtxDuration)
+			series         *models.SeriesModel
+			seriesUid      primitive.ObjectID
+			seriesUidParam = c.Param("series")
+			err            error
+		)
+
+		defer cancel()
+		if seriesUid, err = primitive.ObjectIDFromHex(seriesUidParam); err != nil {
+			responses.IncorrectSeriesId(c, err)
+			return
+		}
+		if series, err = svc.Series.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$ne": primitive.Null{}}},
+				{"_id": bson.M{"$eq": seriesUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if series == nil {
+			responses.NotFound(c, errors.New("series not found"))
+			return
+		}
+		if series.DeletedAt == nil {
+			responses.NoContent(c)
+			return
+		}
+		if err = svc.Series.RestoreOne(ctx, series); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
+
+// @Tags        Series (Editor)
+// @Summary     Delete Series (Permanent)
+// @Description Delete a series (permanent).
+// @Router      /v1/auth/editor/series/{uid}/permanent [delete]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path     string true "Series's UID"
+// @Success     204
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func DeleteSeries(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel    = context.WithTimeout(context.Background(), maxCtxDuration)
+			series         *models.SeriesModel
+			seriesUid      primitive.ObjectID
+			seriesUidParam = c.Param("series")
+			err            error
+		)
+
+		defer cancel()
+		defer func() {
+			audit.Record(ctx, c, svc, models.AuditActionDeleteSeries, audit.SeriesTarget(seriesUidParam, series))
+		}()
+		if seriesUid, err = primitive.ObjectIDFromHex(seriesUidParam); err != nil {
+			responses.IncorrectSeriesId(c, err)
+			return
+		}
+		if series, err = svc.Series.GetOne(ctx, bson.M{
+			"_id": bson.M{"$eq": seriesUid}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if series == nil {
+			responses.NotFound(c, errors.New("series not found"))
+			return
+		}
+		if err = svc.Series.DeleteOne(ctx, series); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
+
+func readCommonQueryParams(c *gin.Context) []bson.M {
+	var (
+		typeParam  = c.DefaultQuery("type", "active")
+		extraQuery = []bson.M{}
+	)
+
+	switch true {
+	case typeParam == "trash":
+		extraQuery = append(extraQuery,
+			bson.M{"deletedat": bson.M{"$ne": primitive.Null{}}})
+	case typeParam == "active":
+		fallthrough
+	default:
+		extraQuery = append(extraQuery,
+			bson.M{"deletedat": bson.M{"$eq": primitive.Null{}}})
+	}
+
+	return extraQuery
+}
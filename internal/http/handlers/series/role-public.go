@@ -0,0 +1,110 @@
+package series
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/responses"
+	internalGin "github.com/misterabdul/goblog-server/internal/pkg/gin"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+// @Tags        Series (Public)
+// @Summary     Get Series
+// @Description Get series with its published parts in order.
+// @Router      /v1/series/{uid} [get]
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path     string true "Series's UID or slug"
+// @Success     200 {object} object{data=object{uid=string,slug=string,title=string,description=string,posts=[]object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string},commentCount=int,readingTime=int,publishedAt=time,position=int}}}
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func GetPublicSeries(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel    = context.WithTimeout(context.Background(), maxCtxDuration)
+			series         *models.SeriesModel
+			parts          []*models.PostModel
+			seriesUidParam = c.Param("series")
+			seriesUid      interface{}
+			err            error
+		)
+
+		defer cancel()
+		if seriesUid, err = primitive.ObjectIDFromHex(seriesUidParam); err != nil {
+			seriesUid = nil
+		}
+		if series, err = svc.Series.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": primitive.Null{}},
+				{"$or": []bson.M{
+					{"_id": bson.M{"$eq": seriesUid}},
+					{"slug": bson.M{"$eq": seriesUidParam}}}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if series == nil {
+			responses.NotFound(c, errors.New("series not found"))
+			return
+		}
+		if parts, err = svc.Series.GetPublishedParts(ctx, series); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.PublicSeries(c, series, parts)
+	}
+}
+
+// @Tags        Series (Public)
+// @Summary     Get Series List
+// @Description Get list of series.
+// @Router      /v1/series [get]
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Success     200   {object} object{data=[]object{uid=string,slug=string,title=string,description=string}}
+// @Param       show  query    int     false "Number of data to be shown."
+// @Param       page  query    int     false "Selected page of data."
+// @Param       order query    string  false "Selected field to order data with."
+// @Param       asc   query    boolean false "Ascending or descending."
+// @Failure     204
+// @Failure     500   {object} object{message=string}
+func GetPublicSeriesList(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			manySeries  []*models.SeriesModel
+			err         error
+		)
+
+		defer cancel()
+		if manySeries, err = svc.Series.GetMany(ctx, bson.M{
+			"deletedat": bson.M{"$eq": primitive.Null{}}},
+			internalGin.GetFindOptions(c),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if len(manySeries) == 0 {
+			responses.NoContent(c)
+			return
+		}
+
+		responses.PublicManySeries(c, manySeries)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
 	"github.com/misterabdul/goblog-server/internal/http/forms"
 	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
 	"github.com/misterabdul/goblog-server/internal/http/requests"
@@ -273,6 +274,12 @@ func UpdateUser(
 			responses.InternalServerError(c, err)
 			return
 		}
+		if len(form.Password) > 0 || len(form.Roles) > 0 {
+			if err = svc.User.RevokeTokens(ctx, user); err != nil {
+				responses.InternalServerError(c, err)
+				return
+			}
+		}
 
 		responses.NoContent(c)
 	}
@@ -413,6 +420,9 @@ func DeleteUser(
 		)
 
 		defer cancel()
+		defer func() {
+			audit.Record(ctx, c, svc, models.AuditActionDeleteUser, audit.UserTarget(userUidParam, user))
+		}()
 		if userUid, err = primitive.ObjectIDFromHex(userUidParam); err != nil {
 			responses.IncorrectUserId(c, err)
 			return
@@ -455,3 +465,105 @@ func readCommonQueryParams(c *gin.Context) []bson.M {
 
 	return extraQuery
 }
+
+// @Tags        User (Admin)
+// @Summary     Reset User Two-Factor
+// @Description Disable a user's two-factor authentication, e.g. when the authenticator & recovery codes are lost.
+// @Router      /v1/auth/admin/user/{uid}/2fa [delete]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path string true "User's UID"
+// @Success     204
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func ResetUserTwoFactor(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			user         *models.UserModel
+			userUid      primitive.ObjectID
+			userUidParam = c.Param("user")
+			err          error
+		)
+
+		defer cancel()
+		if userUid, err = primitive.ObjectIDFromHex(userUidParam); err != nil {
+			responses.IncorrectUserId(c, err)
+			return
+		}
+		if user, err = svc.User.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"_id": bson.M{"$eq": userUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if user == nil {
+			responses.NotFound(c, errors.New("user not found"))
+			return
+		}
+		if err = svc.User.ResetTwoFactor(ctx, user); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
+
+// @Tags        User (Admin)
+// @Summary     Delete User Sessions
+// @Description Sign out all sessions of a user by revoking all of the user's tokens.
+// @Router      /v1/auth/admin/user/{uid}/sessions [delete]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path string true "User's UID"
+// @Success     204
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func DeleteUserSessions(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			user         *models.UserModel
+			userUid      primitive.ObjectID
+			userUidParam = c.Param("user")
+			err          error
+		)
+
+		defer cancel()
+		if userUid, err = primitive.ObjectIDFromHex(userUidParam); err != nil {
+			responses.IncorrectUserId(c, err)
+			return
+		}
+		if user, err = svc.User.GetOne(ctx, bson.M{
+			"_id": bson.M{"$eq": userUid}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if user == nil {
+			responses.NotFound(c, errors.New("user not found"))
+			return
+		}
+		if err = svc.User.RevokeTokens(ctx, user); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
@@ -15,14 +15,17 @@ import (
 	"github.com/misterabdul/goblog-server/internal/service"
 )
 
+// Number of the author's latest posts shown in the public user.
+const latestPostsCount = 5
+
 // @Tags        User (Public)
 // @Summary     Get Public User
-// @Description Get a user that available publicly.
+// @Description Get a user that available publicly, along with the author's published post count & latest posts, co-authored ones included.
 // @Router      /v1/user/{uid} [get]
 // @Produce     application/json
 // @Produce     application/msgpack
 // @Param       uid path     string true "User's UID or slug"
-// @Success     200 {object} object{data=object{uid=string,username=string,email=string,firstName=string,lastName=string}}
+// @Success     200 {object} object{data=object{uid=string,username=string,email=string,firstName=string,lastName=string,bio=string,avatar=string,website=string,socials=[]object{network=string,url=string},publishedPostCount=int,latestPosts=[]object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string,avatar=string},commentCount=int,publishedAt=time}}}
 // @Failure     404 {object} object{message=string}
 // @Failure     500 {object} object{message=string}
 func GetPublicUser(
@@ -34,6 +37,8 @@ func GetPublicUser(
 		var (
 			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
 			user        *models.UserModel
+			posts       []*models.PostModel
+			postCount   int64
 			userUid     interface{}
 			userParam   = c.Param("user")
 			err         error
@@ -57,8 +62,27 @@ func GetPublicUser(
 			responses.NotFound(c, errors.New("user not found"))
 			return
 		}
+		if postCount, err = svc.Post.Count(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"publishedat": bson.M{"$ne": primitive.Null{}}},
+				service.PostAuthoredByFilter(user.UID)}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if posts, err = svc.Post.GetMany(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"publishedat": bson.M{"$ne": primitive.Null{}}},
+				service.PostAuthoredByFilter(user.UID)}},
+			internalGin.CreateFindOptions(latestPostsCount, 1, "publishedat", false),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
 
-		responses.PublicUser(c, user)
+		responses.PublicUserProfile(c, user, postCount, posts)
 	}
 }
 
@@ -72,7 +96,7 @@ func GetPublicUser(
 // @Param       page  query    int    false "Selected page of data."
 // @Param       order query    string false "Selected field to order data with."
 // @Param       asc   query    string false "Ascending or descending, e.g.: ?asc=false."
-// @Success     200   {object} object{data=[]object{uid=string,username=string,email=string,firstName=string,lastName=string}}
+// @Success     200   {object} object{data=[]object{uid=string,username=string,email=string,firstName=string,lastName=string,bio=string,avatar=string,website=string,socials=[]object{network=string,url=string}}}
 // @Success     204
 // @Failure     500   {object} object{message=string}
 func GetPublicUsers(
@@ -104,3 +128,70 @@ func GetPublicUsers(
 		responses.PublicUsers(c, users)
 	}
 }
+
+// @Tags        User (Public)
+// @Summary     Get Public User Posts
+// @Description Get the posts the user authored or co-authored that available publicly.
+// @Router      /v1/user/{uid}/posts [get]
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid   path     string true  "User's UID or username"
+// @Param       show  query    int    false "Number of data to be shown."
+// @Param       page  query    int    false "Selected page of data."
+// @Param       order query    string false "Selected field to order data with."
+// @Param       asc   query    string false "Ascending or descending, e.g.: ?asc=false."
+// @Success     200   {object} object{data=[]object{uid=string,slug=string,title=string,featuringImagePath=string,description=string,categories=[]object{uid=string,slug=string,name=string},tags=[]string,author=object{uid=string,username=string,email=string,firstName=string,lastName=string,avatar=string},commentCount=int,publishedAt=time}}
+// @Success     204
+// @Failure     404   {object} object{message=string}
+// @Failure     500   {object} object{message=string}
+func GetPublicUserPosts(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			user        *models.UserModel
+			posts       []*models.PostModel
+			userUid     interface{}
+			userParam   = c.Param("user")
+			err         error
+		)
+
+		defer cancel()
+		if userUid, err = primitive.ObjectIDFromHex(userParam); err != nil {
+			userUid = nil
+		}
+		if user, err = svc.User.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"$or": []bson.M{
+					{"_id": bson.M{"$eq": userUid}},
+					{"username": bson.M{"$eq": userParam}}}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if user == nil {
+			responses.NotFound(c, errors.New("user not found"))
+			return
+		}
+		if posts, err = svc.Post.GetMany(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"publishedat": bson.M{"$ne": primitive.Null{}}},
+				service.PostAuthoredByFilter(user.UID)}},
+			internalGin.GetFindOptionsPost(c),
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if len(posts) == 0 {
+			responses.NoContent(c)
+			return
+		}
+
+		responses.PublicPosts(c, posts)
+	}
+}
@@ -1,30 +1,205 @@
 package users
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
+	"github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
 	"github.com/misterabdul/goblog-server/internal/http/responses"
+	internalJwt "github.com/misterabdul/goblog-server/internal/pkg/jwt"
 	"github.com/misterabdul/goblog-server/internal/service"
+	"github.com/misterabdul/goblog-server/pkg/jwt"
 )
 
+// @Tags        User (SuperAdmin)
+// @Summary     Adminize User
+// @Description Grant the admin role to a user.
+// @Router      /v1/auth/superadmin/adminize/{uid} [put]
+// @Router      /v1/auth/superadmin/adminize/{uid} [patch]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path string true "User's UID"
+// @Success     204
+// @Failure     400 {object} object{message=string}
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
 func AdminizeUser(
 	maxCtxDuration time.Duration,
 	svc *service.Service,
 ) (handler gin.HandlerFunc) {
 
 	return func(c *gin.Context) {
-		responses.NotImplemented(c, nil)
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			user         *models.UserModel
+			userUid      primitive.ObjectID
+			userUidParam = c.Param("user")
+			err          error
+		)
+
+		defer cancel()
+		defer func() {
+			audit.Record(ctx, c, svc, models.AuditActionAdminize, audit.UserTarget(userUidParam, user))
+		}()
+		if userUid, err = primitive.ObjectIDFromHex(userUidParam); err != nil {
+			responses.IncorrectUserId(c, err)
+			return
+		}
+		if user, err = svc.User.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"_id": bson.M{"$eq": userUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if user == nil {
+			responses.NotFound(c, errors.New("user not found"))
+			return
+		}
+		if err = svc.User.AdminizeOne(ctx, user); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
 	}
 }
 
+// @Tags        User (SuperAdmin)
+// @Summary     Deadminize User
+// @Description Revoke the admin role from a user, signing out all of the user's sessions.
+// @Router      /v1/auth/superadmin/deadminize/{uid} [put]
+// @Router      /v1/auth/superadmin/deadminize/{uid} [patch]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path string true "User's UID"
+// @Success     204
+// @Failure     400 {object} object{message=string}
+// @Failure     401 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
 func DeadminizeUser(
 	maxCtxDuration time.Duration,
 	svc *service.Service,
 ) (handler gin.HandlerFunc) {
 
 	return func(c *gin.Context) {
-		responses.NotImplemented(c, nil)
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			user         *models.UserModel
+			userUid      primitive.ObjectID
+			userUidParam = c.Param("user")
+			err          error
+		)
+
+		defer cancel()
+		defer func() {
+			audit.Record(ctx, c, svc, models.AuditActionDeadminize, audit.UserTarget(userUidParam, user))
+		}()
+		if userUid, err = primitive.ObjectIDFromHex(userUidParam); err != nil {
+			responses.IncorrectUserId(c, err)
+			return
+		}
+		if user, err = svc.User.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"_id": bson.M{"$eq": userUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if user == nil {
+			responses.NotFound(c, errors.New("user not found"))
+			return
+		}
+		if err = svc.User.DeadminizeOne(ctx, user); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.NoContent(c)
+	}
+}
+
+// @Tags        User (SuperAdmin)
+// @Summary     Impersonate User
+// @Description Issue a short-lived access token of a user below the impersonator's level on behalf of them, every request made with it is audited. The SuperAdmin can't be impersonated.
+// @Router      /v1/auth/superadmin/impersonate/{uid} [post]
+// @Security    BearerAuth
+// @Produce     application/json
+// @Produce     application/msgpack
+// @Param       uid path string true "User's UID"
+// @Success     200 {object} object{data=object{tokenType=string,accessToken=string,expiresIn=int}}
+// @Failure     400 {object} object{message=string}
+// @Failure     401 {object} object{message=string}
+// @Failure     403 {object} object{message=string}
+// @Failure     404 {object} object{message=string}
+// @Failure     500 {object} object{message=string}
+func ImpersonateUser(
+	maxCtxDuration time.Duration,
+	svc *service.Service,
+) (handler gin.HandlerFunc) {
+
+	return func(c *gin.Context) {
+		var (
+			ctx, cancel  = context.WithTimeout(context.Background(), maxCtxDuration)
+			me           *models.UserModel
+			user         *models.UserModel
+			userUid      primitive.ObjectID
+			userUidParam = c.Param("user")
+			accessClaims *jwt.CustomClaims
+			accessToken  string
+			err          error
+		)
+
+		defer cancel()
+		defer func() {
+			audit.Record(ctx, c, svc, models.AuditActionImpersonate, audit.UserTarget(userUidParam, user))
+		}()
+		if me, err = authenticate.GetAuthenticatedUser(c); err != nil {
+			responses.Unauthenticated(c, err)
+			return
+		}
+		if userUid, err = primitive.ObjectIDFromHex(userUidParam); err != nil {
+			responses.IncorrectUserId(c, err)
+			return
+		}
+		if userUid == me.UID {
+			responses.SelfImpersonation(c)
+			return
+		}
+		if user, err = svc.User.GetOne(ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"_id": bson.M{"$eq": userUid}}}},
+		); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+		if user == nil {
+			responses.NotFound(c, errors.New("user not found"))
+			return
+		}
+		if !me.CanImpersonate(user) {
+			responses.ForbiddenImpersonation(c)
+			return
+		}
+		if accessClaims, accessToken, err = internalJwt.IssueImpersonationToken(me, user); err != nil {
+			responses.InternalServerError(c, err)
+			return
+		}
+
+		responses.Impersonating(c, accessToken, accessClaims)
 	}
 }
@@ -3,6 +3,7 @@ package authenticate
 import (
 	"context"
 	"errors"
+	"net/http"
 	"strings"
 	"time"
 
@@ -18,11 +19,17 @@ import (
 )
 
 const (
-	AuthenticatedClaims = "AUTH_CLAIMS"
-	AuthenticatedUser   = "AUTH_USER"
+	AuthenticatedClaims        = "AUTH_CLAIMS"
+	AuthenticatedUser          = "AUTH_USER"
+	AuthenticatedPersonalToken = "AUTH_PERSONAL_TOKEN"
+	AuthenticatedImpersonator  = "AUTH_IMPERSONATOR"
 )
 
-// Check the authentication status of given user.
+// Check the authentication status of given user,
+// either by JWT access token or personal token.
+//
+// The access token carrying the actor claim authenticates the impersonated user,
+// the impersonator is exposed separately & must still be allowed to impersonate.
 func Authenticate(
 	maxCtxDuration time.Duration,
 	svc *service.Service,
@@ -44,6 +51,10 @@ func Authenticate(
 			return
 		}
 		auth = strings.ReplaceAll(auth, "Bearer ", "")
+		if svc.PersonalToken.IsPersonalToken(auth) {
+			authenticatePersonalToken(ctx, c, svc, auth)
+			return
+		}
 		if accessClaims, err = internalJwt.CheckAccessToken(auth); err != nil {
 			responses.Unauthenticated(c, err)
 			c.Abort()
@@ -68,8 +79,145 @@ func Authenticate(
 			c.Abort()
 			return
 		}
+		if accessClaims.Version != me.TokenVersion {
+			responses.Unauthenticated(c, errors.New("outdated token version"))
+			c.Abort()
+			return
+		}
+		if accessClaims.Actor != nil {
+			authenticateImpersonator(ctx, c, svc, accessClaims.Actor)
+			if c.IsAborted() {
+				return
+			}
+		}
 		c.Set(AuthenticatedClaims, *accessClaims)
 		c.Set(AuthenticatedUser, *me)
 		c.Next()
 	}
 }
+
+// Reject the requests made while impersonating another user,
+// e.g. permanent deletes & password changes.
+func RejectImpersonation() (handler gin.HandlerFunc) {
+	return func(c *gin.Context) {
+		if _, isImpersonating := GetImpersonator(c); isImpersonating {
+			responses.Basic(c, http.StatusForbidden, gin.H{
+				"message": "this action isn't allowed while impersonating"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// Reject the requests authenticated with personal token,
+// they're only allowed on the routes authorized by their scopes.
+func RejectPersonalToken() (handler gin.HandlerFunc) {
+	return func(c *gin.Context) {
+		if _, isPersonal := GetAuthenticatedPersonalToken(c); isPersonal {
+			responses.Basic(c, http.StatusForbidden, gin.H{
+				"message": "personal tokens can't access this resource"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func authenticatePersonalToken(
+	ctx context.Context,
+	c *gin.Context,
+	svc *service.Service,
+	plainToken string,
+) {
+	var (
+		me    *models.UserModel
+		token *models.PersonalTokenModel
+		err   error
+	)
+
+	if token, err = svc.PersonalToken.GetOneByToken(ctx, plainToken); err != nil {
+		responses.Unauthenticated(c, err)
+		c.Abort()
+		return
+	}
+	if token == nil {
+		responses.Unauthenticated(c, errors.New("invalid or expired personal token"))
+		c.Abort()
+		return
+	}
+	if me, err = svc.User.GetOne(ctx, bson.M{
+		"$and": []bson.M{
+			{"deletedat": bson.M{"$eq": primitive.Null{}}},
+			{"_id": bson.M{"$eq": token.Owner.UID}}}},
+	); err != nil {
+		responses.Unauthenticated(c, err)
+		c.Abort()
+		return
+	}
+	if me == nil {
+		responses.Unauthenticated(c, errors.New("user not found"))
+		c.Abort()
+		return
+	}
+	if err = svc.PersonalToken.TouchOne(ctx, token); err != nil {
+		responses.InternalServerError(c, err)
+		c.Abort()
+		return
+	}
+	c.Set(AuthenticatedPersonalToken, *token)
+	c.Set(AuthenticatedUser, *me)
+	c.Next()
+}
+
+func authenticateImpersonator(
+	ctx context.Context,
+	c *gin.Context,
+	svc *service.Service,
+	actorClaim *jwt.ActorClaim,
+) {
+	var (
+		actor    *models.UserModel
+		actorUid primitive.ObjectID
+		granted  bool
+		err      error
+	)
+
+	if actorUid, err = primitive.ObjectIDFromHex(actorClaim.Subject); err != nil {
+		responses.Unauthenticated(c, err)
+		c.Abort()
+		return
+	}
+	if actor, err = svc.User.GetOne(ctx, bson.M{
+		"$and": []bson.M{
+			{"deletedat": bson.M{"$eq": primitive.Null{}}},
+			{"_id": bson.M{"$eq": actorUid}}}},
+	); err != nil {
+		responses.Unauthenticated(c, err)
+		c.Abort()
+		return
+	}
+	if actor == nil {
+		responses.Unauthenticated(c, errors.New("impersonator not found"))
+		c.Abort()
+		return
+	}
+	if actorClaim.Version != actor.TokenVersion {
+		responses.Unauthenticated(c, errors.New("outdated impersonator token version"))
+		c.Abort()
+		return
+	}
+	if granted, err = svc.Role.HasPermissions(
+		ctx, actor, models.PermissionUserImpersonate,
+	); err != nil {
+		responses.InternalServerError(c, err)
+		c.Abort()
+		return
+	}
+	if !granted {
+		responses.Unauthenticated(c, errors.New("impersonator is no longer allowed to impersonate"))
+		c.Abort()
+		return
+	}
+	c.Set(AuthenticatedImpersonator, *actor)
+}
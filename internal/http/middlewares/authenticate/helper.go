@@ -76,3 +76,37 @@ func GetRefreshClaims(c *gin.Context) (claims *jwt.CustomClaims, err error) {
 
 	return &_claims, nil
 }
+
+func GetAuthenticatedPersonalToken(c *gin.Context) (token *models.PersonalTokenModel, exist bool) {
+	var (
+		_token  models.PersonalTokenModel
+		rawData interface{}
+		ok      bool
+	)
+
+	if rawData, ok = c.Get(AuthenticatedPersonalToken); !ok {
+		return nil, false
+	}
+	if _token, ok = rawData.(models.PersonalTokenModel); !ok {
+		return nil, false
+	}
+
+	return &_token, true
+}
+
+func GetImpersonator(c *gin.Context) (actor *models.UserModel, impersonating bool) {
+	var (
+		_actor  models.UserModel
+		rawData interface{}
+		ok      bool
+	)
+
+	if rawData, ok = c.Get(AuthenticatedImpersonator); !ok {
+		return nil, false
+	}
+	if _actor, ok = rawData.(models.UserModel); !ok {
+		return nil, false
+	}
+
+	return &_actor, true
+}
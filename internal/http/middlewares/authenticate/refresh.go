@@ -76,12 +76,23 @@ func AuthenticateRefresh(
 			c.Abort()
 			return
 		}
+		if refreshClaims.Version != me.TokenVersion {
+			responses.Unauthenticated(c, errors.New("outdated token version"))
+			c.Abort()
+			return
+		}
 		c.Set(RefreshClaims, *refreshClaims)
 		c.Set(RefreshUser, *me)
 		c.Next()
 	}
 }
 
+// Check whether the refresh token is revoked by itself, by its owner
+// or by its token family.
+//
+// Presenting a token that's already rotated revokes its whole family,
+// unless it's rotated within the reuse grace, e.g. by a concurrent
+// refresh from another tab.
 func checkRevokedToken(
 	svc *service.Service,
 	ctx context.Context,
@@ -89,20 +100,50 @@ func checkRevokedToken(
 ) (noted bool, err error) {
 	var (
 		tokenUid         primitive.ObjectID
+		ownerUid         primitive.ObjectID
+		sessionUid       primitive.ObjectID
+		familyID         = internalJwt.GetRefreshTokenFamily(refreshClaims)
+		issuedAt         = time.Unix(refreshClaims.IssuedAt, 0)
 		revokedTokenData *models.RevokedTokenModel
 	)
 
 	if tokenUid, err = primitive.ObjectIDFromHex(refreshClaims.Id); err != nil {
 		return false, err
 	}
+	if ownerUid, err = primitive.ObjectIDFromHex(refreshClaims.Subject); err != nil {
+		return false, err
+	}
 	if revokedTokenData, err = svc.RevokedToken.GetOne(ctx, bson.M{
-		"_id": tokenUid,
+		"$or": []bson.M{
+			{"_id": bson.M{"$eq": tokenUid}},
+			{"revokedfamily": bson.M{"$eq": familyID}},
+			{"$and": []bson.M{
+				{"owner._id": bson.M{"$eq": ownerUid}},
+				{"issuedbefore": bson.M{
+					"$gt": primitive.NewDateTimeFromTime(issuedAt)}}}}},
 	}); err != nil {
 		return false, err
 	}
-	if revokedTokenData != nil {
-		return true, nil
+	if revokedTokenData == nil {
+		return false, nil
+	}
+	if revokedTokenData.UID == tokenUid && revokedTokenData.RotatedAt != nil &&
+		!revokedTokenData.IsRotatedWithin(internalJwt.GetRefreshTokenReuseGrace(), time.Now()) {
+		if err = svc.RevokedToken.RevokeFamily(
+			ctx,
+			revokedTokenData.Owner,
+			familyID,
+			time.Now().Add(internalJwt.GetRefreshTokenDuration()),
+		); err != nil {
+			return true, err
+		}
+		if sessionUid, err = primitive.ObjectIDFromHex(familyID); err != nil {
+			return true, err
+		}
+		if err = svc.Session.DeleteMany(ctx, bson.M{"_id": sessionUid}); err != nil {
+			return true, err
+		}
 	}
 
-	return false, nil
+	return true, nil
 }
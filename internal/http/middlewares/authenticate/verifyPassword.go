@@ -11,8 +11,8 @@ import (
 	"github.com/misterabdul/goblog-server/internal/http/forms"
 	"github.com/misterabdul/goblog-server/internal/http/requests"
 	"github.com/misterabdul/goblog-server/internal/http/responses"
+	"github.com/misterabdul/goblog-server/internal/pkg/hash"
 	"github.com/misterabdul/goblog-server/internal/service"
-	"github.com/misterabdul/goblog-server/pkg/hash"
 )
 
 func VerifyPassword(
@@ -13,16 +13,22 @@ import (
 	"github.com/misterabdul/goblog-server/internal/service"
 )
 
+// Allow the route only when the authenticated user's roles
+// grant all of the permissions.
 func Authorize(
 	maxCtxDuration time.Duration,
 	svc *service.Service,
-	level string,
+	permissions ...string,
 ) (handler gin.HandlerFunc) {
 	return func(c *gin.Context) {
 		var (
-			_, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
-			me        *models.UserModel
-			err       error
+			ctx, cancel = context.WithTimeout(context.Background(), maxCtxDuration)
+			me          *models.UserModel
+			token       *models.PersonalTokenModel
+			scope       string
+			granted     bool
+			isPersonal  bool
+			err         error
 		)
 
 		defer cancel()
@@ -31,11 +37,23 @@ func Authorize(
 			c.Abort()
 			return
 		}
-		if !CheckRoles(me, GetRole(level)) {
+		if granted, err = svc.Role.HasPermissions(ctx, me, permissions...); err != nil {
+			responses.InternalServerError(c, err)
+			c.Abort()
+			return
+		}
+		if !granted {
 			responses.UnauthorizedAction(c, errors.New("unauthorized action"))
 			c.Abort()
 			return
 		}
+		if token, isPersonal = authenticate.GetAuthenticatedPersonalToken(c); isPersonal {
+			if scope = GetRequiredScope(c); scope == "" || !token.HasScope(scope) {
+				responses.UnauthorizedAction(c, errors.New("missing personal token scope"))
+				c.Abort()
+				return
+			}
+		}
 		c.Next()
 	}
 }
@@ -0,0 +1,56 @@
+package authorize
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Resources of the role's routes, by the first path segment
+// after the role's group, e.g. /auth/writer/post/:post => posts.
+var scopeResources = map[string]string{
+	"posts":      "posts",
+	"post":       "posts",
+	"pages":      "pages",
+	"page":       "pages",
+	"categories": "categories",
+	"category":   "categories",
+	"comments":   "comments",
+	"comment":    "comments",
+	"users":      "users",
+	"user":       "users",
+	"lockouts":   "users",
+	"lockout":    "users"}
+
+// Get the personal token scope required by the route of the role's group,
+// empty when personal tokens aren't allowed there, e.g. any SuperAdmin's route.
+func GetRequiredScope(c *gin.Context) (scope string) {
+	var (
+		segments = strings.Split(c.FullPath(), "/")
+		resource string
+		ok       bool
+	)
+
+	for i, segment := range segments {
+		if segment != "auth" || i+2 >= len(segments) {
+			continue
+		}
+		if segments[i+1] == "superadmin" {
+			return ""
+		}
+		if resource, ok = scopeResources[segments[i+2]]; !ok {
+			return ""
+		}
+		switch {
+		case c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead:
+			return resource + ":read"
+		case resource == "comments":
+			return resource + ":moderate"
+		default:
+			return resource + ":write"
+		}
+	}
+
+	return ""
+}
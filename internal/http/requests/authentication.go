@@ -13,3 +13,59 @@ func GetSignInForm(c *gin.Context) (form *forms.SignInForm, err error) {
 
 	return &_form, err
 }
+
+func GetSignUpForm(c *gin.Context) (form *forms.SignUpForm, err error) {
+	var _form = forms.SignUpForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
+
+func GetVerifyEmailForm(c *gin.Context) (form *forms.VerifyEmailForm, err error) {
+	var _form = forms.VerifyEmailForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
+
+func GetResendEmailVerificationForm(c *gin.Context) (form *forms.ResendEmailVerificationForm, err error) {
+	var _form = forms.ResendEmailVerificationForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
+
+func GetForgotPasswordForm(c *gin.Context) (form *forms.ForgotPasswordForm, err error) {
+	var _form = forms.ForgotPasswordForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
+
+func GetResetPasswordForm(c *gin.Context) (form *forms.ResetPasswordForm, err error) {
+	var _form = forms.ResetPasswordForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
+
+func GetCreatePersonalTokenForm(c *gin.Context) (form *forms.CreatePersonalTokenForm, err error) {
+	var _form = forms.CreatePersonalTokenForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
+
+func GetOidcCallbackForm(c *gin.Context) (form *forms.OidcCallbackForm, err error) {
+	var _form = forms.OidcCallbackForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
@@ -0,0 +1,23 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+)
+
+func GetCreateInvitationForm(c *gin.Context) (form *forms.CreateInvitationForm, err error) {
+	var _form = forms.CreateInvitationForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
+
+func GetAcceptInvitationForm(c *gin.Context) (form *forms.AcceptInvitationForm, err error) {
+	var _form = forms.AcceptInvitationForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
@@ -21,3 +21,11 @@ func GetUpdatePostForm(c *gin.Context) (form *forms.UpdatePostForm, err error) {
 
 	return &_form, err
 }
+
+func GetUpdatePostContributorsForm(c *gin.Context) (form *forms.UpdatePostContributorsForm, err error) {
+	var _form = forms.UpdatePostContributorsForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
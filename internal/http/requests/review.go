@@ -0,0 +1,27 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+)
+
+func GetPostReviewForm(c *gin.Context) (form *forms.PostReviewForm, err error) {
+	var _form = forms.PostReviewForm{}
+
+	// The note is optional, so is the body.
+	if c.Request.ContentLength == 0 {
+		return &_form, nil
+	}
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
+
+func GetPostChangesRequestForm(c *gin.Context) (form *forms.PostChangesRequestForm, err error) {
+	var _form = forms.PostChangesRequestForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
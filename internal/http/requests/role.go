@@ -0,0 +1,23 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+)
+
+func GetCreateRoleForm(c *gin.Context) (form *forms.CreateRoleForm, err error) {
+	var _form = forms.CreateRoleForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
+
+func GetUpdateRoleForm(c *gin.Context) (form *forms.UpdateRoleForm, err error) {
+	var _form = forms.UpdateRoleForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
@@ -0,0 +1,15 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+)
+
+func GetSchedulePublishForm(c *gin.Context) (form *forms.SchedulePublishForm, err error) {
+	var _form = forms.SchedulePublishForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
@@ -0,0 +1,31 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+)
+
+func GetCreateSeriesForm(c *gin.Context) (form *forms.CreateSeriesForm, err error) {
+	var _form = forms.CreateSeriesForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
+
+func GetUpdateSeriesForm(c *gin.Context) (form *forms.UpdateSeriesForm, err error) {
+	var _form = forms.UpdateSeriesForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
+
+func GetAttachPostSeriesForm(c *gin.Context) (form *forms.AttachPostSeriesForm, err error) {
+	var _form = forms.AttachPostSeriesForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
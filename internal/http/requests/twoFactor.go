@@ -0,0 +1,23 @@
+package requests
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/http/forms"
+)
+
+func GetTwoFactorCodeForm(c *gin.Context) (form *forms.TwoFactorCodeForm, err error) {
+	var _form = forms.TwoFactorCodeForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
+
+func GetSignInTwoFactorForm(c *gin.Context) (form *forms.SignInTwoFactorForm, err error) {
+	var _form = forms.SignInTwoFactorForm{}
+
+	err = shouldBind(c, &_form)
+
+	return &_form, err
+}
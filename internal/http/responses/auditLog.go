@@ -0,0 +1,60 @@
+package responses
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+func AuditLogs(
+	c *gin.Context,
+	auditLogs []*models.AuditLogModel,
+) {
+	var data []gin.H
+
+	for _, auditLog := range auditLogs {
+		data = append(data, extractAuditLogData(auditLog))
+	}
+	Basic(c, http.StatusOK, gin.H{"data": data})
+}
+
+func IncorrectAuditLogFilter(c *gin.Context, err error) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "incorrect audit log filter: " + err.Error()})
+}
+
+func extractAuditLogData(
+	auditLog *models.AuditLogModel,
+) (extracted gin.H) {
+	return gin.H{
+		"uid":          auditLog.UID.Hex(),
+		"action":       auditLog.Action,
+		"actor":        extractAuditLogActorData(auditLog.Actor),
+		"impersonator": extractAuditLogActorData(auditLog.Impersonator),
+		"target": gin.H{
+			"type": auditLog.Target.Type,
+			"uid":  auditLog.Target.UID,
+			"name": auditLog.Target.Name},
+		"ip":        auditLog.IP,
+		"userAgent": auditLog.UserAgent,
+		"outcome":   auditLog.Outcome,
+		"status":    auditLog.Status,
+		"createdAt": auditLog.CreatedAt}
+}
+
+func extractAuditLogActorData(
+	actor *models.UserCommonModel,
+) (extracted gin.H) {
+	if actor == nil {
+		return nil
+	}
+
+	return gin.H{
+		"uid":       actor.UID.Hex(),
+		"username":  actor.Username,
+		"email":     actor.Email,
+		"firstName": actor.FirstName,
+		"lastName":  actor.LastName}
+}
@@ -1,8 +1,11 @@
 package responses
 
 import (
+	"math"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -23,6 +26,49 @@ func WrongSignIn(c *gin.Context, err error) {
 		"message": "Wrong username or password."})
 }
 
+func SignInLocked(c *gin.Context, retryAfter time.Duration, err error) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	Basic(c, http.StatusTooManyRequests, gin.H{
+		"message": "Too many failed sign in attempts, please try again later."})
+}
+
+func UnverifiedSignIn(c *gin.Context, err error) {
+	Basic(c, http.StatusForbidden, gin.H{
+		"message": "Email address is not verified yet."})
+}
+
+func SignedUp(c *gin.Context) {
+	Basic(c, http.StatusCreated, gin.H{
+		"message": "Signed up, please check your email to verify your account."})
+}
+
+func InvalidVerificationToken(c *gin.Context, err error) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "Invalid or expired verification token."})
+}
+
+func InvalidPasswordResetToken(c *gin.Context, err error) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "Invalid or expired password reset token."})
+}
+
+func WrongTwoFactorCode(c *gin.Context, err error) {
+	Basic(c, http.StatusUnauthorized, gin.H{
+		"message": "Invalid or expired two-factor authentication code."})
+}
+
+func TwoFactorRequired(
+	c *gin.Context,
+	mfaToken string,
+	mfaClaims *jwt.CustomClaims,
+) {
+	Basic(c, http.StatusOK, gin.H{
+		"data": gin.H{
+			"tokenType": "MFA",
+			"mfaToken":  mfaToken,
+			"expiresIn": mfaClaims.GetExpiresAtSeconds()}})
+}
+
 func SignedIn(
 	c *gin.Context,
 	accessToken string,
@@ -89,3 +135,25 @@ func SigningOut(c *gin.Context) {
 
 	NoContent(c)
 }
+
+func Impersonating(
+	c *gin.Context,
+	accessToken string,
+	accessClaims *jwt.CustomClaims,
+) {
+	Basic(c, http.StatusOK, gin.H{
+		"data": gin.H{
+			"tokenType":   "Bearer",
+			"accessToken": accessToken,
+			"expiresIn":   accessClaims.GetExpiresAtSeconds()}})
+}
+
+func SelfImpersonation(c *gin.Context) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "can't impersonate yourself"})
+}
+
+func ForbiddenImpersonation(c *gin.Context) {
+	Basic(c, http.StatusForbidden, gin.H{
+		"message": "can't impersonate the superadmin or a user at or above your level"})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/misterabdul/goblog-server/internal/database/models"
 	internalGin "github.com/misterabdul/goblog-server/internal/pkg/gin"
 )
 
@@ -22,3 +23,45 @@ func ResourceStats(c *gin.Context, count int64) {
 		"itemsPerPage": *show,
 		"totalItems":   count}})
 }
+
+// Replace the markdown content with its rendered HTML & table of contents,
+// or add them next to it, as requested by the format query.
+func formatContent(
+	c *gin.Context,
+	data gin.H,
+	html string,
+	tableOfContents []models.ContentHeadingModel,
+) (formatted gin.H) {
+	switch internalGin.GetContentFormatQuery(c) {
+	case internalGin.ContentFormatHTML:
+		delete(data, "content")
+		fallthrough
+	case internalGin.ContentFormatBoth:
+		data["html"] = html
+		data["tableOfContents"] = tableOfContents
+	}
+
+	return data
+}
+
+func extractContentAnalyticsData(
+	analytics models.ContentAnalyticsModel,
+) (extracted gin.H) {
+	var seoChecks = []gin.H{}
+
+	for _, check := range analytics.SEOChecks {
+		seoChecks = append(seoChecks, gin.H{
+			"name":    check.Name,
+			"passed":  check.Passed,
+			"message": check.Message})
+	}
+
+	return gin.H{
+		"wordCount":     analytics.WordCount,
+		"readingTime":   analytics.ReadingTime,
+		"outline":       analytics.Outline,
+		"imageCount":    analytics.ImageCount,
+		"internalLinks": analytics.InternalLinks,
+		"externalLinks": analytics.ExternalLinks,
+		"seoChecks":     seoChecks}
+}
@@ -0,0 +1,69 @@
+package responses
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+func MyDataExports(
+	c *gin.Context,
+	dataExports []*models.DataExportModel,
+) {
+	var data []gin.H
+
+	for _, dataExport := range dataExports {
+		data = append(data, extractMyDataExportData(dataExport))
+	}
+	Basic(c, http.StatusOK, gin.H{"data": data})
+}
+
+// The data export is built later by the worker.
+func AcceptedDataExport(
+	c *gin.Context,
+	dataExport *models.DataExportModel,
+) {
+	Basic(c, http.StatusAccepted, gin.H{
+		"data": extractMyDataExportData(dataExport)})
+}
+
+// Stream the data export's archive regardless of the accept header.
+func DataExportArchive(
+	c *gin.Context,
+	dataExport *models.DataExportModel,
+	archive io.Reader,
+) {
+	c.DataFromReader(
+		http.StatusOK,
+		dataExport.Size,
+		"application/zip",
+		archive,
+		map[string]string{
+			"Content-Disposition": "attachment; filename=\"goblog-export-" +
+				dataExport.UID.Hex() + ".zip\""})
+}
+
+func IncorrectDataExportId(c *gin.Context, err error) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "incorrect data export id format"})
+}
+
+func DataExportNotReady(c *gin.Context) {
+	Basic(c, http.StatusConflict, gin.H{
+		"message": "data export is not ready"})
+}
+
+func extractMyDataExportData(
+	dataExport *models.DataExportModel,
+) (extracted gin.H) {
+	return gin.H{
+		"uid":        dataExport.UID.Hex(),
+		"status":     dataExport.Status,
+		"size":       dataExport.Size,
+		"expiresAt":  dataExport.ExpiresAt,
+		"createdAt":  dataExport.CreatedAt,
+		"finishedAt": dataExport.FinishedAt}
+}
@@ -0,0 +1,86 @@
+package responses
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+func Invitation(c *gin.Context, invitation *models.InvitationModel) {
+	Basic(c, http.StatusOK, gin.H{
+		"data": extractInvitationData(invitation)})
+}
+
+func CreatedInvitation(c *gin.Context, invitation *models.InvitationModel) {
+	Basic(c, http.StatusCreated, gin.H{
+		"data": extractInvitationData(invitation)})
+}
+
+func Invitations(c *gin.Context, invitations []*models.InvitationModel) {
+	var data []gin.H
+
+	for _, invitation := range invitations {
+		data = append(data, extractInvitationData(invitation))
+	}
+	Basic(c, http.StatusOK, gin.H{"data": data})
+}
+
+func IncorrectInvitationId(c *gin.Context, err error) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "incorrect invitation id format"})
+}
+
+func InvitationNotPending(c *gin.Context, err error) {
+	Basic(c, http.StatusConflict, gin.H{
+		"message": "invitation is no longer pending"})
+}
+
+func InvalidInvitationToken(c *gin.Context, err error) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "Invalid or expired invitation token."})
+}
+
+func extractInvitationData(invitation *models.InvitationModel) gin.H {
+	return gin.H{
+		"uid":        invitation.UID.Hex(),
+		"email":      invitation.Email,
+		"firstName":  invitation.FirstName,
+		"lastName":   invitation.LastName,
+		"roles":      extractInvitationRoles(invitation.Roles),
+		"status":     getInvitationStatus(invitation),
+		"invitedBy":  extractCommonAuthorData(invitation.InvitedBy),
+		"sentCount":  invitation.SentCount,
+		"expiresAt":  invitation.ExpiresAt,
+		"acceptedAt": invitation.AcceptedAt,
+		"revokedAt":  invitation.RevokedAt,
+		"createdAt":  invitation.CreatedAt,
+		"updatedAt":  invitation.UpdatedAt}
+}
+
+func extractInvitationRoles(roles []models.UserRole) []gin.H {
+	var data = []gin.H{}
+
+	for _, role := range roles {
+		data = append(data, gin.H{
+			"level": role.Level,
+			"name":  role.Name})
+	}
+
+	return data
+}
+
+func getInvitationStatus(invitation *models.InvitationModel) string {
+	switch {
+	case invitation.AcceptedAt != nil:
+		return "accepted"
+	case invitation.RevokedAt != nil:
+		return "revoked"
+	case invitation.ExpiresAt.Time().Before(time.Now()):
+		return "expired"
+	default:
+		return "pending"
+	}
+}
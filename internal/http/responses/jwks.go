@@ -0,0 +1,16 @@
+package responses
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/pkg/jwt"
+)
+
+// Always in JSON, as the JWKS consumers expect regardless of the accept header.
+func JSONWebKeySet(c *gin.Context, keys []jwt.JSONWebKey) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, gin.H{
+		"keys": keys})
+}
@@ -0,0 +1,37 @@
+package responses
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+func Lockouts(
+	c *gin.Context,
+	attempts []*models.LoginAttemptModel,
+) {
+	var data []gin.H
+
+	for _, attempt := range attempts {
+		data = append(data, extractLockoutData(attempt))
+	}
+	Basic(c, http.StatusOK, gin.H{"data": data})
+}
+
+func IncorrectLockoutId(c *gin.Context, err error) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "incorrect lockout id format"})
+}
+
+func extractLockoutData(
+	attempt *models.LoginAttemptModel,
+) (extracted gin.H) {
+	return gin.H{
+		"uid":          attempt.UID.Hex(),
+		"key":          attempt.Key,
+		"failures":     attempt.Failures,
+		"lastFailedAt": attempt.LastFailedAt,
+		"lockedUntil":  attempt.LockedUntil}
+}
@@ -0,0 +1,50 @@
+package responses
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func OidcProviders(c *gin.Context, names []string) {
+	Basic(c, http.StatusOK, gin.H{"data": names})
+}
+
+func OidcAuthorization(
+	c *gin.Context,
+	authorizationUrl string,
+	state string,
+	expiresAt time.Time,
+) {
+	Basic(c, http.StatusOK, gin.H{
+		"data": gin.H{
+			"authorizationUrl": authorizationUrl,
+			"state":            state,
+			"expiresIn":        int64(time.Until(expiresAt).Seconds())}})
+}
+
+func UnknownOidcProvider(c *gin.Context, err error) {
+	Basic(c, http.StatusNotFound, gin.H{
+		"message": "Unknown sign in provider."})
+}
+
+func InvalidOidcState(c *gin.Context, err error) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "Invalid or expired sign in state."})
+}
+
+func OidcSignInFailed(c *gin.Context, err error) {
+	Basic(c, http.StatusUnauthorized, gin.H{
+		"message": "Unable to sign in with the provider."})
+}
+
+func OidcUnverifiedEmail(c *gin.Context, err error) {
+	Basic(c, http.StatusForbidden, gin.H{
+		"message": "The provider's email address is not verified."})
+}
+
+func OidcAccountConflict(c *gin.Context, err error) {
+	Basic(c, http.StatusConflict, gin.H{
+		"message": "An account with this email address can't be linked, sign in with password instead."})
+}
@@ -14,6 +14,9 @@ func PublicPage(
 	pageContent *models.PageContentModel,
 ) {
 	data := extractPublicPageData(page, pageContent)
+	if pageContent != nil {
+		data = formatContent(c, data, pageContent.HTML, pageContent.TableOfContents)
+	}
 	Basic(c, http.StatusOK, gin.H{"data": data})
 }
 
@@ -70,12 +73,14 @@ func extractPublicPageData(
 			"uid":         page.UID.Hex(),
 			"slug":        page.Slug,
 			"title":       page.Title,
+			"readingTime": page.Analytics.ReadingTime,
 			"publishedAt": page.PublishedAt}
 	}
 	return gin.H{
 		"uid":         page.UID.Hex(),
 		"slug":        page.Slug,
 		"title":       page.Title,
+		"readingTime": page.Analytics.ReadingTime,
 		"content":     pageContent.Content,
 		"publishedAt": page.PublishedAt}
 }
@@ -90,6 +95,8 @@ func extractAuthorizedPageData(
 			"slug":        page.Slug,
 			"title":       page.Title,
 			"author":      extractCommonAuthorData(page.Author),
+			"readingTime": page.Analytics.ReadingTime,
+			"publishAt":   page.PublishAt,
 			"publishedAt": page.PublishedAt,
 			"createdAt":   page.CreatedAt,
 			"updatedAt":   page.UpdatedAt,
@@ -101,6 +108,8 @@ func extractAuthorizedPageData(
 		"title":       page.Title,
 		"content":     pageContent.Content,
 		"author":      extractCommonAuthorData(page.Author),
+		"analytics":   extractContentAnalyticsData(page.Analytics),
+		"publishAt":   page.PublishAt,
 		"publishedAt": page.PublishedAt,
 		"createdAt":   page.CreatedAt,
 		"updatedAt":   page.UpdatedAt,
@@ -0,0 +1,51 @@
+package responses
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+func MyPersonalTokens(
+	c *gin.Context,
+	tokens []*models.PersonalTokenModel,
+) {
+	var data []gin.H
+
+	for _, token := range tokens {
+		data = append(data, extractMyPersonalTokenData(token))
+	}
+	Basic(c, http.StatusOK, gin.H{"data": data})
+}
+
+// The plain token is only shown once, right after it's created.
+func CreatedPersonalToken(
+	c *gin.Context,
+	token *models.PersonalTokenModel,
+	plainToken string,
+) {
+	var data = extractMyPersonalTokenData(token)
+
+	data["token"] = plainToken
+	Basic(c, http.StatusCreated, gin.H{"data": data})
+}
+
+func IncorrectPersonalTokenId(c *gin.Context, err error) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "incorrect personal token id format"})
+}
+
+func extractMyPersonalTokenData(
+	token *models.PersonalTokenModel,
+) (extracted gin.H) {
+	return gin.H{
+		"uid":        token.UID.Hex(),
+		"name":       token.Name,
+		"hint":       token.Hint,
+		"scopes":     token.Scopes,
+		"expiresAt":  token.ExpiresAt,
+		"lastUsedAt": token.LastUsedAt,
+		"createdAt":  token.CreatedAt}
+}
@@ -12,8 +12,14 @@ func PublicPost(
 	c *gin.Context,
 	post *models.PostModel,
 	postContent *models.PostContentModel,
+	series *models.SeriesModel,
+	seriesParts []*models.PostModel,
 ) {
 	data := extractPublicPostData(post, postContent)
+	if postContent != nil {
+		data = formatContent(c, data, postContent.HTML, postContent.TableOfContents)
+	}
+	data["series"] = extractPostSeriesData(post, series, seriesParts)
 	Basic(c, http.StatusOK, gin.H{"data": data})
 }
 
@@ -75,7 +81,9 @@ func extractPublicPostData(
 			"categories":         extractPostCategoryData(post.Categories),
 			"tags":               post.Tags,
 			"author":             extractCommonAuthorData(post.Author),
+			"contributors":       extractPostContributorsData(post.GetContributors()),
 			"commentCount":       post.CommentCount,
+			"readingTime":        post.Analytics.ReadingTime,
 			"publishedAt":        post.PublishedAt}
 	}
 	return gin.H{
@@ -88,10 +96,25 @@ func extractPublicPostData(
 		"tags":               post.Tags,
 		"content":            postContent.Content,
 		"author":             extractCommonAuthorData(post.Author),
+		"contributors":       extractPostContributorsData(post.GetContributors()),
 		"commentCount":       post.CommentCount,
+		"readingTime":        post.Analytics.ReadingTime,
 		"publishedAt":        post.PublishedAt}
 }
 
+func extractPostContributorsData(
+	contributors []models.PostContributorModel,
+) (extracted []gin.H) {
+	extracted = []gin.H{}
+	for _, contributor := range contributors {
+		data := extractCommonAuthorData(contributor.UserCommonModel)
+		data["role"] = contributor.Role
+		extracted = append(extracted, data)
+	}
+
+	return extracted
+}
+
 func extractAuthorizedPostData(
 	post *models.PostModel,
 	postContent *models.PostContentModel,
@@ -106,7 +129,11 @@ func extractAuthorizedPostData(
 			"categories":         extractPostCategoryData(post.Categories),
 			"tags":               post.Tags,
 			"author":             extractCommonAuthorData(post.Author),
+			"contributors":       extractPostContributorsData(post.GetContributors()),
 			"commentCount":       post.CommentCount,
+			"readingTime":        post.Analytics.ReadingTime,
+			"status":             post.GetStatus(),
+			"publishAt":          post.PublishAt,
 			"publishedAt":        post.PublishedAt,
 			"createdAt":          post.CreatedAt,
 			"updatedAt":          post.UpdatedAt,
@@ -122,7 +149,12 @@ func extractAuthorizedPostData(
 		"tags":               post.Tags,
 		"content":            postContent.Content,
 		"author":             extractCommonAuthorData(post.Author),
+		"contributors":       extractPostContributorsData(post.GetContributors()),
 		"commentCount":       post.CommentCount,
+		"analytics":          extractContentAnalyticsData(post.Analytics),
+		"status":             post.GetStatus(),
+		"transitions":        extractPostTransitionsData(post.Transitions),
+		"publishAt":          post.PublishAt,
 		"publishedAt":        post.PublishedAt,
 		"createdAt":          post.CreatedAt,
 		"updatedAt":          post.UpdatedAt,
@@ -0,0 +1,35 @@
+package responses
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+func PostNotApproved(c *gin.Context) {
+	Basic(c, http.StatusForbidden, gin.H{
+		"message": "post needs to be approved by an editor before publishing"})
+}
+
+func IncorrectPostTransition(c *gin.Context, post *models.PostModel, status string) {
+	Basic(c, http.StatusConflict, gin.H{
+		"message": "unable to move " + post.GetStatus() + " post to " + status})
+}
+
+func extractPostTransitionsData(
+	transitions []models.PostTransitionModel,
+) (extracted []gin.H) {
+	extracted = []gin.H{}
+	for _, transition := range transitions {
+		extracted = append(extracted, gin.H{
+			"from":      transition.From,
+			"to":        transition.To,
+			"note":      transition.Note,
+			"actor":     extractAuditLogActorData(transition.Actor),
+			"createdAt": transition.CreatedAt})
+	}
+
+	return extracted
+}
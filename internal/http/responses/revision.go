@@ -0,0 +1,104 @@
+package responses
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+func PostRevision(c *gin.Context, revision *models.PostRevisionModel) {
+	Basic(c, http.StatusOK, gin.H{
+		"data": extractPostRevisionData(revision, true)})
+}
+
+func PostRevisions(c *gin.Context, revisions []*models.PostRevisionModel) {
+	var data []gin.H
+
+	for _, revision := range revisions {
+		data = append(data, extractPostRevisionData(revision, false))
+	}
+	Basic(c, http.StatusOK, gin.H{"data": data})
+}
+
+func PageRevision(c *gin.Context, revision *models.PageRevisionModel) {
+	Basic(c, http.StatusOK, gin.H{
+		"data": extractPageRevisionData(revision, true)})
+}
+
+func PageRevisions(c *gin.Context, revisions []*models.PageRevisionModel) {
+	var data []gin.H
+
+	for _, revision := range revisions {
+		data = append(data, extractPageRevisionData(revision, false))
+	}
+	Basic(c, http.StatusOK, gin.H{"data": data})
+}
+
+func RevisionDiff(
+	c *gin.Context,
+	fromNumber int,
+	toNumber int,
+	unified string,
+) {
+	Basic(c, http.StatusOK, gin.H{
+		"data": gin.H{
+			"from": fromNumber,
+			"to":   toNumber,
+			"diff": unified}})
+}
+
+func IncorrectRevisionId(c *gin.Context, err error) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "incorrect revision id format"})
+}
+
+func RevisionSlugTaken(c *gin.Context, err error) {
+	Basic(c, http.StatusConflict, gin.H{
+		"message": "revision's slug is already used by another one"})
+}
+
+func extractPostRevisionData(
+	revision *models.PostRevisionModel,
+	withContent bool,
+) (extracted gin.H) {
+	extracted = gin.H{
+		"uid":                revision.UID.Hex(),
+		"number":             revision.Number,
+		"slug":               revision.Slug,
+		"title":              revision.Title,
+		"featuringImagePath": revision.FeaturingImagePath,
+		"description":        revision.Description,
+		"categories":         extractPostCategoryData(revision.Categories),
+		"tags":               revision.Tags,
+		"changedFields":      revision.ChangedFields,
+		"editor":             extractCommonAuthorData(revision.Editor),
+		"restoredFrom":       revision.RestoredFrom,
+		"createdAt":          revision.CreatedAt}
+	if withContent {
+		extracted["content"] = revision.Content
+	}
+
+	return extracted
+}
+
+func extractPageRevisionData(
+	revision *models.PageRevisionModel,
+	withContent bool,
+) (extracted gin.H) {
+	extracted = gin.H{
+		"uid":           revision.UID.Hex(),
+		"number":        revision.Number,
+		"slug":          revision.Slug,
+		"title":         revision.Title,
+		"changedFields": revision.ChangedFields,
+		"editor":        extractCommonAuthorData(revision.Editor),
+		"restoredFrom":  revision.RestoredFrom,
+		"createdAt":     revision.CreatedAt}
+	if withContent {
+		extracted["content"] = revision.Content
+	}
+
+	return extracted
+}
@@ -0,0 +1,49 @@
+package responses
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+func Role(c *gin.Context, role *models.RoleModel) {
+	Basic(c, http.StatusOK, gin.H{
+		"data": extractRoleData(role)})
+}
+
+func Roles(c *gin.Context, roles []*models.RoleModel) {
+	var data []gin.H
+
+	for _, role := range roles {
+		data = append(data, extractRoleData(role))
+	}
+	Basic(c, http.StatusOK, gin.H{"data": data})
+}
+
+func Permissions(c *gin.Context, permissions []string) {
+	Basic(c, http.StatusOK, gin.H{"data": permissions})
+}
+
+func IncorrectRoleId(c *gin.Context, err error) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "incorrect role id format"})
+}
+
+func SystemRole(c *gin.Context, err error) {
+	Basic(c, http.StatusForbidden, gin.H{
+		"message": "built-in roles cannot be deleted"})
+}
+
+func extractRoleData(role *models.RoleModel) (extracted gin.H) {
+	return gin.H{
+		"uid":         role.UID.Hex(),
+		"level":       role.Level,
+		"name":        role.Name,
+		"description": role.Description,
+		"permissions": role.Permissions,
+		"system":      role.System,
+		"createdAt":   role.CreatedAt,
+		"updatedAt":   role.UpdatedAt}
+}
@@ -0,0 +1,78 @@
+package responses
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+// Group the scheduled posts & pages by their publish day in from's location,
+// every day of the range is listed even without any schedule.
+func EditorialCalendar(
+	c *gin.Context,
+	from time.Time,
+	days int,
+	posts []*models.PostModel,
+	pages []*models.PageModel,
+) {
+	var data = make([]gin.H, days)
+
+	for i := range data {
+		data[i] = gin.H{
+			"date":  from.AddDate(0, 0, i).Format("2006-01-02"),
+			"posts": []gin.H{},
+			"pages": []gin.H{}}
+	}
+	for _, post := range posts {
+		if i := calendarDayIndex(from, days, post.PublishAt); i >= 0 {
+			data[i]["posts"] = append(
+				data[i]["posts"].([]gin.H), extractAuthorizedPostData(post, nil))
+		}
+	}
+	for _, page := range pages {
+		if i := calendarDayIndex(from, days, page.PublishAt); i >= 0 {
+			data[i]["pages"] = append(
+				data[i]["pages"].([]gin.H), extractAuthorizedPageData(page, nil))
+		}
+	}
+	Basic(c, http.StatusOK, gin.H{"data": data})
+}
+
+func AlreadyPublished(c *gin.Context, err error) {
+	Basic(c, http.StatusConflict, gin.H{
+		"message": err.Error()})
+}
+
+func IncorrectCalendarRange(c *gin.Context, err error) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "incorrect calendar range: " + err.Error()})
+}
+
+func calendarDayIndex(
+	from time.Time,
+	days int,
+	publishAt interface{},
+) (index int) {
+	var (
+		dateTime, ok = publishAt.(primitive.DateTime)
+		publishTime  time.Time
+	)
+
+	if !ok {
+		return -1
+	}
+	if publishTime = dateTime.Time(); publishTime.Before(from) {
+		return -1
+	}
+	for index = 0; index < days; index++ {
+		if publishTime.Before(from.AddDate(0, 0, index+1)) {
+			return index
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,122 @@
+package responses
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+func PublicSeries(
+	c *gin.Context,
+	series *models.SeriesModel,
+	parts []*models.PostModel,
+) {
+	data := extractPublicSeriesData(series)
+	data["posts"] = extractSeriesPartsData(parts)
+	Basic(c, http.StatusOK, gin.H{"data": data})
+}
+
+func AuthorizedSeries(c *gin.Context, series *models.SeriesModel) {
+	data := extractAuthorizedSeriesData(series)
+	Basic(c, http.StatusOK, gin.H{"data": data})
+}
+
+func PublicManySeries(c *gin.Context, manySeries []*models.SeriesModel) {
+	var data []gin.H
+
+	for _, series := range manySeries {
+		data = append(data, extractPublicSeriesData(series))
+	}
+	Basic(c, http.StatusOK, gin.H{"data": data})
+}
+
+func AuthorizedManySeries(c *gin.Context, manySeries []*models.SeriesModel) {
+	var data []gin.H
+
+	for _, series := range manySeries {
+		data = append(data, extractAuthorizedSeriesData(series))
+	}
+	Basic(c, http.StatusOK, gin.H{"data": data})
+}
+
+func IncorrectSeriesId(c *gin.Context, err error) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "incorrect series id format"})
+}
+
+func extractPublicSeriesData(series *models.SeriesModel) (extracted gin.H) {
+	return gin.H{
+		"uid":         series.UID.Hex(),
+		"slug":        series.Slug,
+		"title":       series.Title,
+		"description": series.Description}
+}
+
+func extractAuthorizedSeriesData(series *models.SeriesModel) (extracted gin.H) {
+	var posts = []string{}
+
+	for _, postUid := range series.Posts {
+		posts = append(posts, postUid.Hex())
+	}
+
+	return gin.H{
+		"uid":         series.UID.Hex(),
+		"slug":        series.Slug,
+		"title":       series.Title,
+		"description": series.Description,
+		"posts":       posts,
+		"createdAt":   series.CreatedAt,
+		"updatedAt":   series.UpdatedAt,
+		"deletedAt":   series.DeletedAt}
+}
+
+func extractSeriesPartsData(parts []*models.PostModel) (extracted []gin.H) {
+	extracted = []gin.H{}
+	for index, part := range parts {
+		data := extractPublicPostData(part, nil)
+		data["position"] = index + 1
+		extracted = append(extracted, data)
+	}
+
+	return extracted
+}
+
+func extractSeriesPartLinkData(part *models.PostModel) (extracted gin.H) {
+	return gin.H{
+		"uid":   part.UID.Hex(),
+		"slug":  part.Slug,
+		"title": part.Title}
+}
+
+// The post's navigation within the published parts of its series,
+// null when the post isn't in any series.
+func extractPostSeriesData(
+	post *models.PostModel,
+	series *models.SeriesModel,
+	parts []*models.PostModel,
+) (extracted gin.H) {
+	if series == nil {
+		return nil
+	}
+	extracted = extractPublicSeriesData(series)
+	extracted["position"] = nil
+	extracted["total"] = len(parts)
+	extracted["previous"] = nil
+	extracted["next"] = nil
+	for index, part := range parts {
+		if part.UID != post.UID {
+			continue
+		}
+		extracted["position"] = index + 1
+		if index > 0 {
+			extracted["previous"] = extractSeriesPartLinkData(parts[index-1])
+		}
+		if index < len(parts)-1 {
+			extracted["next"] = extractSeriesPartLinkData(parts[index+1])
+		}
+	}
+
+	return extracted
+}
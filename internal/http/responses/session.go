@@ -0,0 +1,41 @@
+package responses
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+func MySessions(
+	c *gin.Context,
+	sessions []*models.SessionModel,
+	currentSessionUid string,
+) {
+	var data []gin.H
+
+	for _, session := range sessions {
+		data = append(data, extractMySessionData(session, currentSessionUid))
+	}
+	Basic(c, http.StatusOK, gin.H{"data": data})
+}
+
+func IncorrectSessionId(c *gin.Context, err error) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "incorrect session id format"})
+}
+
+func extractMySessionData(
+	session *models.SessionModel,
+	currentSessionUid string,
+) (extracted gin.H) {
+	return gin.H{
+		"uid":        session.UID.Hex(),
+		"ip":         session.IP,
+		"userAgent":  session.UserAgent,
+		"current":    session.UID.Hex() == currentSessionUid,
+		"lastUsedAt": session.LastUsedAt,
+		"expiresAt":  session.ExpiresAt,
+		"createdAt":  session.CreatedAt}
+}
@@ -0,0 +1,35 @@
+package responses
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TwoFactorEnrollment(c *gin.Context, secret string, uri string) {
+	Basic(c, http.StatusOK, gin.H{
+		"data": gin.H{
+			"secret": secret,
+			"uri":    uri}})
+}
+
+func TwoFactorRecoveryCodes(c *gin.Context, recoveryCodes []string) {
+	Basic(c, http.StatusOK, gin.H{
+		"data": gin.H{
+			"recoveryCodes": recoveryCodes}})
+}
+
+func TwoFactorAlreadyEnabled(c *gin.Context, err error) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "Two-factor authentication is already enabled."})
+}
+
+func TwoFactorNotEnabled(c *gin.Context, err error) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "Two-factor authentication is not enabled."})
+}
+
+func InvalidTwoFactorCode(c *gin.Context, err error) {
+	Basic(c, http.StatusBadRequest, gin.H{
+		"message": "Invalid two-factor authentication code."})
+}
@@ -13,6 +13,26 @@ func PublicUser(c *gin.Context, user *models.UserModel) {
 	Basic(c, http.StatusOK, gin.H{"data": data})
 }
 
+// Public user along with the author's published posts summary.
+func PublicUserProfile(
+	c *gin.Context,
+	user *models.UserModel,
+	publishedPostCount int64,
+	latestPosts []*models.PostModel,
+) {
+	var (
+		data      = extractPublicUserData(user)
+		postsData = []gin.H{}
+	)
+
+	for _, post := range latestPosts {
+		postsData = append(postsData, extractPublicPostData(post, nil))
+	}
+	data["publishedPostCount"] = publishedPostCount
+	data["latestPosts"] = postsData
+	Basic(c, http.StatusOK, gin.H{"data": data})
+}
+
 func AuthorizedUser(c *gin.Context, user *models.UserModel) {
 	data := extractAuthorizedUserData(user)
 	Basic(c, http.StatusOK, gin.H{"data": data})
@@ -46,25 +66,40 @@ func IncorrectUserId(c *gin.Context, err error) {
 		"message": "incorrect user id format"})
 }
 
+func LastSuperAdmin(c *gin.Context) {
+	Basic(c, http.StatusForbidden, gin.H{
+		"message": "the last superadmin can't be deleted"})
+}
+
 func extractPublicUserData(user *models.UserModel) gin.H {
 	return gin.H{
 		"uid":       user.UID.Hex(),
 		"username":  user.Username,
 		"email":     user.Email,
 		"firstName": user.FirstName,
-		"lastName":  user.LastName}
+		"lastName":  user.LastName,
+		"bio":       user.Bio,
+		"avatar":    user.Avatar,
+		"website":   user.Website,
+		"socials":   extractSocials(user.Socials)}
 }
 
 func extractAuthorizedUserData(user *models.UserModel) gin.H {
 	return gin.H{
-		"uid":       user.UID.Hex(),
-		"username":  user.Username,
-		"email":     user.Email,
-		"firstName": user.FirstName,
-		"lastName":  user.LastName,
-		"roles":     extractRoles(user.Roles),
-		"createdAt": user.CreatedAt,
-		"updatedAt": user.UpdatedAt}
+		"uid":        user.UID.Hex(),
+		"username":   user.Username,
+		"email":      user.Email,
+		"firstName":  user.FirstName,
+		"lastName":   user.LastName,
+		"bio":        user.Bio,
+		"avatar":     user.Avatar,
+		"website":    user.Website,
+		"socials":    extractSocials(user.Socials),
+		"roles":      extractRoles(user.Roles),
+		"verifiedAt": user.VerifiedAt,
+		"twoFactor":  user.TwoFactor.EnabledAt != nil,
+		"createdAt":  user.CreatedAt,
+		"updatedAt":  user.UpdatedAt}
 }
 
 func extractCommonAuthorData(user models.UserCommonModel) gin.H {
@@ -73,7 +108,20 @@ func extractCommonAuthorData(user models.UserCommonModel) gin.H {
 		"username":  user.Username,
 		"email":     user.Email,
 		"firstName": user.FirstName,
-		"lastName":  user.LastName}
+		"lastName":  user.LastName,
+		"avatar":    user.Avatar}
+}
+
+func extractSocials(socials []models.UserSocial) []gin.H {
+	var data = []gin.H{}
+
+	for _, social := range socials {
+		data = append(data, gin.H{
+			"network": social.Network,
+			"url":     social.URL})
+	}
+
+	return data
 }
 
 func extractRoles(roles []models.UserRole) []gin.H {
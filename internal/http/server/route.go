@@ -6,14 +6,21 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/http/audit"
+	auditHandler "github.com/misterabdul/goblog-server/internal/http/handlers/audits"
 	authenticationHandler "github.com/misterabdul/goblog-server/internal/http/handlers/authentications"
+	calendarHandler "github.com/misterabdul/goblog-server/internal/http/handlers/calendars"
 	categoryHandler "github.com/misterabdul/goblog-server/internal/http/handlers/categories"
 	commentHandler "github.com/misterabdul/goblog-server/internal/http/handlers/comments"
+	invitationHandler "github.com/misterabdul/goblog-server/internal/http/handlers/invitations"
 	meHandler "github.com/misterabdul/goblog-server/internal/http/handlers/me"
 	notificationHandler "github.com/misterabdul/goblog-server/internal/http/handlers/notifications"
 	otherHandler "github.com/misterabdul/goblog-server/internal/http/handlers/others"
 	pageHandler "github.com/misterabdul/goblog-server/internal/http/handlers/pages"
 	postHandler "github.com/misterabdul/goblog-server/internal/http/handlers/posts"
+	roleHandler "github.com/misterabdul/goblog-server/internal/http/handlers/roles"
+	seriesHandler "github.com/misterabdul/goblog-server/internal/http/handlers/series"
 	userHandler "github.com/misterabdul/goblog-server/internal/http/handlers/users"
 	authenticateMiddleware "github.com/misterabdul/goblog-server/internal/http/middlewares/authenticate"
 	authorizeMiddleware "github.com/misterabdul/goblog-server/internal/http/middlewares/authorize"
@@ -29,8 +36,13 @@ func InitRoutes(
 	maxCtxDuration time.Duration,
 ) {
 	svc := service.NewService(dbConn, queueClient)
+	authorize := func(permissions ...string) gin.HandlerFunc {
+		return authorizeMiddleware.Authorize(maxCtxDuration, svc, permissions...)
+	}
+	rejectImpersonation := authenticateMiddleware.RejectImpersonation()
 
 	server.NoRoute(otherHandler.NotFound())
+	server.GET("/.well-known/jwks.json", otherHandler.JSONWebKeySet())
 
 	api := server.Group("/api")
 	{
@@ -40,15 +52,20 @@ func InitRoutes(
 
 			v1.GET("/users", userHandler.GetPublicUsers(maxCtxDuration, svc))
 			v1.GET("/user/:user", userHandler.GetPublicUser(maxCtxDuration, svc))
+			v1.GET("/user/:user/posts", userHandler.GetPublicUserPosts(maxCtxDuration, svc))
 
 			v1.GET("/categories", categoryHandler.GetPublicCategories(maxCtxDuration, svc))
 			v1.GET("/category/:category", categoryHandler.GetPublicCategory(maxCtxDuration, svc))
 			v1.GET("/category/:category/posts", categoryHandler.GetPublicCategoryPosts(maxCtxDuration, svc))
 
+			v1.GET("/series", seriesHandler.GetPublicSeriesList(maxCtxDuration, svc))
+			v1.GET("/series/:series", seriesHandler.GetPublicSeries(maxCtxDuration, svc))
+
 			v1.GET("/posts", postHandler.GetPublicPosts(maxCtxDuration, svc))
 			v1.GET("/post/search", postHandler.SearchPublicPosts(maxCtxDuration, svc))
 			v1.GET("/post/:post", postHandler.GetPublicPost(maxCtxDuration, svc))
 			v1.GET("/post/:post/comments", commentHandler.GetPublicPostComments(maxCtxDuration, svc))
+			v1.GET("/post/:post/related", postHandler.GetPublicRelatedPosts(maxCtxDuration, svc))
 
 			v1.GET("/pages", pageHandler.GetPublicPages(maxCtxDuration, svc))
 			v1.GET("/page/search", pageHandler.SearchPublicPages(maxCtxDuration, svc))
@@ -61,7 +78,16 @@ func InitRoutes(
 			v1.POST("/comment/reply", commentHandler.CreatePublicCommentReply(maxCtxDuration, svc))
 
 			v1.POST("/signin", authenticationHandler.SignIn(maxCtxDuration, svc))
+			v1.POST("/signin/2fa", authenticationHandler.SignInTwoFactor(maxCtxDuration, svc))
 			v1.POST("/signup", authenticationHandler.SignUp(maxCtxDuration, svc))
+			v1.POST("/signup/verify", authenticationHandler.VerifyEmail(maxCtxDuration, svc))
+			v1.POST("/signup/resend", authenticationHandler.ResendEmailVerification(maxCtxDuration, svc))
+			v1.POST("/password/forgot", authenticationHandler.ForgotPassword(maxCtxDuration, svc))
+			v1.POST("/password/reset", authenticationHandler.ResetPassword(maxCtxDuration, svc))
+			v1.POST("/invitation/accept", invitationHandler.AcceptInvitation(maxCtxDuration, svc))
+			v1.GET("/oidc/providers", authenticationHandler.GetOidcProviders())
+			v1.GET("/oidc/:provider/authorize", authenticationHandler.AuthorizeOidc(maxCtxDuration, svc))
+			v1.POST("/oidc/:provider/callback", authenticationHandler.SignInOidc(maxCtxDuration, svc))
 
 			refresh := v1.Group("/refresh")
 			refresh.Use(authenticateMiddleware.AuthenticateRefresh(maxCtxDuration, svc))
@@ -71,130 +97,217 @@ func InitRoutes(
 			}
 
 			auth := v1.Group("/auth")
-			auth.Use(authenticateMiddleware.Authenticate(maxCtxDuration, svc))
+			auth.Use(
+				authenticateMiddleware.Authenticate(maxCtxDuration, svc),
+				audit.RecordImpersonation(maxCtxDuration, svc))
 			{
-				auth.GET("/me", meHandler.GetMe(maxCtxDuration, svc))
-
-				verifyPassword := auth.Group("/me")
-				verifyPassword.Use(authenticateMiddleware.VerifyPassword(maxCtxDuration, svc))
+				session := auth.Group("")
+				session.Use(authenticateMiddleware.RejectPersonalToken())
 				{
-					verifyPassword.PUT("/", meHandler.UpdateMe(maxCtxDuration, svc))
-					verifyPassword.PATCH("/", meHandler.UpdateMe(maxCtxDuration, svc))
-					verifyPassword.PUT("/password", meHandler.UpdateMePassword(maxCtxDuration, svc))
-					verifyPassword.PATCH("/password", meHandler.UpdateMePassword(maxCtxDuration, svc))
-				}
+					session.GET("/me", meHandler.GetMe(maxCtxDuration, svc))
+					session.POST("/me/2fa/confirm", meHandler.ConfirmTwoFactor(maxCtxDuration, svc))
+					session.GET("/me/sessions", meHandler.GetMySessions(maxCtxDuration, svc))
+					session.DELETE("/me/sessions", meHandler.DeleteMySessions(maxCtxDuration, svc))
+					session.DELETE("/me/session/:session", meHandler.DeleteMySession(maxCtxDuration, svc))
+					session.GET("/me/tokens", meHandler.GetMyPersonalTokens(maxCtxDuration, svc))
+					session.POST("/me/tokens", rejectImpersonation, meHandler.CreateMyPersonalToken(maxCtxDuration, svc))
+					session.DELETE("/me/token/:token", meHandler.DeleteMyPersonalToken(maxCtxDuration, svc))
+					session.POST("/me/export", meHandler.ExportMe(maxCtxDuration, svc))
+					session.GET("/me/exports", meHandler.GetMyDataExports(maxCtxDuration, svc))
+					session.GET("/me/export/:export/download", meHandler.DownloadMyDataExport(maxCtxDuration, svc))
+
+					verifyPassword := session.Group("/me")
+					verifyPassword.Use(
+						rejectImpersonation,
+						authenticateMiddleware.VerifyPassword(maxCtxDuration, svc))
+					{
+						verifyPassword.PUT("/", meHandler.UpdateMe(maxCtxDuration, svc))
+						verifyPassword.PATCH("/", meHandler.UpdateMe(maxCtxDuration, svc))
+						verifyPassword.DELETE("/", meHandler.DeleteMe(maxCtxDuration, svc))
+						verifyPassword.PUT("/password", meHandler.UpdateMePassword(maxCtxDuration, svc))
+						verifyPassword.PATCH("/password", meHandler.UpdateMePassword(maxCtxDuration, svc))
+						verifyPassword.POST("/2fa", meHandler.EnrollTwoFactor(maxCtxDuration, svc))
+						verifyPassword.DELETE("/2fa", meHandler.DisableTwoFactor(maxCtxDuration, svc))
+						verifyPassword.POST("/2fa/recovery-codes", meHandler.RegenerateTwoFactorRecoveryCodes(maxCtxDuration, svc))
+					}
 
-				auth.GET("/notifications", notificationHandler.GetNotifications(maxCtxDuration, svc))
-				auth.GET("/notifications/listen", notificationHandler.ServeListenedNotifications(maxCtxDuration, svc))
-				auth.GET("/notification/:notification", notificationHandler.GetNotification(maxCtxDuration, svc))
-				auth.PUT("/notification/:notification", notificationHandler.ReadNotification(maxCtxDuration, svc))
-				auth.PATCH("/notification/:notification", notificationHandler.ReadNotification(maxCtxDuration, svc))
-				auth.DELETE("/notification/:notification", notificationHandler.DeleteNotification(maxCtxDuration, svc))
+					session.GET("/notifications", notificationHandler.GetNotifications(maxCtxDuration, svc))
+					session.GET("/notifications/listen", notificationHandler.ServeListenedNotifications(maxCtxDuration, svc))
+					session.GET("/notification/:notification", notificationHandler.GetNotification(maxCtxDuration, svc))
+					session.PUT("/notification/:notification", notificationHandler.ReadNotification(maxCtxDuration, svc))
+					session.PATCH("/notification/:notification", notificationHandler.ReadNotification(maxCtxDuration, svc))
+					session.DELETE("/notification/:notification", notificationHandler.DeleteNotification(maxCtxDuration, svc))
+				}
 
 				writer := auth.Group("/writer")
-				writer.Use(authorizeMiddleware.Authorize(maxCtxDuration, svc, "Writer"))
 				{
-					writer.GET("/posts", postHandler.GetMyPosts(maxCtxDuration, svc))
-					writer.GET("/posts/stats", postHandler.GetMyPostsStats(maxCtxDuration, svc))
-					writer.GET("/post/:post", postHandler.GetMyPost(maxCtxDuration, svc))
-					writer.POST("/post", postHandler.CreatePost(maxCtxDuration, svc))
-					writer.PUT("/post/:post", postHandler.UpdateMyPost(maxCtxDuration, svc))
-					writer.PATCH("/post/:post", postHandler.UpdateMyPost(maxCtxDuration, svc))
-					writer.DELETE("/post/:post", postHandler.TrashMyPost(maxCtxDuration, svc))
-					writer.PUT("/post/:post/detrash", postHandler.DetrashMyPost(maxCtxDuration, svc))
-					writer.PATCH("/post/:post/detrash", postHandler.DetrashMyPost(maxCtxDuration, svc))
-					writer.DELETE("/post/:post/permanent", postHandler.DeleteMyPost(maxCtxDuration, svc))
-					writer.PUT("/post/:post/publish", postHandler.PublishMyPost(maxCtxDuration, svc))
-					writer.PATCH("/post/:post/publish", postHandler.PublishMyPost(maxCtxDuration, svc))
-					writer.PUT("/post/:post/depublish", postHandler.DepublishMyPost(maxCtxDuration, svc))
-					writer.PATCH("/post/:post/depublish", postHandler.DepublishMyPost(maxCtxDuration, svc))
-					writer.GET("/post/:post/comments", commentHandler.GetMyPostComments(maxCtxDuration, svc))
-					writer.GET("/post/:post/comments/stats", commentHandler.GetMyPostCommentsStats(maxCtxDuration, svc))
-
-					writer.GET("/comments", commentHandler.GetMyComments(maxCtxDuration, svc))
-					writer.GET("/comments/stats", commentHandler.GetMyCommentsStats(maxCtxDuration, svc))
-					writer.GET("/comment/:comment", commentHandler.GetMyComment(maxCtxDuration, svc))
-					writer.DELETE("/comment/:comment", commentHandler.TrashMyComment(maxCtxDuration, svc))
-					writer.PUT("/comment/:comment/detrash", commentHandler.DetrashMyComment(maxCtxDuration, svc))
-					writer.PATCH("/comment/:comment/detrash", commentHandler.DetrashMyComment(maxCtxDuration, svc))
-					writer.DELETE("/comment/:comment/permanent", commentHandler.DeleteMyComment(maxCtxDuration, svc))
+					writer.GET("/posts", authorize(models.PermissionPostReadOwn), postHandler.GetMyPosts(maxCtxDuration, svc))
+					writer.GET("/posts/stats", authorize(models.PermissionPostReadOwn), postHandler.GetMyPostsStats(maxCtxDuration, svc))
+					writer.GET("/post/:post", authorize(models.PermissionPostReadOwn), postHandler.GetMyPost(maxCtxDuration, svc))
+					writer.POST("/post", authorize(models.PermissionPostCreate), postHandler.CreatePost(maxCtxDuration, svc))
+					writer.PUT("/post/:post", authorize(models.PermissionPostUpdateOwn), postHandler.UpdateMyPost(maxCtxDuration, svc))
+					writer.PATCH("/post/:post", authorize(models.PermissionPostUpdateOwn), postHandler.UpdateMyPost(maxCtxDuration, svc))
+					writer.DELETE("/post/:post", authorize(models.PermissionPostTrashOwn), postHandler.TrashMyPost(maxCtxDuration, svc))
+					writer.PUT("/post/:post/detrash", authorize(models.PermissionPostTrashOwn), postHandler.DetrashMyPost(maxCtxDuration, svc))
+					writer.PATCH("/post/:post/detrash", authorize(models.PermissionPostTrashOwn), postHandler.DetrashMyPost(maxCtxDuration, svc))
+					writer.DELETE("/post/:post/permanent", rejectImpersonation, authorize(models.PermissionPostDeleteOwn), postHandler.DeleteMyPost(maxCtxDuration, svc))
+					writer.PUT("/post/:post/publish", authorize(models.PermissionPostPublishOwn), postHandler.PublishMyPost(maxCtxDuration, svc))
+					writer.PATCH("/post/:post/publish", authorize(models.PermissionPostPublishOwn), postHandler.PublishMyPost(maxCtxDuration, svc))
+					writer.PUT("/post/:post/depublish", authorize(models.PermissionPostPublishOwn), postHandler.DepublishMyPost(maxCtxDuration, svc))
+					writer.PATCH("/post/:post/depublish", authorize(models.PermissionPostPublishOwn), postHandler.DepublishMyPost(maxCtxDuration, svc))
+					writer.PUT("/post/:post/schedule", authorize(models.PermissionPostPublishOwn), postHandler.ScheduleMyPost(maxCtxDuration, svc))
+					writer.PATCH("/post/:post/schedule", authorize(models.PermissionPostPublishOwn), postHandler.ScheduleMyPost(maxCtxDuration, svc))
+					writer.DELETE("/post/:post/schedule", authorize(models.PermissionPostPublishOwn), postHandler.UnscheduleMyPost(maxCtxDuration, svc))
+					writer.PUT("/post/:post/submit", authorize(models.PermissionPostUpdateOwn), postHandler.SubmitMyPost(maxCtxDuration, svc))
+					writer.PATCH("/post/:post/submit", authorize(models.PermissionPostUpdateOwn), postHandler.SubmitMyPost(maxCtxDuration, svc))
+					writer.PUT("/post/:post/contributors", authorize(models.PermissionPostUpdateOwn), postHandler.UpdateMyPostContributors(maxCtxDuration, svc))
+					writer.PATCH("/post/:post/contributors", authorize(models.PermissionPostUpdateOwn), postHandler.UpdateMyPostContributors(maxCtxDuration, svc))
+					writer.PUT("/post/:post/series", authorize(models.PermissionPostUpdateOwn), postHandler.AttachMyPostSeries(maxCtxDuration, svc))
+					writer.PATCH("/post/:post/series", authorize(models.PermissionPostUpdateOwn), postHandler.AttachMyPostSeries(maxCtxDuration, svc))
+					writer.DELETE("/post/:post/series", authorize(models.PermissionPostUpdateOwn), postHandler.DetachMyPostSeries(maxCtxDuration, svc))
+					writer.GET("/post/:post/comments", authorize(models.PermissionCommentReadOwn), commentHandler.GetMyPostComments(maxCtxDuration, svc))
+					writer.GET("/post/:post/comments/stats", authorize(models.PermissionCommentReadOwn), commentHandler.GetMyPostCommentsStats(maxCtxDuration, svc))
+					writer.GET("/post/:post/revisions", authorize(models.PermissionPostReadOwn), postHandler.GetMyPostRevisions(maxCtxDuration, svc))
+					writer.GET("/post/:post/revisions/diff", authorize(models.PermissionPostReadOwn), postHandler.DiffMyPostRevisions(maxCtxDuration, svc))
+					writer.GET("/post/:post/revision/:revision", authorize(models.PermissionPostReadOwn), postHandler.GetMyPostRevision(maxCtxDuration, svc))
+					writer.PUT("/post/:post/revision/:revision/restore", authorize(models.PermissionPostUpdateOwn), postHandler.RestoreMyPostRevision(maxCtxDuration, svc))
+					writer.PATCH("/post/:post/revision/:revision/restore", authorize(models.PermissionPostUpdateOwn), postHandler.RestoreMyPostRevision(maxCtxDuration, svc))
+
+					writer.GET("/comments", authorize(models.PermissionCommentReadOwn), commentHandler.GetMyComments(maxCtxDuration, svc))
+					writer.GET("/comments/stats", authorize(models.PermissionCommentReadOwn), commentHandler.GetMyCommentsStats(maxCtxDuration, svc))
+					writer.GET("/comment/:comment", authorize(models.PermissionCommentReadOwn), commentHandler.GetMyComment(maxCtxDuration, svc))
+					writer.DELETE("/comment/:comment", authorize(models.PermissionCommentTrashOwn), commentHandler.TrashMyComment(maxCtxDuration, svc))
+					writer.PUT("/comment/:comment/detrash", authorize(models.PermissionCommentTrashOwn), commentHandler.DetrashMyComment(maxCtxDuration, svc))
+					writer.PATCH("/comment/:comment/detrash", authorize(models.PermissionCommentTrashOwn), commentHandler.DetrashMyComment(maxCtxDuration, svc))
+					writer.DELETE("/comment/:comment/permanent", rejectImpersonation, authorize(models.PermissionCommentDeleteOwn), commentHandler.DeleteMyComment(maxCtxDuration, svc))
 				}
 
 				editor := auth.Group("/editor")
-				editor.Use(authorizeMiddleware.Authorize(maxCtxDuration, svc, "Editor"))
 				{
-					editor.GET("/categories", categoryHandler.GetCategories(maxCtxDuration, svc))
-					editor.GET("/categories/stats", categoryHandler.GetCategoriesStats(maxCtxDuration, svc))
-					editor.GET("/category/:category", categoryHandler.GetCategory(maxCtxDuration, svc))
-					editor.POST("/category", categoryHandler.CreateCategory(maxCtxDuration, svc))
-					editor.PUT("/category/:category", categoryHandler.UpdateCategory(maxCtxDuration, svc))
-					editor.PATCH("/category/:category", categoryHandler.UpdateCategory(maxCtxDuration, svc))
-					editor.PUT("/category/:category/detrash", categoryHandler.DetrashCategory(maxCtxDuration, svc))
-					editor.PATCH("/category/:category/detrash", categoryHandler.DetrashCategory(maxCtxDuration, svc))
-					editor.DELETE("/category/:category", categoryHandler.TrashCategory(maxCtxDuration, svc))
-					editor.DELETE("/category/:category/permanent", categoryHandler.DeleteCategory(maxCtxDuration, svc))
-
-					editor.GET("/posts", postHandler.GetPosts(maxCtxDuration, svc))
-					editor.GET("/posts/stats", postHandler.GetPostsStats(maxCtxDuration, svc))
-					editor.GET("/post/:post", postHandler.GetPost(maxCtxDuration, svc))
-					editor.POST("/post", postHandler.CreatePost(maxCtxDuration, svc))
-					editor.PUT("/post/:post", postHandler.UpdatePost(maxCtxDuration, svc))
-					editor.PATCH("/post/:post", postHandler.UpdatePost(maxCtxDuration, svc))
-					editor.DELETE("/post/:post", postHandler.TrashPost(maxCtxDuration, svc))
-					editor.DELETE("/post/:post/permanent", postHandler.DeletePost(maxCtxDuration, svc))
-					editor.PUT("/post/:post/publish", postHandler.PublishPost(maxCtxDuration, svc))
-					editor.PATCH("/post/:post/publish", postHandler.PublishPost(maxCtxDuration, svc))
-					editor.PUT("/post/:post/depublish", postHandler.DepublishPost(maxCtxDuration, svc))
-					editor.PATCH("/post/:post/depublish", postHandler.DepublishPost(maxCtxDuration, svc))
-					editor.GET("/post/:post/comments", commentHandler.GetPostComments(maxCtxDuration, svc))
-					editor.GET("/post/:post/comments/stats", commentHandler.GetPostCommentsStats(maxCtxDuration, svc))
-
-					editor.GET("/comments", commentHandler.GetComments(maxCtxDuration, svc))
-					editor.GET("/comments/stats", commentHandler.GetCommentsStats(maxCtxDuration, svc))
-					editor.GET("/comment/:comment", commentHandler.GetComment(maxCtxDuration, svc))
-					editor.DELETE("/comment/:comment", commentHandler.TrashComment(maxCtxDuration, svc))
-					editor.PUT("/comment/:comment/detrash", commentHandler.DetrashComment(maxCtxDuration, svc))
-					editor.PATCH("/comment/:comment/detrash", commentHandler.DetrashComment(maxCtxDuration, svc))
-					editor.DELETE("/comment/:comment/permanent", commentHandler.DeleteComment(maxCtxDuration, svc))
-
-					editor.GET("/pages", pageHandler.GetPages(maxCtxDuration, svc))
-					editor.GET("/pages/stats", pageHandler.GetPagesStats(maxCtxDuration, svc))
-					editor.GET("/page/:page", pageHandler.GetPage(maxCtxDuration, svc))
-					editor.POST("/page", pageHandler.CreatePage(maxCtxDuration, svc))
-					editor.PUT("/page/:page", pageHandler.UpdatePage(maxCtxDuration, svc))
-					editor.PATCH("/page/:page", pageHandler.UpdatePage(maxCtxDuration, svc))
-					editor.DELETE("/page/:page", pageHandler.TrashPage(maxCtxDuration, svc))
-					editor.PUT("/page/:page/publish", pageHandler.PublishPage(maxCtxDuration, svc))
-					editor.PATCH("/page/:page/publish", pageHandler.PublishPage(maxCtxDuration, svc))
-					editor.PUT("/page/:page/depublish", pageHandler.DepublishPage(maxCtxDuration, svc))
-					editor.PATCH("/page/:page/depublish", pageHandler.DepublishPage(maxCtxDuration, svc))
-					editor.PUT("/page/:page/detrash", pageHandler.DetrashPage(maxCtxDuration, svc))
-					editor.PATCH("/page/:page/detrash", pageHandler.DetrashPage(maxCtxDuration, svc))
-					editor.DELETE("/page/:page/permanent", pageHandler.DeletePage(maxCtxDuration, svc))
+					editor.GET("/categories", authorize(models.PermissionCategoryRead), categoryHandler.GetCategories(maxCtxDuration, svc))
+					editor.GET("/categories/stats", authorize(models.PermissionCategoryRead), categoryHandler.GetCategoriesStats(maxCtxDuration, svc))
+					editor.GET("/category/:category", authorize(models.PermissionCategoryRead), categoryHandler.GetCategory(maxCtxDuration, svc))
+					editor.POST("/category", authorize(models.PermissionCategoryCreate), categoryHandler.CreateCategory(maxCtxDuration, svc))
+					editor.PUT("/category/:category", authorize(models.PermissionCategoryUpdate), categoryHandler.UpdateCategory(maxCtxDuration, svc))
+					editor.PATCH("/category/:category", authorize(models.PermissionCategoryUpdate), categoryHandler.UpdateCategory(maxCtxDuration, svc))
+					editor.PUT("/category/:category/detrash", authorize(models.PermissionCategoryTrash), categoryHandler.DetrashCategory(maxCtxDuration, svc))
+					editor.PATCH("/category/:category/detrash", authorize(models.PermissionCategoryTrash), categoryHandler.DetrashCategory(maxCtxDuration, svc))
+					editor.DELETE("/category/:category", authorize(models.PermissionCategoryTrash), categoryHandler.TrashCategory(maxCtxDuration, svc))
+					editor.DELETE("/category/:category/permanent", rejectImpersonation, authorize(models.PermissionCategoryDelete), categoryHandler.DeleteCategory(maxCtxDuration, svc))
+
+					editor.GET("/series", authorize(models.PermissionSeriesRead), seriesHandler.GetSeriesList(maxCtxDuration, svc))
+					editor.GET("/series/stats", authorize(models.PermissionSeriesRead), seriesHandler.GetSeriesListStats(maxCtxDuration, svc))
+					editor.GET("/series/:series", authorize(models.PermissionSeriesRead), seriesHandler.GetSeries(maxCtxDuration, svc))
+					editor.POST("/series", authorize(models.PermissionSeriesCreate), seriesHandler.CreateSeries(maxCtxDuration, svc))
+					editor.PUT("/series/:series", authorize(models.PermissionSeriesUpdate), seriesHandler.UpdateSeries(maxCtxDuration, svc))
+					editor.PATCH("/series/:series", authorize(models.PermissionSeriesUpdate), seriesHandler.UpdateSeries(maxCtxDuration, svc))
+					editor.PUT("/series/:series/detrash", authorize(models.PermissionSeriesTrash), seriesHandler.DetrashSeries(maxCtxDuration, svc))
+					editor.PATCH("/series/:series/detrash", authorize(models.PermissionSeriesTrash), seriesHandler.DetrashSeries(maxCtxDuration, svc))
+					editor.DELETE("/series/:series", authorize(models.PermissionSeriesTrash), seriesHandler.TrashSeries(maxCtxDuration, svc))
+					editor.DELETE("/series/:series/permanent", rejectImpersonation, authorize(models.PermissionSeriesDelete), seriesHandler.DeleteSeries(maxCtxDuration, svc))
+
+					editor.GET("/posts", authorize(models.PermissionPostReadAny), postHandler.GetPosts(maxCtxDuration, svc))
+					editor.GET("/posts/stats", authorize(models.PermissionPostReadAny), postHandler.GetPostsStats(maxCtxDuration, svc))
+					editor.GET("/post/:post", authorize(models.PermissionPostReadAny), postHandler.GetPost(maxCtxDuration, svc))
+					editor.POST("/post", authorize(models.PermissionPostCreate), postHandler.CreatePost(maxCtxDuration, svc))
+					editor.PUT("/post/:post", authorize(models.PermissionPostUpdateAny), postHandler.UpdatePost(maxCtxDuration, svc))
+					editor.PATCH("/post/:post", authorize(models.PermissionPostUpdateAny), postHandler.UpdatePost(maxCtxDuration, svc))
+					editor.DELETE("/post/:post", authorize(models.PermissionPostTrashAny), postHandler.TrashPost(maxCtxDuration, svc))
+					editor.DELETE("/post/:post/permanent", rejectImpersonation, authorize(models.PermissionPostDeleteAny), postHandler.DeletePost(maxCtxDuration, svc))
+					editor.PUT("/post/:post/publish", authorize(models.PermissionPostPublishAny), postHandler.PublishPost(maxCtxDuration, svc))
+					editor.PATCH("/post/:post/publish", authorize(models.PermissionPostPublishAny), postHandler.PublishPost(maxCtxDuration, svc))
+					editor.PUT("/post/:post/depublish", authorize(models.PermissionPostPublishAny), postHandler.DepublishPost(maxCtxDuration, svc))
+					editor.PATCH("/post/:post/depublish", authorize(models.PermissionPostPublishAny), postHandler.DepublishPost(maxCtxDuration, svc))
+					editor.PUT("/post/:post/schedule", authorize(models.PermissionPostPublishAny), postHandler.SchedulePost(maxCtxDuration, svc))
+					editor.PATCH("/post/:post/schedule", authorize(models.PermissionPostPublishAny), postHandler.SchedulePost(maxCtxDuration, svc))
+					editor.DELETE("/post/:post/schedule", authorize(models.PermissionPostPublishAny), postHandler.UnschedulePost(maxCtxDuration, svc))
+					editor.PUT("/post/:post/approve", authorize(models.PermissionPostReview), postHandler.ApprovePost(maxCtxDuration, svc))
+					editor.PATCH("/post/:post/approve", authorize(models.PermissionPostReview), postHandler.ApprovePost(maxCtxDuration, svc))
+					editor.PUT("/post/:post/request-changes", authorize(models.PermissionPostReview), postHandler.RequestPostChanges(maxCtxDuration, svc))
+					editor.PATCH("/post/:post/request-changes", authorize(models.PermissionPostReview), postHandler.RequestPostChanges(maxCtxDuration, svc))
+					editor.PUT("/post/:post/contributors", authorize(models.PermissionPostUpdateAny), postHandler.UpdatePostContributors(maxCtxDuration, svc))
+					editor.PATCH("/post/:post/contributors", authorize(models.PermissionPostUpdateAny), postHandler.UpdatePostContributors(maxCtxDuration, svc))
+					editor.GET("/post/:post/comments", authorize(models.PermissionCommentReadAny), commentHandler.GetPostComments(maxCtxDuration, svc))
+					editor.GET("/post/:post/comments/stats", authorize(models.PermissionCommentReadAny), commentHandler.GetPostCommentsStats(maxCtxDuration, svc))
+					editor.GET("/post/:post/revisions", authorize(models.PermissionPostReadAny), postHandler.GetPostRevisions(maxCtxDuration, svc))
+					editor.GET("/post/:post/revisions/diff", authorize(models.PermissionPostReadAny), postHandler.DiffPostRevisions(maxCtxDuration, svc))
+					editor.GET("/post/:post/revision/:revision", authorize(models.PermissionPostReadAny), postHandler.GetPostRevision(maxCtxDuration, svc))
+					editor.PUT("/post/:post/revision/:revision/restore", authorize(models.PermissionPostUpdateAny), postHandler.RestorePostRevision(maxCtxDuration, svc))
+					editor.PATCH("/post/:post/revision/:revision/restore", authorize(models.PermissionPostUpdateAny), postHandler.RestorePostRevision(maxCtxDuration, svc))
+
+					editor.GET("/comments", authorize(models.PermissionCommentReadAny), commentHandler.GetComments(maxCtxDuration, svc))
+					editor.GET("/comments/stats", authorize(models.PermissionCommentReadAny), commentHandler.GetCommentsStats(maxCtxDuration, svc))
+					editor.GET("/comment/:comment", authorize(models.PermissionCommentReadAny), commentHandler.GetComment(maxCtxDuration, svc))
+					editor.DELETE("/comment/:comment", authorize(models.PermissionCommentTrashAny), commentHandler.TrashComment(maxCtxDuration, svc))
+					editor.PUT("/comment/:comment/detrash", authorize(models.PermissionCommentTrashAny), commentHandler.DetrashComment(maxCtxDuration, svc))
+					editor.PATCH("/comment/:comment/detrash", authorize(models.PermissionCommentTrashAny), commentHandler.DetrashComment(maxCtxDuration, svc))
+					editor.DELETE("/comment/:comment/permanent", rejectImpersonation, authorize(models.PermissionCommentDeleteAny), commentHandler.DeleteComment(maxCtxDuration, svc))
+
+					editor.GET("/pages", authorize(models.PermissionPageRead), pageHandler.GetPages(maxCtxDuration, svc))
+					editor.GET("/pages/stats", authorize(models.PermissionPageRead), pageHandler.GetPagesStats(maxCtxDuration, svc))
+					editor.GET("/page/:page", authorize(models.PermissionPageRead), pageHandler.GetPage(maxCtxDuration, svc))
+					editor.POST("/page", authorize(models.PermissionPageCreate), pageHandler.CreatePage(maxCtxDuration, svc))
+					editor.PUT("/page/:page", authorize(models.PermissionPageUpdate), pageHandler.UpdatePage(maxCtxDuration, svc))
+					editor.PATCH("/page/:page", authorize(models.PermissionPageUpdate), pageHandler.UpdatePage(maxCtxDuration, svc))
+					editor.DELETE("/page/:page", authorize(models.PermissionPageTrash), pageHandler.TrashPage(maxCtxDuration, svc))
+					editor.PUT("/page/:page/publish", authorize(models.PermissionPagePublish), pageHandler.PublishPage(maxCtxDuration, svc))
+					editor.PATCH("/page/:page/publish", authorize(models.PermissionPagePublish), pageHandler.PublishPage(maxCtxDuration, svc))
+					editor.PUT("/page/:page/depublish", authorize(models.PermissionPagePublish), pageHandler.DepublishPage(maxCtxDuration, svc))
+					editor.PATCH("/page/:page/depublish", authorize(models.PermissionPagePublish), pageHandler.DepublishPage(maxCtxDuration, svc))
+					editor.PUT("/page/:page/schedule", authorize(models.PermissionPagePublish), pageHandler.SchedulePage(maxCtxDuration, svc))
+					editor.PATCH("/page/:page/schedule", authorize(models.PermissionPagePublish), pageHandler.SchedulePage(maxCtxDuration, svc))
+					editor.DELETE("/page/:page/schedule", authorize(models.PermissionPagePublish), pageHandler.UnschedulePage(maxCtxDuration, svc))
+					editor.PUT("/page/:page/detrash", authorize(models.PermissionPageTrash), pageHandler.DetrashPage(maxCtxDuration, svc))
+					editor.PATCH("/page/:page/detrash", authorize(models.PermissionPageTrash), pageHandler.DetrashPage(maxCtxDuration, svc))
+					editor.DELETE("/page/:page/permanent", rejectImpersonation, authorize(models.PermissionPageDelete), pageHandler.DeletePage(maxCtxDuration, svc))
+					editor.GET("/page/:page/revisions", authorize(models.PermissionPageRead), pageHandler.GetPageRevisions(maxCtxDuration, svc))
+					editor.GET("/page/:page/revisions/diff", authorize(models.PermissionPageRead), pageHandler.DiffPageRevisions(maxCtxDuration, svc))
+					editor.GET("/page/:page/revision/:revision", authorize(models.PermissionPageRead), pageHandler.GetPageRevision(maxCtxDuration, svc))
+					editor.PUT("/page/:page/revision/:revision/restore", authorize(models.PermissionPageUpdate), pageHandler.RestorePageRevision(maxCtxDuration, svc))
+					editor.PATCH("/page/:page/revision/:revision/restore", authorize(models.PermissionPageUpdate), pageHandler.RestorePageRevision(maxCtxDuration, svc))
+
+					editor.GET("/calendar", authorize(models.PermissionPostReadAny, models.PermissionPageRead), calendarHandler.GetEditorialCalendar(maxCtxDuration, svc))
 				}
 
 				admin := auth.Group("/admin")
-				admin.Use(authorizeMiddleware.Authorize(maxCtxDuration, svc, "Admin"))
 				{
-					admin.GET("/users", userHandler.GetUsers(maxCtxDuration, svc))
-					admin.GET("/users/stats", userHandler.GetUsersStats(maxCtxDuration, svc))
-					admin.GET("/user/:user", userHandler.GetUser(maxCtxDuration, svc))
-					admin.POST("/user", userHandler.CreateUser(maxCtxDuration, svc))
-					admin.PUT("/user/:user", userHandler.UpdateUser(maxCtxDuration, svc))
-					admin.PATCH("/user/:user", userHandler.UpdateUser(maxCtxDuration, svc))
-					admin.DELETE("/user/:user", userHandler.TrashUser(maxCtxDuration, svc))
-					admin.PUT("/user/:user/detrash", userHandler.DetrashUser(maxCtxDuration, svc))
-					admin.PATCH("/user/:user/detrash", userHandler.DetrashUser(maxCtxDuration, svc))
+					admin.GET("/users", authorize(models.PermissionUserRead), userHandler.GetUsers(maxCtxDuration, svc))
+					admin.GET("/users/stats", authorize(models.PermissionUserRead), userHandler.GetUsersStats(maxCtxDuration, svc))
+					admin.GET("/user/:user", authorize(models.PermissionUserRead), userHandler.GetUser(maxCtxDuration, svc))
+					admin.POST("/user", authorize(models.PermissionUserCreate), userHandler.CreateUser(maxCtxDuration, svc))
+					admin.PUT("/user/:user", authorize(models.PermissionUserUpdate), userHandler.UpdateUser(maxCtxDuration, svc))
+					admin.PATCH("/user/:user", authorize(models.PermissionUserUpdate), userHandler.UpdateUser(maxCtxDuration, svc))
+					admin.DELETE("/user/:user", authorize(models.PermissionUserTrash), userHandler.TrashUser(maxCtxDuration, svc))
+					admin.PUT("/user/:user/detrash", authorize(models.PermissionUserTrash), userHandler.DetrashUser(maxCtxDuration, svc))
+					admin.PATCH("/user/:user/detrash", authorize(models.PermissionUserTrash), userHandler.DetrashUser(maxCtxDuration, svc))
+					admin.DELETE("/user/:user/2fa", authorize(models.PermissionUserResetTwoFactor), userHandler.ResetUserTwoFactor(maxCtxDuration, svc))
+					admin.DELETE("/user/:user/sessions", authorize(models.PermissionUserRevokeSessions), userHandler.DeleteUserSessions(maxCtxDuration, svc))
+					admin.GET("/invitations", authorize(models.PermissionUserRead), invitationHandler.GetInvitations(maxCtxDuration, svc))
+					admin.GET("/invitations/stats", authorize(models.PermissionUserRead), invitationHandler.GetInvitationsStats(maxCtxDuration, svc))
+					admin.POST("/invitation", authorize(models.PermissionUserCreate), invitationHandler.CreateInvitation(maxCtxDuration, svc))
+					admin.POST("/invitation/:invitation/resend", authorize(models.PermissionUserCreate), invitationHandler.ResendInvitation(maxCtxDuration, svc))
+					admin.DELETE("/invitation/:invitation", authorize(models.PermissionUserCreate), invitationHandler.RevokeInvitation(maxCtxDuration, svc))
+					admin.GET("/lockouts", authorize(models.PermissionLockoutRead), authenticationHandler.GetLockouts(maxCtxDuration, svc))
+					admin.DELETE("/lockout/:lockout", authorize(models.PermissionLockoutDelete), authenticationHandler.DeleteLockout(maxCtxDuration, svc))
 				}
 
 				superadmin := auth.Group("/superadmin")
-				superadmin.Use(authorizeMiddleware.Authorize(maxCtxDuration, svc, "SuperAdmin"))
 				{
-					superadmin.PUT("/adminize/:user", userHandler.AdminizeUser(maxCtxDuration, svc))
-					superadmin.PATCH("/adminize/:user", userHandler.AdminizeUser(maxCtxDuration, svc))
-					superadmin.PUT("/deadminize/:user", userHandler.DeadminizeUser(maxCtxDuration, svc))
-					superadmin.PATCH("/deadminize/:user", userHandler.DeadminizeUser(maxCtxDuration, svc))
-					superadmin.DELETE("/user/:user/permanent", userHandler.DeleteUser(maxCtxDuration, svc))
+					superadmin.PUT("/adminize/:user", authorize(models.PermissionUserAdminize), userHandler.AdminizeUser(maxCtxDuration, svc))
+					superadmin.PATCH("/adminize/:user", authorize(models.PermissionUserAdminize), userHandler.AdminizeUser(maxCtxDuration, svc))
+					superadmin.PUT("/deadminize/:user", authorize(models.PermissionUserAdminize), userHandler.DeadminizeUser(maxCtxDuration, svc))
+					superadmin.PATCH("/deadminize/:user", authorize(models.PermissionUserAdminize), userHandler.DeadminizeUser(maxCtxDuration, svc))
+					superadmin.DELETE("/user/:user/permanent", rejectImpersonation, authorize(models.PermissionUserDelete), userHandler.DeleteUser(maxCtxDuration, svc))
+					superadmin.POST("/impersonate/:user", rejectImpersonation, authorize(models.PermissionUserImpersonate), userHandler.ImpersonateUser(maxCtxDuration, svc))
+					superadmin.GET("/audit-logs", authorize(models.PermissionAuditRead), auditHandler.GetAuditLogs(maxCtxDuration, svc))
+					superadmin.GET("/audit-logs/stats", authorize(models.PermissionAuditRead), auditHandler.GetAuditLogsStats(maxCtxDuration, svc))
+					superadmin.GET("/permissions", authorize(models.PermissionRoleRead), roleHandler.GetPermissions())
+					superadmin.GET("/roles", authorize(models.PermissionRoleRead), roleHandler.GetRoles(maxCtxDuration, svc))
+					superadmin.GET("/role/:role", authorize(models.PermissionRoleRead), roleHandler.GetRole(maxCtxDuration, svc))
+					superadmin.POST("/role", authorize(models.PermissionRoleManage), roleHandler.CreateRole(maxCtxDuration, svc))
+					superadmin.PUT("/role/:role", authorize(models.PermissionRoleManage), roleHandler.UpdateRole(maxCtxDuration, svc))
+					superadmin.PATCH("/role/:role", authorize(models.PermissionRoleManage), roleHandler.UpdateRole(maxCtxDuration, svc))
+					superadmin.DELETE("/role/:role", authorize(models.PermissionRoleManage), roleHandler.DeleteRole(maxCtxDuration, svc))
 				}
 			}
 		}
@@ -8,6 +8,12 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+const (
+	ContentFormatMarkdown = "markdown"
+	ContentFormatHTML     = "html"
+	ContentFormatBoth     = "both"
+)
+
 func GetFindOptions(c *gin.Context) (option *options.FindOptions) {
 	var (
 		show  = GetShowQuery(c)
@@ -121,3 +127,12 @@ func GetAscQuery(c *gin.Context) (asc int) {
 
 	return -1
 }
+
+func GetContentFormatQuery(c *gin.Context) (format string) {
+	switch format = c.DefaultQuery("format", ContentFormatMarkdown); format {
+	case ContentFormatMarkdown, ContentFormatHTML, ContentFormatBoth:
+		return format
+	}
+
+	return ContentFormatMarkdown
+}
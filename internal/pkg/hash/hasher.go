@@ -0,0 +1,83 @@
+package hash
+
+import (
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/misterabdul/goblog-server/pkg/hash"
+)
+
+var (
+	hasher     hash.Hasher
+	hasherOnce sync.Once
+)
+
+// Get the password hasher configured from the environment,
+// loaded only once.
+//
+// Available algorithms: argon2id (default) & bcrypt.
+// Passwords hashed with any other algorithm or parameters stay valid,
+// they're rehashed on the next successful sign in.
+func GetHasher() (_hasher hash.Hasher) {
+	hasherOnce.Do(func() {
+		hasher = loadHasher()
+	})
+
+	return hasher
+}
+
+// Create hashed string from given password string.
+func Make(password string) (hashedPassword string, err error) {
+	return GetHasher().Make(password)
+}
+
+// Check the given password against the hashed string.
+func Check(password string, hashedPassword string) (match bool) {
+	return hash.Check(password, hashedPassword)
+}
+
+// Check whether the hashed password is outdated.
+func NeedsRehash(hashedPassword string) (needed bool) {
+	return GetHasher().NeedsRehash(hashedPassword)
+}
+
+func loadHasher() (_hasher hash.Hasher) {
+	var (
+		algorithm string
+		ok        bool
+	)
+
+	if algorithm, ok = os.LookupEnv("PASSWORD_HASH_ALGORITHM"); !ok || algorithm == "" {
+		algorithm = "argon2id"
+	}
+	switch {
+	case algorithm == "bcrypt":
+		return hash.NewBcrypt(
+			getEnvInt("BCRYPT_COST", hash.DefaultBcryptCost))
+	default:
+		return hash.NewArgon2id(hash.Argon2idParams{
+			Memory:     uint32(getEnvInt("ARGON2ID_MEMORY", int(hash.DefaultArgon2idParams.Memory))),
+			Time:       uint32(getEnvInt("ARGON2ID_TIME", int(hash.DefaultArgon2idParams.Time))),
+			Threads:    uint8(getEnvInt("ARGON2ID_THREADS", int(hash.DefaultArgon2idParams.Threads))),
+			SaltLength: hash.DefaultArgon2idParams.SaltLength,
+			KeyLength:  hash.DefaultArgon2idParams.KeyLength})
+	}
+}
+
+func getEnvInt(name string, defaultValue int) (value int) {
+	var (
+		value_s string
+		ok      bool
+		err     error
+	)
+
+	if value_s, ok = os.LookupEnv(name); !ok {
+		return defaultValue
+	}
+	if value, err = strconv.Atoi(value_s); err != nil {
+		return defaultValue
+	}
+
+	return value
+}
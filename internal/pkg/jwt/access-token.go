@@ -12,20 +12,21 @@ import (
 
 const accessTokenTypeName = "access-token"
 
-func IssueAccessToken(user *models.UserModel) (
+// Issue new access token for the session of given refresh token family.
+func IssueAccessToken(user *models.UserModel, familyID string) (
 	claims *jwt.CustomClaims,
 	tokenString string,
 	err error,
 ) {
 	var (
-		secret     string
+		keys       *jwt.KeySet
 		duration_s string
 		duration   int
 		ok         bool
 	)
 
-	if secret, ok = os.LookupEnv("AUTH_SECRET"); !ok {
-		return nil, "", errors.New("unable to get authentication secret data")
+	if keys, err = GetKeySet(); err != nil {
+		return nil, "", err
 	}
 	if duration_s, ok = os.LookupEnv("AUTH_ACCESS_DURATION"); !ok {
 		duration_s = "60"
@@ -33,11 +34,13 @@ func IssueAccessToken(user *models.UserModel) (
 	if duration, err = strconv.Atoi(duration_s); err != nil {
 		duration = 60
 	}
-	if claims, tokenString, err = jwt.Issue(
+	claims = jwt.NewClaims(
 		accessTokenTypeName,
 		user.UID.Hex(),
-		time.Duration(duration)*time.Minute,
-		secret); err != nil {
+		time.Duration(duration)*time.Minute)
+	claims.FamilyID = familyID
+	claims.Version = user.TokenVersion
+	if tokenString, err = jwt.IssueClaims(claims, keys); err != nil {
 		return nil, "", err
 	}
 
@@ -48,15 +51,12 @@ func CheckAccessToken(token string) (
 	claims *jwt.CustomClaims,
 	err error,
 ) {
-	var (
-		secret string
-		ok     bool
-	)
+	var keys *jwt.KeySet
 
-	if secret, ok = os.LookupEnv("AUTH_SECRET"); !ok {
-		return nil, errors.New("unable to get authentication secret data")
+	if keys, err = GetKeySet(); err != nil {
+		return nil, err
 	}
-	if claims, err = jwt.Check(token, secret); err != nil {
+	if claims, err = jwt.Check(token, keys); err != nil {
 		return nil, err
 	}
 	if claims.Type != accessTokenTypeName {
@@ -0,0 +1,48 @@
+package jwt
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/pkg/jwt"
+)
+
+// Issue short-lived access token of the user on behalf of the actor,
+// carrying the actor claim. It has no session, so it can't be refreshed.
+func IssueImpersonationToken(actor *models.UserModel, user *models.UserModel) (
+	claims *jwt.CustomClaims,
+	tokenString string,
+	err error,
+) {
+	var (
+		keys       *jwt.KeySet
+		duration_s string
+		duration   int
+		ok         bool
+	)
+
+	if keys, err = GetKeySet(); err != nil {
+		return nil, "", err
+	}
+	if duration_s, ok = os.LookupEnv("AUTH_IMPERSONATION_DURATION"); !ok {
+		duration_s = "15"
+	}
+	if duration, err = strconv.Atoi(duration_s); err != nil {
+		duration = 15
+	}
+	claims = jwt.NewClaims(
+		accessTokenTypeName,
+		user.UID.Hex(),
+		time.Duration(duration)*time.Minute)
+	claims.Version = user.TokenVersion
+	claims.Actor = &jwt.ActorClaim{
+		Subject: actor.UID.Hex(),
+		Version: actor.TokenVersion}
+	if tokenString, err = jwt.IssueClaims(claims, keys); err != nil {
+		return nil, "", err
+	}
+
+	return claims, tokenString, nil
+}
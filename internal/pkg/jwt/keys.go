@@ -0,0 +1,95 @@
+package jwt
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/misterabdul/goblog-server/pkg/jwt"
+)
+
+const hmacKeyID = "hs512"
+
+var (
+	keySet     *jwt.KeySet
+	keySetErr  error
+	keySetOnce sync.Once
+)
+
+// Get the signing & verification keys configured from the environment,
+// loaded only once.
+//
+// Available signing methods: HS512 (default), RS256, ES256 & EdDSA.
+// After switching to the asymmetric keys, the HS512 tokens are only
+// accepted when AUTH_ACCEPT_LEGACY_HS512 is set to true, so the switch
+// doesn't sign everyone out. It should be unset once the longest token
+// lifetime has passed, anyone having the secret can sign tokens until then.
+func GetKeySet() (keys *jwt.KeySet, err error) {
+	keySetOnce.Do(func() {
+		keySet, keySetErr = loadKeySet()
+	})
+
+	return keySet, keySetErr
+}
+
+func loadKeySet() (keys *jwt.KeySet, err error) {
+	var (
+		methodName   string
+		secret       string
+		keyFile      string
+		keyFiles     string
+		pemBytes     []byte
+		signing      *jwt.Key
+		verification []*jwt.Key
+		key          *jwt.Key
+		acceptLegacy string
+		hasSecret    bool
+		ok           bool
+	)
+
+	if methodName, ok = os.LookupEnv("AUTH_SIGNING_METHOD"); !ok || methodName == "" {
+		methodName = "HS512"
+	}
+	secret, hasSecret = os.LookupEnv("AUTH_SECRET")
+	acceptLegacy, _ = os.LookupEnv("AUTH_ACCEPT_LEGACY_HS512")
+	if methodName != "HS512" && hasSecret && secret != "" &&
+		(acceptLegacy == "true" || acceptLegacy == "TRUE") {
+		verification = append(verification, jwt.NewHMACKey(hmacKeyID, secret))
+	}
+	if keyFiles, ok = os.LookupEnv("AUTH_VERIFICATION_KEY_FILES"); ok {
+		for _, keyFile = range strings.Split(keyFiles, ",") {
+			if keyFile = strings.TrimSpace(keyFile); keyFile == "" {
+				continue
+			}
+			if pemBytes, err = os.ReadFile(keyFile); err != nil {
+				return nil, err
+			}
+			if key, err = jwt.ParseVerificationKeyPEM(pemBytes); err != nil {
+				return nil, err
+			}
+			verification = append(verification, key)
+		}
+	}
+	switch {
+	case methodName == "HS512":
+		if !hasSecret || secret == "" {
+			return nil, errors.New("unable to get authentication secret data")
+		}
+		signing = jwt.NewHMACKey(hmacKeyID, secret)
+	case methodName == "RS256" || methodName == "ES256" || methodName == "EdDSA":
+		if keyFile, ok = os.LookupEnv("AUTH_SIGNING_KEY_FILE"); !ok || keyFile == "" {
+			return nil, errors.New("unable to get authentication signing key file")
+		}
+		if pemBytes, err = os.ReadFile(keyFile); err != nil {
+			return nil, err
+		}
+		if signing, err = jwt.ParsePrivateKeyPEM(pemBytes, methodName); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("unsupported authentication signing method: " + methodName)
+	}
+
+	return jwt.NewKeySet(signing, verification...), nil
+}
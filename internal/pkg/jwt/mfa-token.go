@@ -0,0 +1,67 @@
+package jwt
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/pkg/jwt"
+)
+
+// Short-lived token proving the password step of signing in,
+// exchanged for the actual tokens after the second factor step.
+const mfaPendingTokenTypeName = "mfa-pending"
+
+func IssueMfaPendingToken(user *models.UserModel) (
+	claims *jwt.CustomClaims,
+	tokenString string,
+	err error,
+) {
+	var (
+		keys       *jwt.KeySet
+		duration_s string
+		duration   int
+		ok         bool
+	)
+
+	if keys, err = GetKeySet(); err != nil {
+		return nil, "", err
+	}
+	if duration_s, ok = os.LookupEnv("AUTH_MFA_DURATION"); !ok {
+		duration_s = "5"
+	}
+	if duration, err = strconv.Atoi(duration_s); err != nil {
+		duration = 5
+	}
+	claims = jwt.NewClaims(
+		mfaPendingTokenTypeName,
+		user.UID.Hex(),
+		time.Duration(duration)*time.Minute)
+	claims.Version = user.TokenVersion
+	if tokenString, err = jwt.IssueClaims(claims, keys); err != nil {
+		return nil, "", err
+	}
+
+	return claims, tokenString, nil
+}
+
+func CheckMfaPendingToken(token string) (
+	claims *jwt.CustomClaims,
+	err error,
+) {
+	var keys *jwt.KeySet
+
+	if keys, err = GetKeySet(); err != nil {
+		return nil, err
+	}
+	if claims, err = jwt.Check(token, keys); err != nil {
+		return nil, err
+	}
+	if claims.Type != mfaPendingTokenTypeName {
+		return nil, errors.New("invalid token type")
+	}
+
+	return claims, nil
+}
@@ -12,48 +12,53 @@ import (
 
 const refreshTokenTypeName = "refresh-token"
 
-func IssueRefreshToken(user *models.UserModel) (
+// Issue new refresh token within the given token family,
+// starting a new family when it's empty.
+func IssueRefreshToken(user *models.UserModel, familyID string) (
 	claims *jwt.CustomClaims,
 	tokenString string,
 	err error,
 ) {
-	var (
-		secret     string
-		duration_s string
-		duration   int
-		ok         bool
-	)
+	var keys *jwt.KeySet
 
-	if secret, ok = os.LookupEnv("AUTH_SECRET"); !ok {
-		return nil, "", errors.New("unable to get authentication secret data")
-	}
-	if duration_s, ok = os.LookupEnv("AUTH_REFRESH_DURATION"); !ok {
-		duration_s = "14"
-	}
-	if duration, err = strconv.Atoi(duration_s); err != nil {
-		duration = 14
+	if keys, err = GetKeySet(); err != nil {
+		return nil, "", err
 	}
-	if claims, tokenString, err = jwt.Issue(
+	claims = jwt.NewClaims(
 		refreshTokenTypeName,
 		user.UID.Hex(),
-		time.Duration(duration)*24*time.Hour,
-		secret); err != nil {
+		GetRefreshTokenDuration())
+	if familyID == "" {
+		familyID = claims.Id
+	}
+	claims.FamilyID = familyID
+	claims.Version = user.TokenVersion
+	if tokenString, err = jwt.IssueClaims(claims, keys); err != nil {
 		return nil, "", err
 	}
 
 	return claims, tokenString, nil
 }
 
+// Get the token family of the refresh token claims.
+//
+// Tokens issued before the rotation was introduced have no family,
+// each of them is treated as the first token of its own family.
+func GetRefreshTokenFamily(claims *jwt.CustomClaims) (familyID string) {
+	if claims.FamilyID == "" {
+		return claims.Id
+	}
+
+	return claims.FamilyID
+}
+
 func CheckRefreshToken(token string) (claims *jwt.CustomClaims, err error) {
-	var (
-		secret string
-		ok     bool
-	)
+	var keys *jwt.KeySet
 
-	if secret, ok = os.LookupEnv("AUTH_SECRET"); !ok {
-		return nil, errors.New("unable to get authentication secret data")
+	if keys, err = GetKeySet(); err != nil {
+		return nil, err
 	}
-	if claims, err = jwt.Check(token, secret); err != nil {
+	if claims, err = jwt.Check(token, keys); err != nil {
 		return nil, err
 	}
 	if claims.Type != refreshTokenTypeName {
@@ -62,3 +67,42 @@ func CheckRefreshToken(token string) (claims *jwt.CustomClaims, err error) {
 
 	return claims, nil
 }
+
+// Get the refresh token lifetime.
+func GetRefreshTokenDuration() (duration time.Duration) {
+	var (
+		duration_s string
+		days       int
+		ok         bool
+		err        error
+	)
+
+	if duration_s, ok = os.LookupEnv("AUTH_REFRESH_DURATION"); !ok {
+		duration_s = "14"
+	}
+	if days, err = strconv.Atoi(duration_s); err != nil {
+		days = 14
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// Get how long a rotated refresh token may still be presented
+// without revoking its whole family.
+func GetRefreshTokenReuseGrace() (grace time.Duration) {
+	var (
+		grace_s string
+		seconds int
+		ok      bool
+		err     error
+	)
+
+	if grace_s, ok = os.LookupEnv("AUTH_REFRESH_REUSE_GRACE"); !ok {
+		grace_s = "10"
+	}
+	if seconds, err = strconv.Atoi(grace_s); err != nil {
+		seconds = 10
+	}
+
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,53 @@
+package mail
+
+import (
+	"os"
+
+	"github.com/misterabdul/goblog-server/pkg/mail"
+)
+
+// Get the mail transport configured from the environment.
+//
+// Available transports: smtp & file (default).
+func GetTransport() (transport mail.Transport) {
+	var (
+		transportName string
+		ok            bool
+	)
+
+	if transportName, ok = os.LookupEnv("MAIL_TRANSPORT"); !ok {
+		transportName = "file"
+	}
+	switch {
+	case transportName == "smtp":
+		return mail.NewSMTPTransport(
+			lookupEnvDefault("SMTP_HOST", "localhost"),
+			lookupEnvDefault("SMTP_PORT", "25"),
+			lookupEnvDefault("SMTP_USER", ""),
+			lookupEnvDefault("SMTP_PASS", ""))
+	case transportName == "file":
+		fallthrough
+	default:
+		return mail.NewFileTransport(
+			lookupEnvDefault("MAIL_OUTBOX_DIR", "storage/outbox"))
+	}
+}
+
+// Create new plain text message from the configured sender address.
+func NewMessage(to string, subject string, body string) (message *mail.Message) {
+	return &mail.Message{
+		From:    lookupEnvDefault("MAIL_FROM", "no-reply@goblog.local"),
+		To:      []string{to},
+		Subject: subject,
+		Body:    body}
+}
+
+func lookupEnvDefault(key string, fallback string) (value string) {
+	var ok bool
+
+	if value, ok = os.LookupEnv(key); !ok {
+		return fallback
+	}
+
+	return value
+}
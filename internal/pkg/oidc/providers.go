@@ -0,0 +1,67 @@
+package oidc
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/misterabdul/goblog-server/pkg/oidc"
+)
+
+var (
+	providers     map[string]*oidc.Provider
+	providersOnce sync.Once
+)
+
+// Get the OpenID Connect provider of the name, configured from the environment,
+// e.g. OIDC_PROVIDERS="google" with OIDC_GOOGLE_ISSUER, OIDC_GOOGLE_CLIENT_ID,
+// OIDC_GOOGLE_CLIENT_SECRET, OIDC_GOOGLE_REDIRECT_URL & OIDC_GOOGLE_SCOPES.
+func GetProvider(name string) (provider *oidc.Provider, ok bool) {
+	provider, ok = GetProviders()[name]
+
+	return provider, ok
+}
+
+// Get all of the configured OpenID Connect providers, loaded only once.
+func GetProviders() (_providers map[string]*oidc.Provider) {
+	providersOnce.Do(func() {
+		providers = loadProviders()
+	})
+
+	return providers
+}
+
+func loadProviders() (_providers map[string]*oidc.Provider) {
+	var (
+		names  string
+		prefix string
+		scopes string
+		ok     bool
+	)
+
+	_providers = map[string]*oidc.Provider{}
+	if names, ok = os.LookupEnv("OIDC_PROVIDERS"); !ok {
+		return _providers
+	}
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name == "" {
+			continue
+		}
+		prefix = "OIDC_" + strings.ToUpper(name) + "_"
+		if scopes, ok = os.LookupEnv(prefix + "SCOPES"); !ok || scopes == "" {
+			scopes = "openid email profile"
+		}
+		_providers[name] = &oidc.Provider{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       strings.Fields(strings.ReplaceAll(scopes, ",", " ")),
+			HTTPClient:   &http.Client{Timeout: 10 * time.Second}}
+	}
+
+	return _providers
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/hibiken/asynq"
+
+	internalMail "github.com/misterabdul/goblog-server/internal/pkg/mail"
+	"github.com/misterabdul/goblog-server/internal/queue/payloads"
+	"github.com/misterabdul/goblog-server/pkg/mail"
+)
+
+func SendInvitation(
+	transport mail.Transport,
+) (handler asynq.HandlerFunc) {
+
+	return func(ctx context.Context, t *asynq.Task) error {
+		var (
+			payload   *payloads.SendInvitationPayload
+			acceptUrl string
+			name      string
+			inviter   string
+			ok        bool
+			err       error
+		)
+
+		if payload, err = payloads.UnmarshallSendInvitationPayload(t.Payload()); err != nil {
+			return err
+		}
+		if acceptUrl, ok = os.LookupEnv("INVITATION_URL"); !ok {
+			acceptUrl = "http://localhost/accept-invitation"
+		}
+		if name = payload.Name; name == "" {
+			name = "there"
+		}
+		if inviter = payload.Inviter; inviter == "" {
+			inviter = "An administrator"
+		}
+		if err = transport.Send(ctx, internalMail.NewMessage(
+			payload.Email,
+			"You're invited to join the team",
+			fmt.Sprintf("Hi %s,\n\n"+
+				"%s invited you to join the team. "+
+				"Open the link below to choose your username & password:\n\n"+
+				"%s?token=%s\n\n"+
+				"The invitation expires on %s. "+
+				"If you were not expecting this, you can ignore this email.\n",
+				name, inviter, acceptUrl, url.QueryEscape(payload.Token), payload.ExpiresAt),
+		)); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
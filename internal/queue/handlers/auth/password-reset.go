@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/hibiken/asynq"
+
+	internalMail "github.com/misterabdul/goblog-server/internal/pkg/mail"
+	"github.com/misterabdul/goblog-server/internal/queue/payloads"
+	"github.com/misterabdul/goblog-server/pkg/mail"
+)
+
+func SendPasswordReset(
+	transport mail.Transport,
+) (handler asynq.HandlerFunc) {
+
+	return func(ctx context.Context, t *asynq.Task) error {
+		var (
+			payload  *payloads.SendPasswordResetPayload
+			resetUrl string
+			ok       bool
+			err      error
+		)
+
+		if payload, err = payloads.UnmarshallSendPasswordResetPayload(t.Payload()); err != nil {
+			return err
+		}
+		if resetUrl, ok = os.LookupEnv("PASSWORD_RESET_URL"); !ok {
+			resetUrl = "http://localhost/reset-password"
+		}
+		if err = transport.Send(ctx, internalMail.NewMessage(
+			payload.Email,
+			"Reset your password",
+			fmt.Sprintf("Hi %s,\n\n"+
+				"Someone requested a password reset for your account. "+
+				"Open the link below to choose a new password:\n\n"+
+				"%s?token=%s\n\n"+
+				"The link can only be used once and will expire soon. "+
+				"If you did not request this, you can ignore this email.\n",
+				payload.Name, resetUrl, url.QueryEscape(payload.Token)),
+		)); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/hibiken/asynq"
+
+	internalMail "github.com/misterabdul/goblog-server/internal/pkg/mail"
+	"github.com/misterabdul/goblog-server/internal/queue/payloads"
+	"github.com/misterabdul/goblog-server/pkg/mail"
+)
+
+func SendEmailVerification(
+	transport mail.Transport,
+) (handler asynq.HandlerFunc) {
+
+	return func(ctx context.Context, t *asynq.Task) error {
+		var (
+			payload   *payloads.SendEmailVerificationPayload
+			verifyUrl string
+			ok        bool
+			err       error
+		)
+
+		if payload, err = payloads.UnmarshallSendEmailVerificationPayload(t.Payload()); err != nil {
+			return err
+		}
+		if verifyUrl, ok = os.LookupEnv("EMAIL_VERIFICATION_URL"); !ok {
+			verifyUrl = "http://localhost/verify-email"
+		}
+		if err = transport.Send(ctx, internalMail.NewMessage(
+			payload.Email,
+			"Verify your email address",
+			fmt.Sprintf("Hi %s,\n\n"+
+				"Please verify your email address by opening the link below:\n\n"+
+				"%s?token=%s\n\n"+
+				"If you did not sign up, you can ignore this email.\n",
+				payload.Name, verifyUrl, url.QueryEscape(payload.Token)),
+		)); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,54 @@
+package me
+
+import (
+	"context"
+
+	"github.com/hibiken/asynq"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/queue/payloads"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+func ExportMe(
+	svc *service.Service,
+) (handler asynq.HandlerFunc) {
+
+	return func(ctx context.Context, t *asynq.Task) error {
+		var (
+			payload    *payloads.ExportMePayload
+			dataExport *models.DataExportModel
+			err        error
+		)
+
+		if payload, err = payloads.UnmarshallExportMePayload(t.Payload()); err != nil {
+			return err
+		}
+		if dataExport, err = svc.DataExport.GetOne(ctx, bson.M{
+			"_id": payload.DataExportUid},
+		); err != nil {
+			return err
+		}
+		if dataExport == nil || dataExport.Status != models.DataExportStatusPending {
+			return nil
+		}
+		if err = svc.DataExport.BuildOne(ctx, dataExport); err != nil {
+			retried, _ := asynq.GetRetryCount(ctx)
+			maxRetry, _ := asynq.GetMaxRetry(ctx)
+			if retried >= maxRetry {
+				svc.DataExport.FailOne(ctx, dataExport)
+			}
+			return err
+		}
+		if err = svc.Notification.SaveOne(ctx, &models.NotificationModel{
+			Title:   "Your data export is ready",
+			Content: "The archive of your personal data is ready to be downloaded from your data exports.",
+			Owner:   dataExport.Owner},
+		); err != nil {
+			return err
+		}
+
+		return svc.DataExport.DeleteExpired(ctx)
+	}
+}
@@ -25,6 +25,9 @@ func UpdateMe(
 		if err = svc.Post.UpdateManyAuthor(ctx, &payload.UserModel); err != nil {
 			return err
 		}
+		if err = svc.Page.UpdateManyAuthor(ctx, &payload.UserModel); err != nil {
+			return err
+		}
 
 		return nil
 	}
@@ -0,0 +1,33 @@
+package page
+
+import (
+	"context"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/misterabdul/goblog-server/internal/queue/payloads"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+func PublishScheduledPage(
+	svc *service.Service,
+) (handler asynq.HandlerFunc) {
+
+	return func(ctx context.Context, t *asynq.Task) error {
+		var (
+			payload *payloads.PublishScheduledPagePayload
+			err     error
+		)
+
+		if payload, err = payloads.UnmarshallPublishScheduledPagePayload(t.Payload()); err != nil {
+			return err
+		}
+		if _, err = svc.Page.PublishScheduledOne(
+			ctx, payload.PageUid, payload.PublishAt,
+		); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,33 @@
+package post
+
+import (
+	"context"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/misterabdul/goblog-server/internal/queue/payloads"
+	"github.com/misterabdul/goblog-server/internal/service"
+)
+
+func PublishScheduledPost(
+	svc *service.Service,
+) (handler asynq.HandlerFunc) {
+
+	return func(ctx context.Context, t *asynq.Task) error {
+		var (
+			payload *payloads.PublishScheduledPostPayload
+			err     error
+		)
+
+		if payload, err = payloads.UnmarshallPublishScheduledPostPayload(t.Payload()); err != nil {
+			return err
+		}
+		if _, err = svc.Post.PublishScheduledOne(
+			ctx, payload.PostUid, payload.PublishAt,
+		); err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
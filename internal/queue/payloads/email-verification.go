@@ -0,0 +1,42 @@
+package payloads
+
+import (
+	"encoding/json"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+type SendEmailVerificationPayload struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+	Token string `json:"token"`
+}
+
+func (p *SendEmailVerificationPayload) Marshall() (
+	data []byte,
+	err error,
+) {
+	return json.Marshal(p)
+}
+
+func NewSendEmailVerificationPayload(user models.UserModel, token string) (
+	payload *SendEmailVerificationPayload,
+) {
+	return &SendEmailVerificationPayload{
+		Email: user.Email,
+		Name:  user.FirstName,
+		Token: token}
+}
+
+func UnmarshallSendEmailVerificationPayload(data []byte) (
+	payload *SendEmailVerificationPayload,
+	err error,
+) {
+	var _payload SendEmailVerificationPayload
+
+	if err = json.Unmarshal(data, &_payload); err != nil {
+		return nil, err
+	}
+
+	return &_payload, nil
+}
@@ -0,0 +1,48 @@
+package payloads
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+type SendInvitationPayload struct {
+	Email     string `json:"email"`
+	Name      string `json:"name"`
+	Inviter   string `json:"inviter"`
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expiresAt"`
+}
+
+func (p *SendInvitationPayload) Marshall() (
+	data []byte,
+	err error,
+) {
+	return json.Marshal(p)
+}
+
+func NewSendInvitationPayload(invitation models.InvitationModel, token string) (
+	payload *SendInvitationPayload,
+) {
+	return &SendInvitationPayload{
+		Email: invitation.Email,
+		Name:  invitation.FirstName,
+		Inviter: strings.TrimSpace(
+			invitation.InvitedBy.FirstName + " " + invitation.InvitedBy.LastName),
+		Token:     token,
+		ExpiresAt: invitation.ExpiresAt.Time().UTC().Format("2 January 2006 15:04 MST")}
+}
+
+func UnmarshallSendInvitationPayload(data []byte) (
+	payload *SendInvitationPayload,
+	err error,
+) {
+	var _payload SendInvitationPayload
+
+	if err = json.Unmarshal(data, &_payload); err != nil {
+		return nil, err
+	}
+
+	return &_payload, nil
+}
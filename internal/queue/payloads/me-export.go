@@ -0,0 +1,40 @@
+package payloads
+
+import (
+	"encoding/json"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+type ExportMePayload struct {
+	DataExportUid primitive.ObjectID `json:"dataExportUid"`
+}
+
+func (p *ExportMePayload) Marshall() (
+	data []byte,
+	err error,
+) {
+	return json.Marshal(p)
+}
+
+func NewExportMePayload(dataExport models.DataExportModel) (
+	payload *ExportMePayload,
+) {
+	return &ExportMePayload{
+		DataExportUid: dataExport.UID}
+}
+
+func UnmarshallExportMePayload(data []byte) (
+	payload *ExportMePayload,
+	err error,
+) {
+	var _payload ExportMePayload
+
+	if err = json.Unmarshal(data, &_payload); err != nil {
+		return nil, err
+	}
+
+	return &_payload, nil
+}
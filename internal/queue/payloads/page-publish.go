@@ -0,0 +1,53 @@
+package payloads
+
+import (
+	"encoding/json"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+type PublishScheduledPagePayload struct {
+	PageUid   primitive.ObjectID `json:"pageUid"`
+	PublishAt primitive.DateTime `json:"publishAt"`
+}
+
+func (p *PublishScheduledPagePayload) Marshall() (
+	data []byte,
+	err error,
+) {
+	return json.Marshal(p)
+}
+
+func NewPublishScheduledPagePayload(page models.PageModel) (
+	payload *PublishScheduledPagePayload,
+	err error,
+) {
+	var (
+		publishAt primitive.DateTime
+		ok        bool
+	)
+
+	if publishAt, ok = page.PublishAt.(primitive.DateTime); !ok {
+		return nil, errors.New("page isn't scheduled")
+	}
+
+	return &PublishScheduledPagePayload{
+		PageUid:   page.UID,
+		PublishAt: publishAt}, nil
+}
+
+func UnmarshallPublishScheduledPagePayload(data []byte) (
+	payload *PublishScheduledPagePayload,
+	err error,
+) {
+	var _payload PublishScheduledPagePayload
+
+	if err = json.Unmarshal(data, &_payload); err != nil {
+		return nil, err
+	}
+
+	return &_payload, nil
+}
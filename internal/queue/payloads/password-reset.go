@@ -0,0 +1,42 @@
+package payloads
+
+import (
+	"encoding/json"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+type SendPasswordResetPayload struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+	Token string `json:"token"`
+}
+
+func (p *SendPasswordResetPayload) Marshall() (
+	data []byte,
+	err error,
+) {
+	return json.Marshal(p)
+}
+
+func NewSendPasswordResetPayload(user models.UserModel, token string) (
+	payload *SendPasswordResetPayload,
+) {
+	return &SendPasswordResetPayload{
+		Email: user.Email,
+		Name:  user.FirstName,
+		Token: token}
+}
+
+func UnmarshallSendPasswordResetPayload(data []byte) (
+	payload *SendPasswordResetPayload,
+	err error,
+) {
+	var _payload SendPasswordResetPayload
+
+	if err = json.Unmarshal(data, &_payload); err != nil {
+		return nil, err
+	}
+
+	return &_payload, nil
+}
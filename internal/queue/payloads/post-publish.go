@@ -0,0 +1,53 @@
+package payloads
+
+import (
+	"encoding/json"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+type PublishScheduledPostPayload struct {
+	PostUid   primitive.ObjectID `json:"postUid"`
+	PublishAt primitive.DateTime `json:"publishAt"`
+}
+
+func (p *PublishScheduledPostPayload) Marshall() (
+	data []byte,
+	err error,
+) {
+	return json.Marshal(p)
+}
+
+func NewPublishScheduledPostPayload(post models.PostModel) (
+	payload *PublishScheduledPostPayload,
+	err error,
+) {
+	var (
+		publishAt primitive.DateTime
+		ok        bool
+	)
+
+	if publishAt, ok = post.PublishAt.(primitive.DateTime); !ok {
+		return nil, errors.New("post isn't scheduled")
+	}
+
+	return &PublishScheduledPostPayload{
+		PostUid:   post.UID,
+		PublishAt: publishAt}, nil
+}
+
+func UnmarshallPublishScheduledPostPayload(data []byte) (
+	payload *PublishScheduledPostPayload,
+	err error,
+) {
+	var _payload PublishScheduledPostPayload
+
+	if err = json.Unmarshal(data, &_payload); err != nil {
+		return nil, err
+	}
+
+	return &_payload, nil
+}
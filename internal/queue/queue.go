@@ -1,5 +1,11 @@
 package queue
 
 const (
-	UpdateMe = "me:update"
+	UpdateMe              = "me:update"
+	ExportMe              = "me:export"
+	SendEmailVerification = "auth:send-email-verification"
+	SendPasswordReset     = "auth:send-password-reset"
+	SendInvitation        = "auth:send-invitation"
+	PublishScheduledPost  = "post:publish-scheduled"
+	PublishScheduledPage  = "page:publish-scheduled"
 )
@@ -8,9 +8,13 @@ import (
 	"github.com/hibiken/asynq"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	internalMail "github.com/misterabdul/goblog-server/internal/pkg/mail"
 	"github.com/misterabdul/goblog-server/internal/queue"
 	"github.com/misterabdul/goblog-server/internal/queue/client"
+	authHandler "github.com/misterabdul/goblog-server/internal/queue/handlers/auth"
 	meHandler "github.com/misterabdul/goblog-server/internal/queue/handlers/me"
+	pageHandler "github.com/misterabdul/goblog-server/internal/queue/handlers/page"
+	postHandler "github.com/misterabdul/goblog-server/internal/queue/handlers/post"
 	"github.com/misterabdul/goblog-server/internal/service"
 )
 
@@ -22,6 +26,7 @@ func InitServeMux(
 		mux              *asynq.ServeMux
 		serverRelatedEnv = getRedisServerRelatedEnv()
 		svc              = service.NewService(dbConn, queueClient)
+		mailTransport    = internalMail.GetTransport()
 	)
 
 	mux = asynq.NewServeMux()
@@ -37,6 +42,12 @@ func InitServeMux(
 	}
 
 	mux.HandleFunc(queue.UpdateMe, meHandler.UpdateMe(svc))
+	mux.HandleFunc(queue.ExportMe, meHandler.ExportMe(svc))
+	mux.HandleFunc(queue.SendEmailVerification, authHandler.SendEmailVerification(mailTransport))
+	mux.HandleFunc(queue.SendPasswordReset, authHandler.SendPasswordReset(mailTransport))
+	mux.HandleFunc(queue.SendInvitation, authHandler.SendInvitation(mailTransport))
+	mux.HandleFunc(queue.PublishScheduledPost, postHandler.PublishScheduledPost(svc))
+	mux.HandleFunc(queue.PublishScheduledPage, pageHandler.PublishScheduledPage(svc))
 
 	return mux
 }
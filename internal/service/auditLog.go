@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/database/repositories"
+)
+
+type auditLog struct {
+	dbConn *mongo.Database
+}
+
+func newAuditLogService(
+	dbConn *mongo.Database,
+) (service *auditLog) {
+
+	return &auditLog{dbConn: dbConn}
+}
+
+// Get multiple audit logs
+func (s *auditLog) GetMany(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (auditLogs []*models.AuditLogModel, err error) {
+
+	return repositories.ReadManyAuditLogs(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Get total audit logs count
+func (s *auditLog) Count(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.CountOptions,
+) (count int64, err error) {
+
+	return repositories.CountAuditLogs(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Append new audit log
+func (s *auditLog) SaveOne(
+	ctx context.Context,
+	auditLog *models.AuditLogModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	auditLog.UID = primitive.NewObjectID()
+	auditLog.CreatedAt = now
+
+	return repositories.SaveOneAuditLog(
+		s.dbConn, ctx, auditLog, opts...)
+}
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/database/repositories"
+)
+
+type dataExport struct {
+	dbConn *mongo.Database
+
+	duration time.Duration
+}
+
+func newDataExportService(
+	dbConn *mongo.Database,
+) (service *dataExport) {
+
+	return &dataExport{
+		dbConn:   dbConn,
+		duration: time.Duration(getEnvInt("DATA_EXPORT_DURATION", 48)) * time.Hour}
+}
+
+// Get single data export
+func (s *dataExport) GetOne(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (dataExport *models.DataExportModel, err error) {
+
+	return repositories.ReadOneDataExport(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Get multiple data exports
+func (s *dataExport) GetMany(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (dataExports []*models.DataExportModel, err error) {
+
+	return repositories.ReadManyDataExports(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Create new pending data export of the user
+func (s *dataExport) CreateOne(
+	ctx context.Context,
+	user *models.UserModel,
+	opts ...*options.InsertOneOptions,
+) (dataExport *models.DataExportModel, err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	dataExport = &models.DataExportModel{
+		UID:       primitive.NewObjectID(),
+		Owner:     user.ToCommonModel(),
+		Status:    models.DataExportStatusPending,
+		CreatedAt: now}
+	if err = repositories.SaveOneDataExport(
+		s.dbConn, ctx, dataExport, opts...,
+	); err != nil {
+		return nil, err
+	}
+
+	return dataExport, nil
+}
+
+// Mark the data export failed
+func (s *dataExport) FailOne(
+	ctx context.Context,
+	dataExport *models.DataExportModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	dataExport.Status = models.DataExportStatusFailed
+	dataExport.FinishedAt = now
+
+	return repositories.UpdateOneDataExport(
+		s.dbConn, ctx, dataExport, opts...)
+}
+
+// Build the zip archive of the owner's personal data & store it,
+// marking the data export ready until it's expired
+func (s *dataExport) BuildOne(
+	ctx context.Context,
+	dataExport *models.DataExportModel,
+) (err error) {
+	var (
+		archive bytes.Buffer
+		fileID  primitive.ObjectID
+		now     time.Time
+	)
+
+	if err = s.writeArchive(ctx, dataExport.Owner.UID, &archive); err != nil {
+		return err
+	}
+	if fileID, err = repositories.UploadOneDataExportFile(
+		s.dbConn, ctx, dataExport.UID.Hex()+".zip", bytes.NewReader(archive.Bytes()),
+	); err != nil {
+		return err
+	}
+	now = time.Now()
+	dataExport.Status = models.DataExportStatusReady
+	dataExport.FileID = fileID
+	dataExport.Size = int64(archive.Len())
+	dataExport.ExpiresAt = primitive.NewDateTimeFromTime(now.Add(s.duration))
+	dataExport.FinishedAt = primitive.NewDateTimeFromTime(now)
+
+	return repositories.UpdateOneDataExport(
+		s.dbConn, ctx, dataExport)
+}
+
+// Open the ready data export's archive, the caller must close it
+func (s *dataExport) OpenArchive(
+	ctx context.Context,
+	dataExport *models.DataExportModel,
+) (archive io.ReadCloser, err error) {
+	if dataExport.Status != models.DataExportStatusReady {
+		return nil, errors.New("data export is not ready")
+	}
+
+	return repositories.OpenOneDataExportFile(
+		s.dbConn, ctx, dataExport.FileID)
+}
+
+// Permanently delete multiple data exports along with their archives
+func (s *dataExport) DeleteMany(
+	ctx context.Context,
+	filter interface{},
+) (err error) {
+	var (
+		dataExports []*models.DataExportModel
+		uids        []primitive.ObjectID
+	)
+
+	if dataExports, err = repositories.ReadManyDataExports(
+		s.dbConn, ctx, filter,
+	); err != nil {
+		return err
+	}
+	for _, dataExport := range dataExports {
+		if dataExport.FileID != nil {
+			if err = repositories.DeleteOneDataExportFile(
+				s.dbConn, ctx, dataExport.FileID,
+			); err != nil {
+				return err
+			}
+		}
+		uids = append(uids, dataExport.UID)
+	}
+	if len(uids) == 0 {
+		return nil
+	}
+
+	return repositories.DeleteManyDataExports(
+		s.dbConn, ctx, bson.M{"_id": bson.M{"$in": uids}})
+}
+
+// Permanently delete the expired data exports along with their archives
+func (s *dataExport) DeleteExpired(
+	ctx context.Context,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	return s.DeleteMany(ctx, bson.M{"expiresat": bson.M{"$lt": now}})
+}
+
+// Write the profile as JSON, the rest as newline delimited JSON
+func (s *dataExport) writeArchive(
+	ctx context.Context,
+	ownerUid primitive.ObjectID,
+	archive io.Writer,
+) (err error) {
+	var (
+		writer        = zip.NewWriter(archive)
+		user          *models.UserModel
+		posts         []*models.PostModel
+		postContents  []*models.PostContentModel
+		pages         []*models.PageModel
+		pageContents  []*models.PageContentModel
+		comments      []*models.CommentModel
+		notifications []*models.NotificationModel
+		postUids      = []primitive.ObjectID{}
+		pageUids      = []primitive.ObjectID{}
+		contents      = map[primitive.ObjectID]string{}
+		records       []interface{}
+		sortOldest    = options.Find().SetSort(bson.M{"createdat": 1})
+	)
+
+	if user, err = repositories.ReadOneUser(
+		s.dbConn, ctx, bson.M{"_id": ownerUid},
+	); err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("data export's owner not found")
+	}
+	if posts, err = repositories.ReadManyPosts(
+		s.dbConn, ctx, bson.M{"author._id": ownerUid}, sortOldest,
+	); err != nil {
+		return err
+	}
+	for _, post := range posts {
+		postUids = append(postUids, post.UID)
+	}
+	if postContents, err = repositories.ReadManyPostContents(
+		s.dbConn, ctx, bson.M{"_id": bson.M{"$in": postUids}},
+	); err != nil {
+		return err
+	}
+	for _, postContent := range postContents {
+		contents[postContent.UID] = postContent.Content
+	}
+	if pages, err = repositories.ReadManyPages(
+		s.dbConn, ctx, bson.M{"author._id": ownerUid}, sortOldest,
+	); err != nil {
+		return err
+	}
+	for _, page := range pages {
+		pageUids = append(pageUids, page.UID)
+	}
+	if pageContents, err = repositories.ReadManyPageContents(
+		s.dbConn, ctx, bson.M{"_id": bson.M{"$in": pageUids}},
+	); err != nil {
+		return err
+	}
+	for _, pageContent := range pageContents {
+		contents[pageContent.UID] = pageContent.Content
+	}
+	if comments, err = repositories.ReadManyComments(
+		s.dbConn, ctx, bson.M{"postauthoruid": ownerUid}, sortOldest,
+	); err != nil {
+		return err
+	}
+	if notifications, err = repositories.ReadManyNotifications(
+		s.dbConn, ctx, bson.M{"owner._id": ownerUid}, sortOldest,
+	); err != nil {
+		return err
+	}
+
+	if err = writeArchiveFile(writer, "profile.json", false, []interface{}{
+		exportedProfile(user)}); err != nil {
+		return err
+	}
+	for _, post := range posts {
+		records = append(records, struct {
+			*models.PostModel
+			Content string `json:"content"`
+		}{post, contents[post.UID]})
+	}
+	if err = writeArchiveFile(writer, "posts.ndjson", true, records); err != nil {
+		return err
+	}
+	records = nil
+	for _, page := range pages {
+		records = append(records, struct {
+			*models.PageModel
+			Content string `json:"content"`
+		}{page, contents[page.UID]})
+	}
+	if err = writeArchiveFile(writer, "pages.ndjson", true, records); err != nil {
+		return err
+	}
+	records = nil
+	for _, comment := range comments {
+		records = append(records, comment)
+	}
+	if err = writeArchiveFile(writer, "comments.ndjson", true, records); err != nil {
+		return err
+	}
+	records = nil
+	for _, notification := range notifications {
+		records = append(records, notification)
+	}
+	if err = writeArchiveFile(writer, "notifications.ndjson", true, records); err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+func writeArchiveFile(
+	writer *zip.Writer,
+	name string,
+	delimited bool,
+	records []interface{},
+) (err error) {
+	var (
+		file    io.Writer
+		encoder *json.Encoder
+	)
+
+	if file, err = writer.Create(name); err != nil {
+		return err
+	}
+	encoder = json.NewEncoder(file)
+	if !delimited {
+		encoder.SetIndent("", "  ")
+	}
+	for _, record := range records {
+		if err = encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// The user's profile without the credentials & second factor secrets.
+func exportedProfile(user *models.UserModel) (profile bson.M) {
+	var identities = []bson.M{}
+
+	for _, identity := range user.Identities {
+		identities = append(identities, bson.M{
+			"provider": identity.Provider,
+			"email":    identity.Email,
+			"linkedAt": identity.LinkedAt})
+	}
+
+	return bson.M{
+		"id":               user.UID.Hex(),
+		"username":         user.Username,
+		"email":            user.Email,
+		"firstName":        user.FirstName,
+		"lastName":         user.LastName,
+		"bio":              user.Bio,
+		"avatar":           user.Avatar,
+		"website":          user.Website,
+		"socials":          user.Socials,
+		"roles":            user.Roles,
+		"identities":       identities,
+		"twoFactorEnabled": user.TwoFactor.EnabledAt != nil,
+		"verifiedAt":       user.VerifiedAt,
+		"createdAt":        user.CreatedAt,
+		"updatedAt":        user.UpdatedAt}
+}
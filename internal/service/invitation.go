@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/database/repositories"
+	"github.com/misterabdul/goblog-server/pkg/hash"
+	customMongo "github.com/misterabdul/goblog-server/pkg/mongo"
+)
+
+type invitation struct {
+	dbConn *mongo.Database
+
+	duration time.Duration
+}
+
+func newInvitationService(
+	dbConn *mongo.Database,
+) (service *invitation) {
+
+	return &invitation{
+		dbConn:   dbConn,
+		duration: time.Duration(getEnvInt("INVITATION_DURATION", 72)) * time.Hour}
+}
+
+// Filter of the invitations neither accepted, revoked nor expired
+func (s *invitation) PendingFilter() (filter bson.M) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	return bson.M{
+		"$and": []bson.M{
+			{"acceptedat": bson.M{"$eq": primitive.Null{}}},
+			{"revokedat": bson.M{"$eq": primitive.Null{}}},
+			{"expiresat": bson.M{"$gt": now}}}}
+}
+
+// Get single invitation
+func (s *invitation) GetOne(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (invitation *models.InvitationModel, err error) {
+
+	return repositories.ReadOneInvitation(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Get the pending invitation of given plain token
+func (s *invitation) GetOneByToken(
+	ctx context.Context,
+	plainToken string,
+) (invitation *models.InvitationModel, err error) {
+
+	return repositories.ReadOneInvitation(
+		s.dbConn, ctx, bson.M{
+			"$and": []bson.M{
+				{"tokenhash": bson.M{"$eq": hash.HashToken(plainToken)}},
+				s.PendingFilter()}})
+}
+
+// Get multiple invitations
+func (s *invitation) GetMany(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (invitations []*models.InvitationModel, err error) {
+
+	return repositories.ReadManyInvitations(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Get total invitations count
+func (s *invitation) Count(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.CountOptions,
+) (count int64, err error) {
+
+	return repositories.CountInvitations(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Create new invitation, the plain token is only returned here
+func (s *invitation) CreateOne(
+	ctx context.Context,
+	invitation *models.InvitationModel,
+	opts ...*options.InsertOneOptions,
+) (plainToken string, err error) {
+	var now = time.Now()
+
+	if plainToken, invitation.TokenHash, err = hash.MakeToken(32); err != nil {
+		return "", err
+	}
+	invitation.UID = primitive.NewObjectID()
+	invitation.SentCount = 1
+	invitation.ExpiresAt = primitive.NewDateTimeFromTime(now.Add(s.duration))
+	invitation.AcceptedAt = nil
+	invitation.RevokedAt = nil
+	invitation.CreatedAt = primitive.NewDateTimeFromTime(now)
+	invitation.UpdatedAt = primitive.NewDateTimeFromTime(now)
+	if err = repositories.SaveOneInvitation(
+		s.dbConn, ctx, invitation, opts...,
+	); err != nil {
+		return "", err
+	}
+
+	return plainToken, nil
+}
+
+// Replace the invitation's token & extend its expiry to be sent again,
+// invalidating the previously sent token
+func (s *invitation) RenewOne(
+	ctx context.Context,
+	invitation *models.InvitationModel,
+	opts ...*options.UpdateOptions,
+) (plainToken string, err error) {
+	var now = time.Now()
+
+	if plainToken, invitation.TokenHash, err = hash.MakeToken(32); err != nil {
+		return "", err
+	}
+	invitation.SentCount++
+	invitation.ExpiresAt = primitive.NewDateTimeFromTime(now.Add(s.duration))
+	invitation.UpdatedAt = primitive.NewDateTimeFromTime(now)
+	if err = repositories.UpdateOneInvitation(
+		s.dbConn, ctx, invitation, opts...,
+	); err != nil {
+		return "", err
+	}
+
+	return plainToken, nil
+}
+
+// Revoke the invitation, its token can no longer be accepted
+func (s *invitation) RevokeOne(
+	ctx context.Context,
+	invitation *models.InvitationModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	invitation.RevokedAt = now
+	invitation.UpdatedAt = now
+
+	return repositories.UpdateOneInvitation(
+		s.dbConn, ctx, invitation, opts...)
+}
+
+// Accept the still pending invitation by creating the invited user,
+// not accepted when it's no longer pending in the meantime
+func (s *invitation) AcceptOne(
+	ctx context.Context,
+	invitation *models.InvitationModel,
+	user *models.UserModel,
+) (accepted bool, err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	err = customMongo.Transaction(ctx, s.dbConn, false,
+		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
+			var _invitation *models.InvitationModel
+
+			if _invitation, sErr = repositories.AcceptOneInvitation(
+				dbConn, sCtx, bson.M{
+					"$and": []bson.M{
+						{"_id": bson.M{"$eq": invitation.UID}},
+						s.PendingFilter()}},
+				now,
+			); sErr != nil || _invitation == nil {
+				return sErr
+			}
+			user.UID = primitive.NewObjectID()
+			user.CreatedAt = now
+			user.UpdatedAt = now
+			user.DeletedAt = nil
+			if sErr = repositories.SaveOneUser(
+				dbConn, sCtx, user,
+			); sErr != nil {
+				return sErr
+			}
+			accepted = true
+
+			return nil
+		})
+
+	return accepted, err
+}
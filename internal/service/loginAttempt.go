@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+)
+
+const (
+	loginAttemptAccountKeyPrefix = "account:"
+	loginAttemptIPKeyPrefix      = "ip:"
+)
+
+type loginAttempt struct {
+	store LoginAttemptStore
+
+	accountMaxAttempts int
+	ipMaxAttempts      int
+	window             time.Duration
+	lockoutDuration    time.Duration
+	lockoutMaxDuration time.Duration
+}
+
+func newLoginAttemptService(
+	dbConn *mongo.Database,
+) (service *loginAttempt) {
+	var store LoginAttemptStore
+
+	if storeName, ok := os.LookupEnv("SIGNIN_ATTEMPT_STORE"); ok && storeName == "memory" {
+		store = NewMemoryLoginAttemptStore()
+	} else {
+		store = NewMongoLoginAttemptStore(dbConn)
+	}
+
+	return &loginAttempt{
+		store:              store,
+		accountMaxAttempts: getEnvInt("SIGNIN_ACCOUNT_MAX_ATTEMPTS", 5),
+		ipMaxAttempts:      getEnvInt("SIGNIN_IP_MAX_ATTEMPTS", 20),
+		window:             time.Duration(getEnvInt("SIGNIN_ATTEMPT_WINDOW", 15)) * time.Minute,
+		lockoutDuration:    time.Duration(getEnvInt("SIGNIN_LOCKOUT_DURATION", 1)) * time.Minute,
+		lockoutMaxDuration: time.Duration(getEnvInt("SIGNIN_LOCKOUT_MAX_DURATION", 60)) * time.Minute}
+}
+
+// Key of the attempts made against an account
+func (s *loginAttempt) AccountKey(account string) (key string) {
+	return loginAttemptAccountKeyPrefix + account
+}
+
+// Key of the attempts made from a client IP
+func (s *loginAttempt) IPKey(ip string) (key string) {
+	return loginAttemptIPKeyPrefix + ip
+}
+
+// Get the remaining lockout duration of the keys, zero if none is locked
+func (s *loginAttempt) Check(
+	ctx context.Context,
+	keys ...string,
+) (retryAfter time.Duration, err error) {
+	var (
+		attempts []*models.LoginAttemptModel
+		now      = time.Now()
+	)
+
+	if attempts, err = s.store.GetMany(ctx, keys); err != nil {
+		return 0, err
+	}
+	for _, attempt := range attempts {
+		if remaining := attempt.LockedUntil.Time().Sub(now); remaining > retryAfter {
+			retryAfter = remaining
+		}
+	}
+
+	return retryAfter, nil
+}
+
+// Record a failed attempt of the keys, locking the ones exceeding
+// their threshold with exponential backoff. Returns the resulting
+// lockout duration, zero if none gets locked.
+func (s *loginAttempt) Fail(
+	ctx context.Context,
+	keys ...string,
+) (retryAfter time.Duration, err error) {
+	var (
+		attempt  *models.LoginAttemptModel
+		duration time.Duration
+		now      = time.Now()
+	)
+
+	for _, key := range keys {
+		if attempt, err = s.store.Fail(ctx, key, now, s.window); err != nil {
+			return 0, err
+		}
+		if duration = s.lockoutDurationOf(key, attempt.Failures); duration <= 0 {
+			continue
+		}
+		if err = s.store.Lock(ctx, key, now.Add(duration)); err != nil {
+			return 0, err
+		}
+		if duration > retryAfter {
+			retryAfter = duration
+		}
+	}
+
+	return retryAfter, nil
+}
+
+// Clear the attempts of the key, e.g. after successful sign in
+func (s *loginAttempt) Clear(
+	ctx context.Context,
+	key string,
+) (err error) {
+
+	return s.store.Clear(ctx, key)
+}
+
+// Get currently locked out keys
+func (s *loginAttempt) GetLocked(
+	ctx context.Context,
+) (attempts []*models.LoginAttemptModel, err error) {
+
+	return s.store.GetLocked(ctx, time.Now())
+}
+
+// Clear single lockout
+func (s *loginAttempt) ClearOne(
+	ctx context.Context,
+	uid primitive.ObjectID,
+) (cleared bool, err error) {
+
+	return s.store.ClearOne(ctx, uid)
+}
+
+func (s *loginAttempt) lockoutDurationOf(
+	key string,
+	failures int,
+) (duration time.Duration) {
+	var maxAttempts = s.accountMaxAttempts
+
+	if strings.HasPrefix(key, loginAttemptIPKeyPrefix) {
+		maxAttempts = s.ipMaxAttempts
+	}
+	if maxAttempts <= 0 || failures < maxAttempts {
+		return 0
+	}
+	duration = time.Duration(
+		float64(s.lockoutDuration) * math.Pow(2, float64(failures-maxAttempts)))
+	if duration <= 0 || duration > s.lockoutMaxDuration {
+		duration = s.lockoutMaxDuration
+	}
+
+	return duration
+}
+
+func getEnvInt(name string, defaultValue int) (value int) {
+	var (
+		value_s string
+		ok      bool
+		err     error
+	)
+
+	if value_s, ok = os.LookupEnv(name); !ok {
+		return defaultValue
+	}
+	if value, err = strconv.Atoi(value_s); err != nil {
+		return defaultValue
+	}
+
+	return value
+}
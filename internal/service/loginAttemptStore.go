@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/database/repositories"
+)
+
+// Storage of the failed sign in attempts.
+type LoginAttemptStore interface {
+	// Count another failed attempt of the key, restarting the count
+	// when both the previous failure & lockout end are older than the window.
+	Fail(ctx context.Context, key string, now time.Time, window time.Duration) (
+		attempt *models.LoginAttemptModel, err error)
+	// Lock the key until given time.
+	Lock(ctx context.Context, key string, lockedUntil time.Time) (err error)
+	// Get the attempts of the keys.
+	GetMany(ctx context.Context, keys []string) (
+		attempts []*models.LoginAttemptModel, err error)
+	// Get the attempts locked at given time.
+	GetLocked(ctx context.Context, now time.Time) (
+		attempts []*models.LoginAttemptModel, err error)
+	// Clear the attempts of the key.
+	Clear(ctx context.Context, key string) (err error)
+	// Clear the attempts of given UID.
+	ClearOne(ctx context.Context, uid primitive.ObjectID) (cleared bool, err error)
+}
+
+// Store the attempts in MongoDB, expired by its TTL index.
+type mongoLoginAttemptStore struct {
+	dbConn *mongo.Database
+}
+
+func NewMongoLoginAttemptStore(dbConn *mongo.Database) (store *mongoLoginAttemptStore) {
+	return &mongoLoginAttemptStore{dbConn: dbConn}
+}
+
+func (s *mongoLoginAttemptStore) Fail(
+	ctx context.Context,
+	key string,
+	now time.Time,
+	window time.Duration,
+) (attempt *models.LoginAttemptModel, err error) {
+
+	return repositories.FailOneLoginAttempt(
+		s.dbConn, ctx, key,
+		primitive.NewDateTimeFromTime(now),
+		primitive.NewDateTimeFromTime(now.Add(-window)),
+		primitive.NewDateTimeFromTime(now.Add(window)))
+}
+
+func (s *mongoLoginAttemptStore) Lock(
+	ctx context.Context,
+	key string,
+	lockedUntil time.Time,
+) (err error) {
+
+	return repositories.LockOneLoginAttempt(
+		s.dbConn, ctx, key, primitive.NewDateTimeFromTime(lockedUntil))
+}
+
+func (s *mongoLoginAttemptStore) GetMany(
+	ctx context.Context,
+	keys []string,
+) (attempts []*models.LoginAttemptModel, err error) {
+
+	return repositories.ReadManyLoginAttempts(
+		s.dbConn, ctx, bson.M{"key": bson.M{"$in": keys}})
+}
+
+func (s *mongoLoginAttemptStore) GetLocked(
+	ctx context.Context,
+	now time.Time,
+) (attempts []*models.LoginAttemptModel, err error) {
+
+	return repositories.ReadManyLoginAttempts(
+		s.dbConn, ctx,
+		bson.M{"lockeduntil": bson.M{"$gt": primitive.NewDateTimeFromTime(now)}},
+		options.Find().SetSort(bson.M{"lockeduntil": -1}))
+}
+
+func (s *mongoLoginAttemptStore) Clear(
+	ctx context.Context,
+	key string,
+) (err error) {
+
+	return repositories.DeleteManyLoginAttempts(
+		s.dbConn, ctx, bson.M{"key": key})
+}
+
+func (s *mongoLoginAttemptStore) ClearOne(
+	ctx context.Context,
+	uid primitive.ObjectID,
+) (cleared bool, err error) {
+	var attempt *models.LoginAttemptModel
+
+	if attempt, err = repositories.ReadOneLoginAttempt(
+		s.dbConn, ctx, bson.M{"_id": uid},
+	); err != nil || attempt == nil {
+		return false, err
+	}
+
+	return true, repositories.DeleteManyLoginAttempts(
+		s.dbConn, ctx, bson.M{"_id": uid})
+}
+
+// Store the attempts in the process memory,
+// only suitable for a single instance setup.
+type memoryLoginAttemptStore struct {
+	mutex    sync.Mutex
+	attempts map[string]*models.LoginAttemptModel
+}
+
+func NewMemoryLoginAttemptStore() (store *memoryLoginAttemptStore) {
+	return &memoryLoginAttemptStore{
+		attempts: map[string]*models.LoginAttemptModel{}}
+}
+
+func (s *memoryLoginAttemptStore) Fail(
+	ctx context.Context,
+	key string,
+	now time.Time,
+	window time.Duration,
+) (attempt *models.LoginAttemptModel, err error) {
+	var (
+		_attempt models.LoginAttemptModel
+		exists   bool
+	)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.removeExpired(now)
+	if attempt, exists = s.attempts[key]; !exists {
+		attempt = &models.LoginAttemptModel{
+			UID: primitive.NewObjectID(),
+			Key: key}
+		s.attempts[key] = attempt
+	}
+	if attempt.LastFailedAt.Time().After(now.Add(-window)) ||
+		attempt.LockedUntil.Time().After(now.Add(-window)) {
+		attempt.Failures++
+	} else {
+		attempt.Failures = 1
+	}
+	attempt.LastFailedAt = primitive.NewDateTimeFromTime(now)
+	attempt.ExpiresAt = primitive.NewDateTimeFromTime(now.Add(window))
+	if attempt.LockedUntil > attempt.ExpiresAt {
+		attempt.ExpiresAt = attempt.LockedUntil
+	}
+	_attempt = *attempt
+
+	return &_attempt, nil
+}
+
+func (s *memoryLoginAttemptStore) Lock(
+	ctx context.Context,
+	key string,
+	lockedUntil time.Time,
+) (err error) {
+	var (
+		attempt *models.LoginAttemptModel
+		exists  bool
+	)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if attempt, exists = s.attempts[key]; !exists {
+		return nil
+	}
+	attempt.LockedUntil = primitive.NewDateTimeFromTime(lockedUntil)
+	if attempt.LockedUntil > attempt.ExpiresAt {
+		attempt.ExpiresAt = attempt.LockedUntil
+	}
+
+	return nil
+}
+
+func (s *memoryLoginAttemptStore) GetMany(
+	ctx context.Context,
+	keys []string,
+) (attempts []*models.LoginAttemptModel, err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.removeExpired(time.Now())
+	for _, key := range keys {
+		if attempt, exists := s.attempts[key]; exists {
+			_attempt := *attempt
+			attempts = append(attempts, &_attempt)
+		}
+	}
+
+	return attempts, nil
+}
+
+func (s *memoryLoginAttemptStore) GetLocked(
+	ctx context.Context,
+	now time.Time,
+) (attempts []*models.LoginAttemptModel, err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.removeExpired(now)
+	for _, attempt := range s.attempts {
+		if attempt.LockedUntil.Time().After(now) {
+			_attempt := *attempt
+			attempts = append(attempts, &_attempt)
+		}
+	}
+	sort.Slice(attempts, func(i, j int) bool {
+		return attempts[i].LockedUntil > attempts[j].LockedUntil
+	})
+
+	return attempts, nil
+}
+
+func (s *memoryLoginAttemptStore) Clear(
+	ctx context.Context,
+	key string,
+) (err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.attempts, key)
+
+	return nil
+}
+
+func (s *memoryLoginAttemptStore) ClearOne(
+	ctx context.Context,
+	uid primitive.ObjectID,
+) (cleared bool, err error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for key, attempt := range s.attempts {
+		if attempt.UID == uid {
+			delete(s.attempts, key)
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (s *memoryLoginAttemptStore) removeExpired(now time.Time) {
+	for key, attempt := range s.attempts {
+		if attempt.ExpiresAt.Time().Before(now) {
+			delete(s.attempts, key)
+		}
+	}
+}
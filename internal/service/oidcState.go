@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/database/repositories"
+)
+
+type oidcState struct {
+	dbConn *mongo.Database
+}
+
+func newOidcStateService(
+	dbConn *mongo.Database,
+) (service *oidcState) {
+
+	return &oidcState{dbConn: dbConn}
+}
+
+// Create new OIDC state
+func (s *oidcState) SaveOne(
+	ctx context.Context,
+	state *models.OidcStateModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	state.UID = primitive.NewObjectID()
+	state.CreatedAt = now
+
+	return repositories.SaveOneOidcState(
+		s.dbConn, ctx, state, opts...)
+}
+
+// Use the unexpired OIDC state of given state hash & provider
+func (s *oidcState) UseOne(
+	ctx context.Context,
+	provider string,
+	stateHash string,
+) (state *models.OidcStateModel, err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	return repositories.TakeOneOidcState(
+		s.dbConn, ctx, bson.M{
+			"$and": []bson.M{
+				{"statehash": bson.M{"$eq": stateHash}},
+				{"provider": bson.M{"$eq": provider}},
+				{"expiresat": bson.M{"$gt": now}}}})
+}
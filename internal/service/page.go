@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/hibiken/asynq"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -11,18 +14,29 @@ import (
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
 	"github.com/misterabdul/goblog-server/internal/database/repositories"
+	"github.com/misterabdul/goblog-server/internal/queue"
+	"github.com/misterabdul/goblog-server/internal/queue/client"
+	"github.com/misterabdul/goblog-server/internal/queue/payloads"
+	"github.com/misterabdul/goblog-server/pkg/diff"
 	customMongo "github.com/misterabdul/goblog-server/pkg/mongo"
 )
 
 type page struct {
-	dbConn *mongo.Database
+	dbConn      *mongo.Database
+	queueClient *client.QueueClient
+
+	revisionRetention int
 }
 
 func newPageService(
 	dbConn *mongo.Database,
+	queueClient *client.QueueClient,
 ) (service *page) {
 
-	return &page{dbConn: dbConn}
+	return &page{
+		dbConn:            dbConn,
+		queueClient:       queueClient,
+		revisionRetention: getEnvInt("REVISION_RETENTION", 50)}
 }
 
 // Get single page
@@ -79,7 +93,7 @@ func (s *page) Count(
 		s.dbConn, ctx, filter, opts...)
 }
 
-// Create new page with its content
+// Create new page with its content, snapshotted as the first revision
 func (s *page) SaveOneWithContent(
 	ctx context.Context,
 	page *models.PageModel,
@@ -93,6 +107,7 @@ func (s *page) SaveOneWithContent(
 	page.UpdatedAt = now
 	page.DeletedAt = nil
 	content.UID = page.UID
+	page.Analyze(content.Render())
 
 	return customMongo.Transaction(ctx, s.dbConn, false,
 		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
@@ -107,7 +122,8 @@ func (s *page) SaveOneWithContent(
 				return sErr
 			}
 
-			return nil
+			return repositories.SaveOnePageRevision(
+				dbConn, sCtx, newPageRevision(page, content, page.Author, 1, nil))
 		})
 }
 
@@ -119,53 +135,119 @@ func (s *page) PublishOne(
 ) (err error) {
 	var now = primitive.NewDateTimeFromTime(time.Now())
 
+	page.PublishAt = nil
 	page.PublishedAt = now
 
 	return repositories.UpdateOnePage(
 		s.dbConn, ctx, page, opts...)
 }
 
-// Remove published mark from the page
+// Remove published mark & schedule from the page
 func (s *page) DepublishOne(
 	ctx context.Context,
 	page *models.PageModel,
 	opts ...*options.UpdateOptions,
 ) (err error) {
+	page.PublishAt = nil
 	page.PublishedAt = nil
 
 	return repositories.UpdateOnePage(
 		s.dbConn, ctx, page, opts...)
 }
 
-// Update page
+// Schedule the page to be published by the worker at given time,
+// replacing its previous schedule. The schedule is saved only after
+// the task is enqueued, the task is ignored when it's not saved.
+func (s *page) ScheduleOne(
+	ctx context.Context,
+	page *models.PageModel,
+	publishAt time.Time,
+) (err error) {
+	var (
+		payload           *payloads.PublishScheduledPagePayload
+		publishAtDateTime = primitive.NewDateTimeFromTime(publishAt)
+	)
+
+	page.PublishAt = publishAtDateTime
+	if payload, err = payloads.NewPublishScheduledPagePayload(*page); err != nil {
+		return err
+	}
+	if err = s.queueClient.NewTask(
+		queue.PublishScheduledPage,
+		payload,
+		asynq.ProcessAt(publishAt),
+		asynq.TaskID(fmt.Sprintf("%s:%s:%d",
+			queue.PublishScheduledPage, page.UID.Hex(), int64(publishAtDateTime))),
+	); err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return err
+	}
+
+	return repositories.UpdateOnePage(
+		s.dbConn, ctx, page)
+}
+
+// Cancel the page's schedule, its already enqueued task is ignored
+func (s *page) UnscheduleOne(
+	ctx context.Context,
+	page *models.PageModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	page.PublishAt = nil
+
+	return repositories.UpdateOnePage(
+		s.dbConn, ctx, page, opts...)
+}
+
+// Publish the page if it's still scheduled at given time, so the tasks
+// of the rescheduled or cancelled schedule are safely ignored
+func (s *page) PublishScheduledOne(
+	ctx context.Context,
+	pageUid primitive.ObjectID,
+	publishAt primitive.DateTime,
+) (published bool, err error) {
+
+	return repositories.PublishOneScheduledPage(
+		s.dbConn, ctx, bson.M{
+			"$and": []bson.M{
+				{"_id": bson.M{"$eq": pageUid}},
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"publishedat": bson.M{"$eq": primitive.Null{}}},
+				{"publishat": bson.M{"$eq": publishAt}}}},
+		publishAt)
+}
+
+// Update page, snapshotted as a new revision of the editor
+// in the same transaction
 func (s *page) UpdateOneWithContent(
 	ctx context.Context,
 	page *models.PageModel,
 	content *models.PageContentModel,
-	opts ...*options.UpdateOptions,
+	editor *models.UserModel,
 ) (err error) {
-	var now = primitive.NewDateTimeFromTime(time.Now())
 
-	page.UpdatedAt = now
+	return s.updateOneWithContent(ctx, page, content, editor, 0)
+}
 
+// Update page's author
+func (s *page) UpdateManyAuthor(
+	ctx context.Context,
+	author *models.UserModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
 	return customMongo.Transaction(ctx, s.dbConn, false,
 		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
-			if sErr = repositories.UpdateOnePage(
-				dbConn, sCtx, page, opts...,
-			); sErr != nil {
-				return sErr
-			}
-			if sErr = repositories.UpdateOnePageContent(
-				dbConn, sCtx, content, opts...,
+			if sErr = repositories.UpdateManyPageAuthor(
+				dbConn, sCtx, author,
 			); sErr != nil {
 				return sErr
 			}
 
-			return nil
+			return repositories.UpdateManyPageRevisionEditor(
+				dbConn, sCtx, author.UID, author.ToCommonModel())
 		})
 }
 
-// Delete page to trash
+// Delete page to trash, cancelling its schedule
 func (s *page) TrashOne(
 	ctx context.Context,
 	page *models.PageModel,
@@ -173,6 +255,7 @@ func (s *page) TrashOne(
 ) (err error) {
 	var now = primitive.NewDateTimeFromTime(time.Now())
 
+	page.PublishAt = nil
 	page.DeletedAt = now
 
 	return repositories.UpdateOnePage(
@@ -191,7 +274,7 @@ func (s *page) RestoreOne(
 		s.dbConn, ctx, page, opts...)
 }
 
-// Permanently delete page
+// Permanently delete page with its revisions
 func (s *page) DeleteOneWithContent(
 	ctx context.Context,
 	page *models.PageModel,
@@ -211,7 +294,210 @@ func (s *page) DeleteOneWithContent(
 			); sErr != nil {
 				return sErr
 			}
+			if sErr = repositories.DeleteManyPageRevisions(
+				dbConn, sCtx, bson.M{"pageuid": bson.M{"$eq": page.UID}},
+			); sErr != nil {
+				return sErr
+			}
 
 			return nil
 		})
 }
+
+// Get single revision of the page
+func (s *page) GetOneRevision(
+	ctx context.Context,
+	page *models.PageModel,
+	revisionUid primitive.ObjectID,
+) (revision *models.PageRevisionModel, err error) {
+
+	return repositories.ReadOnePageRevision(
+		s.dbConn, ctx, bson.M{
+			"$and": []bson.M{
+				{"pageuid": bson.M{"$eq": page.UID}},
+				{"_id": bson.M{"$eq": revisionUid}}}})
+}
+
+// Get multiple revisions of the page
+func (s *page) GetManyRevisions(
+	ctx context.Context,
+	page *models.PageModel,
+	opts ...*options.FindOptions,
+) (revisions []*models.PageRevisionModel, err error) {
+
+	return repositories.ReadManyPageRevisions(
+		s.dbConn, ctx, bson.M{"pageuid": bson.M{"$eq": page.UID}}, opts...)
+}
+
+// Get total revisions count of the page
+func (s *page) CountRevisions(
+	ctx context.Context,
+	page *models.PageModel,
+	opts ...*options.CountOptions,
+) (count int64, err error) {
+
+	return repositories.CountPageRevisions(
+		s.dbConn, ctx, bson.M{"pageuid": bson.M{"$eq": page.UID}}, opts...)
+}
+
+// Get the unified diff between both revisions
+func (s *page) DiffRevisions(
+	from *models.PageRevisionModel,
+	to *models.PageRevisionModel,
+) (unified string) {
+
+	return diff.Unified(
+		fmt.Sprintf("revision %d", from.Number),
+		fmt.Sprintf("revision %d", to.Number),
+		pageRevisionText(from),
+		pageRevisionText(to),
+		diff.DefaultContext)
+}
+
+// Bring the page back to the revision's state as a new update of the editor
+func (s *page) RestoreRevisionOne(
+	ctx context.Context,
+	page *models.PageModel,
+	content *models.PageContentModel,
+	revision *models.PageRevisionModel,
+	editor *models.UserModel,
+) (err error) {
+	page.Slug = revision.Slug
+	page.Title = revision.Title
+	content.Content = revision.Content
+
+	return s.updateOneWithContent(ctx, page, content, editor, revision.Number)
+}
+
+func (s *page) updateOneWithContent(
+	ctx context.Context,
+	page *models.PageModel,
+	content *models.PageContentModel,
+	editor *models.UserModel,
+	restoredFrom int,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	page.UpdatedAt = now
+	page.Analyze(content.Render())
+
+	return customMongo.Transaction(ctx, s.dbConn, false,
+		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
+			var (
+				storedPage    *models.PageModel
+				storedContent *models.PageContentModel
+				previous      *models.PageRevisionModel
+				revision      *models.PageRevisionModel
+				number        = 1
+			)
+
+			if storedPage, sErr = repositories.ReadOnePage(
+				dbConn, sCtx, bson.M{"_id": bson.M{"$eq": page.UID}},
+			); sErr != nil {
+				return sErr
+			}
+			if storedContent, sErr = repositories.ReadOnePageContent(
+				dbConn, sCtx, bson.M{"_id": bson.M{"$eq": page.UID}},
+			); sErr != nil {
+				return sErr
+			}
+			if previous, sErr = repositories.ReadOnePageRevision(
+				dbConn, sCtx, bson.M{"pageuid": bson.M{"$eq": page.UID}},
+				options.FindOne().SetSort(bson.M{"number": -1}),
+			); sErr != nil {
+				return sErr
+			}
+			if previous != nil {
+				number = previous.Number + 1
+			} else if storedPage != nil && storedContent != nil {
+				// The page saved before revisions existed gets its stored state
+				// as the first revision, so the overwritten state is kept.
+				previous = newPageRevision(
+					storedPage, storedContent, storedPage.Author, number, nil)
+				if sErr = repositories.SaveOnePageRevision(
+					dbConn, sCtx, previous,
+				); sErr != nil {
+					return sErr
+				}
+				number++
+			}
+			if sErr = repositories.UpdateOnePage(
+				dbConn, sCtx, page,
+			); sErr != nil {
+				return sErr
+			}
+			if sErr = repositories.UpdateOnePageContent(
+				dbConn, sCtx, content,
+			); sErr != nil {
+				return sErr
+			}
+			revision = newPageRevision(
+				page, content, editor.ToCommonModel(), number, previous)
+			revision.RestoredFrom = restoredFrom
+			if sErr = repositories.SaveOnePageRevision(
+				dbConn, sCtx, revision,
+			); sErr != nil {
+				return sErr
+			}
+			if s.revisionRetention <= 0 || number <= s.revisionRetention {
+				return nil
+			}
+
+			return repositories.DeleteManyPageRevisions(
+				dbConn, sCtx, bson.M{
+					"$and": []bson.M{
+						{"pageuid": bson.M{"$eq": page.UID}},
+						{"number": bson.M{"$lte": number - s.revisionRetention}}}})
+		})
+}
+
+// Snapshot the page & its content as the given numbered revision,
+// the changed fields are taken against the previous revision when given
+func newPageRevision(
+	page *models.PageModel,
+	content *models.PageContentModel,
+	editor models.UserCommonModel,
+	number int,
+	previous *models.PageRevisionModel,
+) (revision *models.PageRevisionModel) {
+	revision = &models.PageRevisionModel{
+		UID:           primitive.NewObjectID(),
+		PageUID:       page.UID,
+		Number:        number,
+		Slug:          page.Slug,
+		Title:         page.Title,
+		Content:       content.Content,
+		ChangedFields: []string{},
+		Editor:        editor,
+		CreatedAt:     primitive.NewDateTimeFromTime(time.Now())}
+	if previous != nil {
+		revision.ChangedFields = pageChangedFields(previous, revision)
+	}
+
+	return revision
+}
+
+func pageChangedFields(
+	from *models.PageRevisionModel,
+	to *models.PageRevisionModel,
+) (changedFields []string) {
+	changedFields = []string{}
+	if from.Slug != to.Slug {
+		changedFields = append(changedFields, "slug")
+	}
+	if from.Title != to.Title {
+		changedFields = append(changedFields, "title")
+	}
+	if from.Content != to.Content {
+		changedFields = append(changedFields, "content")
+	}
+
+	return changedFields
+}
+
+func pageRevisionText(revision *models.PageRevisionModel) (text string) {
+
+	return "Slug: " + revision.Slug + "\n" +
+		"Title: " + revision.Title + "\n" +
+		"\n" + revision.Content
+}
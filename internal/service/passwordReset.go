@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/database/repositories"
+	customMongo "github.com/misterabdul/goblog-server/pkg/mongo"
+)
+
+type passwordReset struct {
+	dbConn *mongo.Database
+}
+
+func newPasswordResetService(
+	dbConn *mongo.Database,
+) (service *passwordReset) {
+
+	return &passwordReset{dbConn: dbConn}
+}
+
+// Get single password reset
+func (s *passwordReset) GetOne(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (reset *models.PasswordResetModel, err error) {
+
+	return repositories.ReadOnePasswordReset(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Create new password reset, replacing the owner's previous ones
+func (s *passwordReset) SaveOne(
+	ctx context.Context,
+	reset *models.PasswordResetModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	reset.UID = primitive.NewObjectID()
+	reset.UsedAt = nil
+	reset.CreatedAt = now
+
+	return customMongo.Transaction(ctx, s.dbConn, false,
+		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
+			if sErr = repositories.DeleteManyPasswordResets(
+				dbConn, sCtx, bson.M{"owner._id": reset.Owner.UID},
+			); sErr != nil {
+				return sErr
+			}
+			if sErr = repositories.SaveOnePasswordReset(
+				dbConn, sCtx, reset, opts...,
+			); sErr != nil {
+				return sErr
+			}
+
+			return nil
+		})
+}
+
+// Mark the unused & unexpired password reset of given token hash used
+func (s *passwordReset) UseOne(
+	ctx context.Context,
+	tokenHash string,
+) (reset *models.PasswordResetModel, err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	return repositories.UseOnePasswordReset(
+		s.dbConn, ctx, bson.M{
+			"$and": []bson.M{
+				{"tokenhash": bson.M{"$eq": tokenHash}},
+				{"usedat": bson.M{"$eq": primitive.Null{}}},
+				{"expiresat": bson.M{"$gt": now}}}},
+		now)
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/database/repositories"
+	"github.com/misterabdul/goblog-server/pkg/crypto"
+	"github.com/misterabdul/goblog-server/pkg/hash"
+)
+
+// Prefix telling personal tokens apart from the JWT access tokens.
+const PersonalTokenPrefix = "gbp_"
+
+type personalToken struct {
+	dbConn *mongo.Database
+}
+
+func newPersonalTokenService(
+	dbConn *mongo.Database,
+) (service *personalToken) {
+
+	return &personalToken{dbConn: dbConn}
+}
+
+// Check whether the bearer token looks like a personal token
+func (s *personalToken) IsPersonalToken(token string) (isPersonal bool) {
+	return strings.HasPrefix(token, PersonalTokenPrefix)
+}
+
+// Get single personal token
+func (s *personalToken) GetOne(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (token *models.PersonalTokenModel, err error) {
+
+	return repositories.ReadOnePersonalToken(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Get the unexpired personal token of given plain token
+func (s *personalToken) GetOneByToken(
+	ctx context.Context,
+	plainToken string,
+) (token *models.PersonalTokenModel, err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	return repositories.ReadOnePersonalToken(
+		s.dbConn, ctx, bson.M{
+			"$and": []bson.M{
+				{"tokenhash": bson.M{"$eq": hash.HashToken(plainToken)}},
+				{"expiresat": bson.M{"$gt": now}}}})
+}
+
+// Get multiple personal tokens
+func (s *personalToken) GetMany(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (tokens []*models.PersonalTokenModel, err error) {
+
+	return repositories.ReadManyPersonalTokens(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Create new personal token, the plain token is only returned here
+func (s *personalToken) CreateOne(
+	ctx context.Context,
+	token *models.PersonalTokenModel,
+	opts ...*options.InsertOneOptions,
+) (plainToken string, err error) {
+	var (
+		now    = primitive.NewDateTimeFromTime(time.Now())
+		secret string
+	)
+
+	if secret, err = crypto.GenerateRandomStringURLSafe(32); err != nil {
+		return "", err
+	}
+	plainToken = PersonalTokenPrefix + secret
+	token.TokenHash = hash.HashToken(plainToken)
+	token.Hint = plainToken[len(plainToken)-4:]
+	token.UID = primitive.NewObjectID()
+	token.LastUsedAt = nil
+	token.CreatedAt = now
+	token.UpdatedAt = now
+
+	if err = repositories.SaveOnePersonalToken(
+		s.dbConn, ctx, token, opts...,
+	); err != nil {
+		return "", err
+	}
+
+	return plainToken, nil
+}
+
+// Mark the personal token used just now
+func (s *personalToken) TouchOne(
+	ctx context.Context,
+	token *models.PersonalTokenModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	token.LastUsedAt = now
+
+	return repositories.UpdateOnePersonalToken(
+		s.dbConn, ctx, token, opts...)
+}
+
+// Permanently delete personal token
+func (s *personalToken) DeleteOne(
+	ctx context.Context,
+	token *models.PersonalTokenModel,
+	opts ...*options.DeleteOptions,
+) (err error) {
+
+	return repositories.DeleteOnePersonalToken(
+		s.dbConn, ctx, token, opts...)
+}
+
+// Permanently delete multiple personal tokens
+func (s *personalToken) DeleteMany(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.DeleteOptions,
+) (err error) {
+
+	return repositories.DeleteManyPersonalTokens(
+		s.dbConn, ctx, filter, opts...)
+}
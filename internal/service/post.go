@@ -2,8 +2,13 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/hibiken/asynq"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -11,18 +16,62 @@ import (
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
 	"github.com/misterabdul/goblog-server/internal/database/repositories"
+	"github.com/misterabdul/goblog-server/internal/queue"
+	"github.com/misterabdul/goblog-server/internal/queue/client"
+	"github.com/misterabdul/goblog-server/internal/queue/payloads"
+	"github.com/misterabdul/goblog-server/pkg/diff"
 	customMongo "github.com/misterabdul/goblog-server/pkg/mongo"
 )
 
+const (
+	// Writers publish their own posts once approved by an editor.
+	PostPublishPolicyReview = "review"
+	// Writers publish their own posts directly.
+	PostPublishPolicyDirect = "direct"
+)
+
+var ErrPostNotApproved = errors.New("post needs to be approved by an editor before publishing")
+
+// Filter the posts credited to the user as their author or co-author
+func PostAuthoredByFilter(userUid primitive.ObjectID) (filter bson.M) {
+
+	return bson.M{"$or": []bson.M{
+		{"author._id": bson.M{"$eq": userUid}},
+		{"contributors": bson.M{"$elemMatch": bson.M{
+			"_id": bson.M{"$eq": userUid},
+			"role": bson.M{"$in": bson.A{
+				models.PostContributorRoleAuthor,
+				models.PostContributorRoleCoAuthor}}}}}}}
+}
+
 type post struct {
-	dbConn *mongo.Database
+	dbConn      *mongo.Database
+	queueClient *client.QueueClient
+
+	revisionRetention int
+	publishPolicy     string
+	related           relatedPostsConfig
 }
 
 func newPostService(
 	dbConn *mongo.Database,
+	queueClient *client.QueueClient,
 ) (service *post) {
+	var (
+		policy string
+		ok     bool
+	)
+
+	if policy, ok = os.LookupEnv("POST_PUBLISH_POLICY"); !ok || policy != PostPublishPolicyDirect {
+		policy = PostPublishPolicyReview
+	}
 
-	return &post{dbConn: dbConn}
+	return &post{
+		dbConn:            dbConn,
+		queueClient:       queueClient,
+		revisionRetention: getEnvInt("REVISION_RETENTION", 50),
+		publishPolicy:     policy,
+		related:           newRelatedPostsConfig()}
 }
 
 // Get single post
@@ -81,7 +130,7 @@ func (s *post) Count(
 		s.dbConn, ctx, filter, opts...)
 }
 
-// Create new post with its content
+// Create new post with its content, snapshotted as the first revision
 func (s *post) SaveOneWithContent(
 	ctx context.Context,
 	post *models.PostModel,
@@ -95,6 +144,10 @@ func (s *post) SaveOneWithContent(
 	post.UpdatedAt = now
 	post.DeletedAt = nil
 	content.UID = post.UID
+	post.Analyze(content.Render())
+	if post.PublishedAt != nil {
+		transitPost(post, models.PostStatusPublished, &post.Author, "")
+	}
 
 	return customMongo.Transaction(ctx, s.dbConn, false,
 		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
@@ -109,63 +162,201 @@ func (s *post) SaveOneWithContent(
 				return sErr
 			}
 
-			return nil
+			return repositories.SaveOnePostRevision(
+				dbConn, sCtx, newPostRevision(post, content, post.Author, 1, nil))
 		})
+}
+
+// Replace the post's contributors, keeping its author as the first one
+func (s *post) UpdateOneContributors(
+	ctx context.Context,
+	post *models.PostModel,
+	contributors []models.PostContributorModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	post.Contributors = append([]models.PostContributorModel{{
+		UserCommonModel: post.Author,
+		Role:            models.PostContributorRoleAuthor}}, contributors...)
+	post.UpdatedAt = now
+
+	return repositories.UpdateOnePost(
+		s.dbConn, ctx, post, opts...)
+}
 
+// Check whether the post's author may publish it by themself,
+// which needs the editor's approval under the review policy
+func (s *post) IsPublishableByAuthor(post *models.PostModel) (publishable bool) {
+
+	return s.publishPolicy == PostPublishPolicyDirect ||
+		post.GetStatus() == models.PostStatusApproved
+}
+
+// Check whether the editor's update of the unpublished post moves it
+// back to submitted, the approval doesn't cover the author's later changes
+func (s *post) IsApprovalResetBy(
+	post *models.PostModel,
+	editor *models.UserModel,
+) (reset bool) {
+
+	return s.publishPolicy == PostPublishPolicyReview &&
+		post.PublishedAt == nil &&
+		post.GetStatus() == models.PostStatusApproved &&
+		post.IsAuthoredBy(editor.UID)
 }
 
 // Mark the post published
 func (s *post) PublishOne(
 	ctx context.Context,
 	post *models.PostModel,
+	actor *models.UserModel,
 	opts ...*options.UpdateOptions,
 ) (err error) {
-	var now = primitive.NewDateTimeFromTime(time.Now())
+	var (
+		now         = primitive.NewDateTimeFromTime(time.Now())
+		actorCommon = actor.ToCommonModel()
+	)
 
+	transitPost(post, models.PostStatusPublished, &actorCommon, "")
+	post.PublishAt = nil
 	post.PublishedAt = now
 
 	return repositories.UpdateOnePost(
 		s.dbConn, ctx, post, opts...)
 }
 
-// Remove published mark from the post
+// Remove published mark & schedule from the post, back to draft
 func (s *post) DepublishOne(
 	ctx context.Context,
 	post *models.PostModel,
+	actor *models.UserModel,
 	opts ...*options.UpdateOptions,
 ) (err error) {
+	var actorCommon = actor.ToCommonModel()
+
+	transitPost(post, models.PostStatusDraft, &actorCommon, "")
+	post.PublishAt = nil
 	post.PublishedAt = nil
 
 	return repositories.UpdateOnePost(
 		s.dbConn, ctx, post, opts...)
 }
 
-// Update post
-func (s *post) UpdateOneWithContent(
+// Move the unpublished post through the review with the actor's note,
+// requesting changes also cancels its schedule
+func (s *post) TransitOne(
 	ctx context.Context,
 	post *models.PostModel,
-	content *models.PostContentModel,
+	status string,
+	actor *models.UserModel,
+	note string,
 	opts ...*options.UpdateOptions,
 ) (err error) {
-	var now = primitive.NewDateTimeFromTime(time.Now())
+	var actorCommon = actor.ToCommonModel()
 
-	post.UpdatedAt = now
+	if !post.CanTransitTo(status) || status == models.PostStatusPublished {
+		return fmt.Errorf("unable to move %s post to %s", post.GetStatus(), status)
+	}
+	transitPost(post, status, &actorCommon, note)
+	if status == models.PostStatusChangesRequested {
+		post.PublishAt = nil
+	}
 
-	return customMongo.Transaction(ctx, s.dbConn, false,
-		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
-			if sErr = repositories.UpdateOnePost(
-				dbConn, sCtx, post,
-			); sErr != nil {
-				return sErr
-			}
-			if sErr = repositories.UpdateOnePostContent(
-				dbConn, sCtx, content,
-			); sErr != nil {
-				return sErr
-			}
+	return repositories.UpdateOnePost(
+		s.dbConn, ctx, post, opts...)
+}
 
-			return nil
-		})
+// Schedule the post to be published by the worker at given time,
+// replacing its previous schedule. The schedule is saved only after
+// the task is enqueued, the task is ignored when it's not saved.
+// Under the review policy the post must be approved, unless it's
+// scheduled by the approver, e.g. an editor, whose schedule approves it.
+func (s *post) ScheduleOne(
+	ctx context.Context,
+	post *models.PostModel,
+	publishAt time.Time,
+	approver *models.UserModel,
+) (err error) {
+	var (
+		payload           *payloads.PublishScheduledPostPayload
+		publishAtDateTime = primitive.NewDateTimeFromTime(publishAt)
+	)
+
+	if !s.IsPublishableByAuthor(post) {
+		if approver == nil {
+			return ErrPostNotApproved
+		}
+		approverCommon := approver.ToCommonModel()
+		transitPost(post, models.PostStatusApproved, &approverCommon, "scheduled")
+	}
+	post.PublishAt = publishAtDateTime
+	if payload, err = payloads.NewPublishScheduledPostPayload(*post); err != nil {
+		return err
+	}
+	if err = s.queueClient.NewTask(
+		queue.PublishScheduledPost,
+		payload,
+		asynq.ProcessAt(publishAt),
+		asynq.TaskID(fmt.Sprintf("%s:%s:%d",
+			queue.PublishScheduledPost, post.UID.Hex(), int64(publishAtDateTime))),
+	); err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return err
+	}
+
+	return repositories.UpdateOnePost(
+		s.dbConn, ctx, post)
+}
+
+// Cancel the post's schedule, its already enqueued task is ignored
+func (s *post) UnscheduleOne(
+	ctx context.Context,
+	post *models.PostModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	post.PublishAt = nil
+
+	return repositories.UpdateOnePost(
+		s.dbConn, ctx, post, opts...)
+}
+
+// Publish the post if it's still scheduled at given time & still approved
+// under the review policy, so the tasks of the rescheduled, cancelled
+// or resubmitted schedule are safely ignored
+func (s *post) PublishScheduledOne(
+	ctx context.Context,
+	postUid primitive.ObjectID,
+	publishAt primitive.DateTime,
+) (published bool, err error) {
+	var filter = []bson.M{
+		{"_id": bson.M{"$eq": postUid}},
+		{"deletedat": bson.M{"$eq": primitive.Null{}}},
+		{"publishedat": bson.M{"$eq": primitive.Null{}}},
+		{"publishat": bson.M{"$eq": publishAt}}}
+
+	if s.publishPolicy == PostPublishPolicyReview {
+		filter = append(filter, bson.M{"status": bson.M{"$eq": models.PostStatusApproved}})
+	}
+
+	return repositories.PublishOneScheduledPost(
+		s.dbConn, ctx, bson.M{"$and": filter},
+		publishAt, models.PostTransitionModel{
+			To:        models.PostStatusPublished,
+			Note:      "scheduled",
+			Actor:     nil,
+			CreatedAt: primitive.NewDateTimeFromTime(time.Now())})
+}
+
+// Update post, snapshotted as a new revision of the editor
+// in the same transaction
+func (s *post) UpdateOneWithContent(
+	ctx context.Context,
+	post *models.PostModel,
+	content *models.PostContentModel,
+	editor *models.UserModel,
+) (err error) {
+
+	return s.updateOneWithContent(ctx, post, content, editor, 0)
 }
 
 // Update post's author
@@ -182,11 +373,18 @@ func (s *post) UpdateManyAuthor(
 				return sErr
 			}
 
-			return nil
+			if sErr = repositories.UpdateManyPostContributor(
+				dbConn, sCtx, author.UID, author.ToCommonModel(),
+			); sErr != nil {
+				return sErr
+			}
+
+			return repositories.UpdateManyPostRevisionEditor(
+				dbConn, sCtx, author.UID, author.ToCommonModel())
 		})
 }
 
-// Delete post to trash
+// Delete post to trash, cancelling its schedule
 func (s *post) TrashOne(
 	ctx context.Context,
 	post *models.PostModel,
@@ -194,6 +392,7 @@ func (s *post) TrashOne(
 ) (err error) {
 	var now = primitive.NewDateTimeFromTime(time.Now())
 
+	post.PublishAt = nil
 	post.DeletedAt = now
 
 	return repositories.UpdateOnePost(
@@ -212,7 +411,7 @@ func (s *post) RestoreOne(
 		s.dbConn, ctx, post, opts...)
 }
 
-// Permanently delete post
+// Permanently delete post with its revisions
 func (s *post) DeleteOneWithContent(
 	ctx context.Context,
 	post *models.PostModel,
@@ -232,7 +431,293 @@ func (s *post) DeleteOneWithContent(
 			); sErr != nil {
 				return sErr
 			}
+			if sErr = repositories.DeleteManyPostRevisions(
+				dbConn, sCtx, bson.M{"postuid": bson.M{"$eq": post.UID}},
+			); sErr != nil {
+				return sErr
+			}
+			if sErr = repositories.PullManySeriesPost(
+				dbConn, sCtx, post.UID, primitive.NewDateTimeFromTime(time.Now()),
+			); sErr != nil {
+				return sErr
+			}
+			if sErr = invalidateRelatedPosts(
+				dbConn, sCtx, post.UID,
+			); sErr != nil {
+				return sErr
+			}
 
 			return nil
 		})
 }
+
+// Get single revision of the post
+func (s *post) GetOneRevision(
+	ctx context.Context,
+	post *models.PostModel,
+	revisionUid primitive.ObjectID,
+) (revision *models.PostRevisionModel, err error) {
+
+	return repositories.ReadOnePostRevision(
+		s.dbConn, ctx, bson.M{
+			"$and": []bson.M{
+				{"postuid": bson.M{"$eq": post.UID}},
+				{"_id": bson.M{"$eq": revisionUid}}}})
+}
+
+// Get multiple revisions of the post
+func (s *post) GetManyRevisions(
+	ctx context.Context,
+	post *models.PostModel,
+	opts ...*options.FindOptions,
+) (revisions []*models.PostRevisionModel, err error) {
+
+	return repositories.ReadManyPostRevisions(
+		s.dbConn, ctx, bson.M{"postuid": bson.M{"$eq": post.UID}}, opts...)
+}
+
+// Get total revisions count of the post
+func (s *post) CountRevisions(
+	ctx context.Context,
+	post *models.PostModel,
+	opts ...*options.CountOptions,
+) (count int64, err error) {
+
+	return repositories.CountPostRevisions(
+		s.dbConn, ctx, bson.M{"postuid": bson.M{"$eq": post.UID}}, opts...)
+}
+
+// Get the unified diff between both revisions
+func (s *post) DiffRevisions(
+	from *models.PostRevisionModel,
+	to *models.PostRevisionModel,
+) (unified string) {
+
+	return diff.Unified(
+		fmt.Sprintf("revision %d", from.Number),
+		fmt.Sprintf("revision %d", to.Number),
+		postRevisionText(from),
+		postRevisionText(to),
+		diff.DefaultContext)
+}
+
+// Bring the post back to the revision's state as a new update of the editor
+func (s *post) RestoreRevisionOne(
+	ctx context.Context,
+	post *models.PostModel,
+	content *models.PostContentModel,
+	revision *models.PostRevisionModel,
+	editor *models.UserModel,
+) (err error) {
+	post.Slug = revision.Slug
+	post.Title = revision.Title
+	post.FeaturingImagePath = revision.FeaturingImagePath
+	post.Description = revision.Description
+	post.Categories = revision.Categories
+	post.Tags = revision.Tags
+	content.Content = revision.Content
+
+	return s.updateOneWithContent(ctx, post, content, editor, revision.Number)
+}
+
+func (s *post) updateOneWithContent(
+	ctx context.Context,
+	post *models.PostModel,
+	content *models.PostContentModel,
+	editor *models.UserModel,
+	restoredFrom int,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	post.UpdatedAt = now
+	post.Analyze(content.Render())
+
+	return customMongo.Transaction(ctx, s.dbConn, false,
+		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
+			var (
+				storedPost    *models.PostModel
+				storedContent *models.PostContentModel
+				previous      *models.PostRevisionModel
+				revision      *models.PostRevisionModel
+				number        = 1
+			)
+
+			if storedPost, sErr = repositories.ReadOnePost(
+				dbConn, sCtx, bson.M{"_id": bson.M{"$eq": post.UID}},
+			); sErr != nil {
+				return sErr
+			}
+			if storedPost != nil && storedPost.PublishedAt == nil {
+				var editorCommon = editor.ToCommonModel()
+
+				switch {
+				case post.PublishedAt != nil:
+					transitPost(post, models.PostStatusPublished, &editorCommon, "")
+				case s.IsApprovalResetBy(storedPost, editor):
+					// The approval doesn't cover the author's later changes,
+					// neither its schedule.
+					transitPost(post, models.PostStatusSubmitted, &editorCommon, "updated after approval")
+					post.PublishAt = nil
+				}
+			}
+			if storedContent, sErr = repositories.ReadOnePostContent(
+				dbConn, sCtx, bson.M{"_id": bson.M{"$eq": post.UID}},
+			); sErr != nil {
+				return sErr
+			}
+			if previous, sErr = repositories.ReadOnePostRevision(
+				dbConn, sCtx, bson.M{"postuid": bson.M{"$eq": post.UID}},
+				options.FindOne().SetSort(bson.M{"number": -1}),
+			); sErr != nil {
+				return sErr
+			}
+			if previous != nil {
+				number = previous.Number + 1
+			} else if storedPost != nil && storedContent != nil {
+				// The post saved before revisions existed gets its stored state
+				// as the first revision, so the overwritten state is kept.
+				previous = newPostRevision(
+					storedPost, storedContent, storedPost.Author, number, nil)
+				if sErr = repositories.SaveOnePostRevision(
+					dbConn, sCtx, previous,
+				); sErr != nil {
+					return sErr
+				}
+				number++
+			}
+			if sErr = repositories.UpdateOnePost(
+				dbConn, sCtx, post,
+			); sErr != nil {
+				return sErr
+			}
+			if storedPost != nil && postRelationChanged(storedPost, post) {
+				if sErr = invalidateRelatedPosts(
+					dbConn, sCtx, post.UID,
+				); sErr != nil {
+					return sErr
+				}
+			}
+			if sErr = repositories.UpdateOnePostContent(
+				dbConn, sCtx, content,
+			); sErr != nil {
+				return sErr
+			}
+			revision = newPostRevision(
+				post, content, editor.ToCommonModel(), number, previous)
+			revision.RestoredFrom = restoredFrom
+			if sErr = repositories.SaveOnePostRevision(
+				dbConn, sCtx, revision,
+			); sErr != nil {
+				return sErr
+			}
+			if s.revisionRetention <= 0 || number <= s.revisionRetention {
+				return nil
+			}
+
+			return repositories.DeleteManyPostRevisions(
+				dbConn, sCtx, bson.M{
+					"$and": []bson.M{
+						{"postuid": bson.M{"$eq": post.UID}},
+						{"number": bson.M{"$lte": number - s.revisionRetention}}}})
+		})
+}
+
+// Change the post's status, kept in its transitions
+func transitPost(
+	post *models.PostModel,
+	status string,
+	actor *models.UserCommonModel,
+	note string,
+) {
+	post.Transitions = append(post.Transitions, models.PostTransitionModel{
+		From:      post.GetStatus(),
+		To:        status,
+		Note:      note,
+		Actor:     actor,
+		CreatedAt: primitive.NewDateTimeFromTime(time.Now())})
+	post.Status = status
+}
+
+// Snapshot the post & its content as the given numbered revision,
+// the changed fields are taken against the previous revision when given
+func newPostRevision(
+	post *models.PostModel,
+	content *models.PostContentModel,
+	editor models.UserCommonModel,
+	number int,
+	previous *models.PostRevisionModel,
+) (revision *models.PostRevisionModel) {
+	revision = &models.PostRevisionModel{
+		UID:                primitive.NewObjectID(),
+		PostUID:            post.UID,
+		Number:             number,
+		Slug:               post.Slug,
+		Title:              post.Title,
+		FeaturingImagePath: post.FeaturingImagePath,
+		Description:        post.Description,
+		Categories:         post.Categories,
+		Tags:               post.Tags,
+		Content:            content.Content,
+		ChangedFields:      []string{},
+		Editor:             editor,
+		CreatedAt:          primitive.NewDateTimeFromTime(time.Now())}
+	if previous != nil {
+		revision.ChangedFields = postChangedFields(previous, revision)
+	}
+
+	return revision
+}
+
+func postChangedFields(
+	from *models.PostRevisionModel,
+	to *models.PostRevisionModel,
+) (changedFields []string) {
+	var fromCategories, toCategories []string
+
+	for _, category := range from.Categories {
+		fromCategories = append(fromCategories, category.UID.Hex())
+	}
+	for _, category := range to.Categories {
+		toCategories = append(toCategories, category.UID.Hex())
+	}
+	changedFields = []string{}
+	if from.Slug != to.Slug {
+		changedFields = append(changedFields, "slug")
+	}
+	if from.Title != to.Title {
+		changedFields = append(changedFields, "title")
+	}
+	if from.FeaturingImagePath != to.FeaturingImagePath {
+		changedFields = append(changedFields, "featuringImagePath")
+	}
+	if from.Description != to.Description {
+		changedFields = append(changedFields, "description")
+	}
+	if strings.Join(fromCategories, ",") != strings.Join(toCategories, ",") {
+		changedFields = append(changedFields, "categories")
+	}
+	if strings.Join(from.Tags, ",") != strings.Join(to.Tags, ",") {
+		changedFields = append(changedFields, "tags")
+	}
+	if from.Content != to.Content {
+		changedFields = append(changedFields, "content")
+	}
+
+	return changedFields
+}
+
+func postRevisionText(revision *models.PostRevisionModel) (text string) {
+	var categories []string
+
+	for _, category := range revision.Categories {
+		categories = append(categories, category.Name)
+	}
+
+	return "Slug: " + revision.Slug + "\n" +
+		"Title: " + revision.Title + "\n" +
+		"Featuring Image: " + revision.FeaturingImagePath + "\n" +
+		"Description: " + revision.Description + "\n" +
+		"Categories: " + strings.Join(categories, ", ") + "\n" +
+		"Tags: " + strings.Join(revision.Tags, ", ") + "\n" +
+		"\n" + revision.Content
+}
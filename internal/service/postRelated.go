@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/database/repositories"
+)
+
+// Weighting of the related posts' ranking, every shared tag & category
+// scores its weight, the same author scores once. The recency scores
+// its full weight for the newest post, halved at the recency period's age.
+type relatedPostsConfig struct {
+	limit          int
+	cacheDuration  time.Duration
+	tagWeight      int
+	categoryWeight int
+	authorWeight   int
+	recencyWeight  int
+	recencyPeriod  time.Duration
+}
+
+func newRelatedPostsConfig() (config relatedPostsConfig) {
+	config = relatedPostsConfig{
+		limit:          getEnvInt("RELATED_POSTS_LIMIT", 5),
+		cacheDuration:  time.Duration(getEnvInt("RELATED_POSTS_CACHE_DURATION", 60)) * time.Minute,
+		tagWeight:      getEnvInt("RELATED_POSTS_TAG_WEIGHT", 3),
+		categoryWeight: getEnvInt("RELATED_POSTS_CATEGORY_WEIGHT", 2),
+		authorWeight:   getEnvInt("RELATED_POSTS_AUTHOR_WEIGHT", 1),
+		recencyWeight:  getEnvInt("RELATED_POSTS_RECENCY_WEIGHT", 1),
+		recencyPeriod:  time.Duration(getEnvInt("RELATED_POSTS_RECENCY_PERIOD", 30)) * 24 * time.Hour}
+	if config.limit <= 0 {
+		config.limit = 5
+	}
+	if config.recencyPeriod <= 0 {
+		config.recencyPeriod = 30 * 24 * time.Hour
+	}
+
+	return config
+}
+
+// Get the published posts related to the post, most relevant first.
+// The ranking is cached, the posts trashed or unpublished since then are skipped.
+func (s *post) GetManyRelated(
+	ctx context.Context,
+	post *models.PostModel,
+) (posts []*models.PostModel, err error) {
+	var (
+		now       = time.Now()
+		related   *models.RelatedPostsModel
+		postUids  []primitive.ObjectID
+		found     []*models.PostModel
+		postByUid = map[primitive.ObjectID]*models.PostModel{}
+	)
+
+	if related, err = repositories.ReadOneRelatedPosts(
+		s.dbConn, ctx, bson.M{
+			"$and": []bson.M{
+				{"_id": bson.M{"$eq": post.UID}},
+				{"expiresat": bson.M{"$gt": primitive.NewDateTimeFromTime(now)}}}},
+	); err != nil {
+		return nil, err
+	}
+	if related != nil {
+		postUids = related.Posts
+	} else {
+		if postUids, err = repositories.AggregatePostUids(
+			s.dbConn, ctx, s.relatedPostsPipeline(post, now),
+		); err != nil {
+			return nil, err
+		}
+		if err = repositories.ReplaceOneRelatedPosts(
+			s.dbConn, ctx, &models.RelatedPostsModel{
+				UID:       post.UID,
+				Posts:     postUids,
+				ExpiresAt: primitive.NewDateTimeFromTime(now.Add(s.related.cacheDuration)),
+				CreatedAt: primitive.NewDateTimeFromTime(now)},
+		); err != nil {
+			return nil, err
+		}
+	}
+	if len(postUids) == 0 {
+		return posts, nil
+	}
+	if found, err = repositories.ReadManyPosts(
+		s.dbConn, ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"publishedat": bson.M{"$ne": primitive.Null{}}},
+				{"_id": bson.M{"$in": postUids}}}},
+	); err != nil {
+		return nil, err
+	}
+	for _, _post := range found {
+		postByUid[_post.UID] = _post
+	}
+	for _, postUid := range postUids {
+		if _post, ok := postByUid[postUid]; ok {
+			posts = append(posts, _post)
+		}
+	}
+
+	return posts, nil
+}
+
+// Rank the other published posts sharing a tag, a category or the author
+func (s *post) relatedPostsPipeline(
+	post *models.PostModel,
+	now time.Time,
+) (pipeline mongo.Pipeline) {
+	var (
+		tags         = bson.A{}
+		categoryUids = bson.A{}
+	)
+
+	for _, tag := range post.Tags {
+		tags = append(tags, tag)
+	}
+	for _, category := range post.Categories {
+		categoryUids = append(categoryUids, category.UID)
+	}
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"publishedat": bson.M{"$ne": primitive.Null{}}},
+				{"_id": bson.M{"$ne": post.UID}},
+				{"$or": []bson.M{
+					{"tags": bson.M{"$in": tags}},
+					{"categories._id": bson.M{"$in": categoryUids}},
+					{"author._id": bson.M{"$eq": post.Author.UID}}}}}}}},
+		{{Key: "$addFields", Value: bson.M{
+			"relatedscore": bson.M{"$add": bson.A{
+				bson.M{"$multiply": bson.A{s.related.tagWeight,
+					bson.M{"$size": bson.M{"$setIntersection": bson.A{
+						bson.M{"$ifNull": bson.A{"$tags", bson.A{}}}, tags}}}}},
+				bson.M{"$multiply": bson.A{s.related.categoryWeight,
+					bson.M{"$size": bson.M{"$setIntersection": bson.A{
+						bson.M{"$ifNull": bson.A{"$categories._id", bson.A{}}}, categoryUids}}}}},
+				bson.M{"$cond": bson.A{
+					bson.M{"$eq": bson.A{"$author._id", post.Author.UID}},
+					s.related.authorWeight, 0}},
+				bson.M{"$divide": bson.A{s.related.recencyWeight,
+					bson.M{"$add": bson.A{1, bson.M{"$divide": bson.A{
+						bson.M{"$max": bson.A{0, bson.M{"$subtract": bson.A{
+							primitive.NewDateTimeFromTime(now), "$publishedat"}}}},
+						s.related.recencyPeriod.Milliseconds()}}}}}}}}}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "relatedscore", Value: -1},
+			{Key: "publishedat", Value: -1}}}},
+		{{Key: "$limit", Value: s.related.limit}},
+		{{Key: "$project", Value: bson.M{"_id": 1}}}}
+}
+
+// Drop the cached related posts of the post & the ones ranking it
+func invalidateRelatedPosts(
+	dbConn *mongo.Database,
+	ctx context.Context,
+	postUid primitive.ObjectID,
+) (err error) {
+
+	return repositories.DeleteManyRelatedPosts(
+		dbConn, ctx, bson.M{"$or": []bson.M{
+			{"_id": bson.M{"$eq": postUid}},
+			{"posts": bson.M{"$eq": postUid}}}})
+}
+
+// Check whether the post's tags or categories are changed
+func postRelationChanged(
+	from *models.PostModel,
+	to *models.PostModel,
+) (changed bool) {
+	var fromCategories, toCategories []string
+
+	for _, category := range from.Categories {
+		fromCategories = append(fromCategories, category.UID.Hex())
+	}
+	for _, category := range to.Categories {
+		toCategories = append(toCategories, category.UID.Hex())
+	}
+
+	return strings.Join(fromCategories, ",") != strings.Join(toCategories, ",") ||
+		strings.Join(from.Tags, ",") != strings.Join(to.Tags, ",")
+}
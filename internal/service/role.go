@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/database/repositories"
+	customMongo "github.com/misterabdul/goblog-server/pkg/mongo"
+)
+
+// Lowest level taken by the custom roles.
+const customRoleMinLevel = models.RoleLevelWriter + 1
+
+type role struct {
+	dbConn *mongo.Database
+}
+
+func newRoleService(
+	dbConn *mongo.Database,
+) (service *role) {
+
+	return &role{dbConn: dbConn}
+}
+
+// Get single role
+func (s *role) GetOne(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (role *models.RoleModel, err error) {
+
+	return repositories.ReadOneRole(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Get multiple roles
+func (s *role) GetMany(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (roles []*models.RoleModel, err error) {
+
+	return repositories.ReadManyRoles(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Get the roles of given levels
+func (s *role) GetManyByLevels(
+	ctx context.Context,
+	levels []int,
+) (roles []*models.RoleModel, err error) {
+
+	return repositories.ReadManyRoles(
+		s.dbConn, ctx, bson.M{"level": bson.M{"$in": levels}},
+		options.Find().SetSort(bson.M{"level": 1}))
+}
+
+// Create new custom role, taking the next available level
+func (s *role) SaveOne(
+	ctx context.Context,
+	role *models.RoleModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var (
+		now       = primitive.NewDateTimeFromTime(time.Now())
+		lastRole  *models.RoleModel
+		nextLevel = customRoleMinLevel
+	)
+
+	if lastRole, err = repositories.ReadOneRole(
+		s.dbConn, ctx, bson.M{},
+		options.FindOne().SetSort(bson.M{"level": -1}),
+	); err != nil {
+		return err
+	}
+	if lastRole != nil && lastRole.Level >= nextLevel {
+		nextLevel = lastRole.Level + 1
+	}
+	role.UID = primitive.NewObjectID()
+	role.Level = nextLevel
+	role.System = false
+	role.CreatedAt = now
+	role.UpdatedAt = now
+
+	return repositories.SaveOneRole(
+		s.dbConn, ctx, role, opts...)
+}
+
+// Update role, renaming it in the users' roles too
+func (s *role) UpdateOne(
+	ctx context.Context,
+	role *models.RoleModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	role.UpdatedAt = now
+
+	return customMongo.Transaction(ctx, s.dbConn, false,
+		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
+			if sErr = repositories.UpdateOneRole(
+				dbConn, sCtx, role, opts...,
+			); sErr != nil {
+				return sErr
+			}
+
+			return repositories.UpdateManyUsers(
+				dbConn, sCtx,
+				bson.M{"roles.level": role.Level},
+				bson.M{"$set": bson.M{"roles.$[role].name": role.Name}},
+				options.Update().SetArrayFilters(options.ArrayFilters{
+					Filters: []interface{}{bson.M{"role.level": role.Level}}}))
+		})
+}
+
+// Permanently delete custom role, revoking it from the users
+func (s *role) DeleteOne(
+	ctx context.Context,
+	role *models.RoleModel,
+	opts ...*options.DeleteOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	return customMongo.Transaction(ctx, s.dbConn, false,
+		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
+			if sErr = repositories.DeleteOneRole(
+				dbConn, sCtx, role, opts...,
+			); sErr != nil {
+				return sErr
+			}
+
+			return repositories.UpdateManyUsers(
+				dbConn, sCtx,
+				bson.M{"roles.level": role.Level},
+				bson.M{
+					"$pull": bson.M{"roles": bson.M{"level": role.Level}},
+					"$set":  bson.M{"updatedat": now}})
+		})
+}
+
+// Get every permission granted by the user's roles,
+// the SuperAdmin is granted every permission.
+func (s *role) GetPermissions(
+	ctx context.Context,
+	user *models.UserModel,
+) (permissions []string, err error) {
+	var (
+		levels  []int
+		roles   []*models.RoleModel
+		granted = map[string]bool{}
+	)
+
+	permissions = []string{}
+	for _, userRole := range user.Roles {
+		if userRole.Level == models.RoleLevelSuperAdmin {
+			return models.GetAllPermissions(), nil
+		}
+		levels = append(levels, userRole.Level)
+	}
+	if len(levels) == 0 {
+		return permissions, nil
+	}
+	if roles, err = s.GetManyByLevels(ctx, levels); err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		for _, permission := range role.Permissions {
+			if !granted[permission] {
+				granted[permission] = true
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+
+	return permissions, nil
+}
+
+// Check whether the user's roles grant all of the permissions,
+// the SuperAdmin is granted every permission.
+func (s *role) HasPermissions(
+	ctx context.Context,
+	user *models.UserModel,
+	permissions ...string,
+) (granted bool, err error) {
+	var (
+		levels []int
+		roles  []*models.RoleModel
+	)
+
+	for _, userRole := range user.Roles {
+		if userRole.Level == models.RoleLevelSuperAdmin {
+			return true, nil
+		}
+		levels = append(levels, userRole.Level)
+	}
+	if len(levels) == 0 {
+		return len(permissions) == 0, nil
+	}
+	if roles, err = s.GetManyByLevels(ctx, levels); err != nil {
+		return false, err
+	}
+	for _, permission := range permissions {
+		granted = false
+		for _, role := range roles {
+			if role.HasPermission(permission) {
+				granted = true
+				break
+			}
+		}
+		if !granted {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
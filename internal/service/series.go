@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/database/repositories"
+	customMongo "github.com/misterabdul/goblog-server/pkg/mongo"
+)
+
+type series struct {
+	dbConn *mongo.Database
+}
+
+func newSeriesService(
+	dbConn *mongo.Database,
+) (service *series) {
+
+	return &series{dbConn: dbConn}
+}
+
+// Get single series
+func (s *series) GetOne(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (series *models.SeriesModel, err error) {
+
+	return repositories.ReadOneSeries(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Get the series containing the post
+func (s *series) GetOneByPost(
+	ctx context.Context,
+	postUid primitive.ObjectID,
+	opts ...*options.FindOneOptions,
+) (series *models.SeriesModel, err error) {
+
+	return repositories.ReadOneSeries(
+		s.dbConn, ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"posts": bson.M{"$eq": postUid}}}},
+		opts...)
+}
+
+// Get multiple series
+func (s *series) GetMany(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (manySeries []*models.SeriesModel, err error) {
+
+	return repositories.ReadManySeries(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Get the series' parts that are available publicly, in the series' order,
+// so the trashed & unpublished posts are skipped by the navigation
+func (s *series) GetPublishedParts(
+	ctx context.Context,
+	series *models.SeriesModel,
+) (parts []*models.PostModel, err error) {
+	var (
+		posts     []*models.PostModel
+		postByUid = map[primitive.ObjectID]*models.PostModel{}
+	)
+
+	if len(series.Posts) == 0 {
+		return parts, nil
+	}
+	if posts, err = repositories.ReadManyPosts(
+		s.dbConn, ctx, bson.M{
+			"$and": []bson.M{
+				{"deletedat": bson.M{"$eq": primitive.Null{}}},
+				{"publishedat": bson.M{"$ne": primitive.Null{}}},
+				{"_id": bson.M{"$in": series.Posts}}}},
+	); err != nil {
+		return nil, err
+	}
+	for _, post := range posts {
+		postByUid[post.UID] = post
+	}
+	for _, postUid := range series.Posts {
+		if post, ok := postByUid[postUid]; ok {
+			parts = append(parts, post)
+		}
+	}
+
+	return parts, nil
+}
+
+// Get total series count
+func (s *series) Count(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.CountOptions,
+) (count int64, err error) {
+
+	return repositories.CountSeries(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Create new series, the posts are taken out of their previous series
+func (s *series) SaveOne(
+	ctx context.Context,
+	series *models.SeriesModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	series.UID = primitive.NewObjectID()
+	series.CreatedAt = now
+	series.UpdatedAt = now
+	series.DeletedAt = nil
+	if series.Posts == nil {
+		series.Posts = []primitive.ObjectID{}
+	}
+
+	return customMongo.Transaction(ctx, s.dbConn, false,
+		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
+			for _, postUid := range series.Posts {
+				if sErr = repositories.PullManySeriesPost(
+					dbConn, sCtx, postUid, now,
+				); sErr != nil {
+					return sErr
+				}
+			}
+
+			return repositories.SaveOneSeries(
+				dbConn, sCtx, series, opts...)
+		})
+}
+
+// Update series, the posts are taken out of their other series
+func (s *series) UpdateOne(
+	ctx context.Context,
+	series *models.SeriesModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	series.UpdatedAt = now
+
+	return customMongo.Transaction(ctx, s.dbConn, false,
+		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
+			for _, postUid := range series.Posts {
+				if sErr = repositories.PullManySeriesPost(
+					dbConn, sCtx, postUid, now,
+				); sErr != nil {
+					return sErr
+				}
+			}
+
+			return repositories.UpdateOneSeries(
+				dbConn, sCtx, series, opts...)
+		})
+}
+
+// Attach the post to the series at given position, appended when it's negative.
+// The post is taken out of its previous series.
+func (s *series) AttachPost(
+	ctx context.Context,
+	series *models.SeriesModel,
+	post *models.PostModel,
+	position int,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	return customMongo.Transaction(ctx, s.dbConn, false,
+		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
+			if sErr = repositories.PullManySeriesPost(
+				dbConn, sCtx, post.UID, now,
+			); sErr != nil {
+				return sErr
+			}
+
+			return repositories.PushSeriesPost(
+				dbConn, sCtx, series, post.UID, position, now)
+		})
+}
+
+// Detach the post from its series
+func (s *series) DetachPost(
+	ctx context.Context,
+	post *models.PostModel,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	return repositories.PullManySeriesPost(
+		s.dbConn, ctx, post.UID, now)
+}
+
+// Delete series to trash
+func (s *series) TrashOne(
+	ctx context.Context,
+	series *models.SeriesModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	series.DeletedAt = now
+
+	return repositories.UpdateOneSeries(
+		s.dbConn, ctx, series, opts...)
+}
+
+// Restore series from trash
+func (s *series) RestoreOne(
+	ctx context.Context,
+	series *models.SeriesModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	series.DeletedAt = nil
+
+	return repositories.UpdateOneSeries(
+		s.dbConn, ctx, series, opts...)
+}
+
+// Permanently delete series
+func (s *series) DeleteOne(
+	ctx context.Context,
+	series *models.SeriesModel,
+	opts ...*options.DeleteOptions,
+) (err error) {
+
+	return repositories.DeleteOneSeries(
+		s.dbConn, ctx, series, opts...)
+}
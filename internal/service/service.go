@@ -10,13 +10,24 @@ type Service struct {
 	dbConn      *mongo.Database
 	queueClient *client.QueueClient
 
-	User         *user
-	RevokedToken *revokedToken
-	Category     *category
-	Post         *post
-	Comment      *comment
-	Page         *page
-	Notification *notification
+	User              *user
+	EmailVerification *emailVerification
+	PasswordReset     *passwordReset
+	Invitation        *invitation
+	RevokedToken      *revokedToken
+	Session           *session
+	LoginAttempt      *loginAttempt
+	PersonalToken     *personalToken
+	OidcState         *oidcState
+	AuditLog          *auditLog
+	Role              *role
+	Category          *category
+	Series            *series
+	Post              *post
+	Comment           *comment
+	Page              *page
+	Notification      *notification
+	DataExport        *dataExport
 }
 
 func NewService(
@@ -27,11 +38,22 @@ func NewService(
 		dbConn:      dbConn,
 		queueClient: queueClient,
 
-		User:         newUserService(dbConn),
-		RevokedToken: newRevokedTokenService(dbConn),
-		Category:     newCategoryService(dbConn),
-		Post:         newPostService(dbConn),
-		Comment:      newCommentService(dbConn),
-		Page:         newPageService(dbConn),
-		Notification: newNotificationService(dbConn)}
+		User:              newUserService(dbConn),
+		EmailVerification: newEmailVerificationService(dbConn),
+		PasswordReset:     newPasswordResetService(dbConn),
+		Invitation:        newInvitationService(dbConn),
+		RevokedToken:      newRevokedTokenService(dbConn),
+		Session:           newSessionService(dbConn),
+		LoginAttempt:      newLoginAttemptService(dbConn),
+		PersonalToken:     newPersonalTokenService(dbConn),
+		OidcState:         newOidcStateService(dbConn),
+		AuditLog:          newAuditLogService(dbConn),
+		Role:              newRoleService(dbConn),
+		Category:          newCategoryService(dbConn),
+		Series:            newSeriesService(dbConn),
+		Post:              newPostService(dbConn, queueClient),
+		Comment:           newCommentService(dbConn),
+		Page:              newPageService(dbConn, queueClient),
+		Notification:      newNotificationService(dbConn),
+		DataExport:        newDataExportService(dbConn)}
 }
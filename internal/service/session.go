@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/database/repositories"
+)
+
+type session struct {
+	dbConn *mongo.Database
+}
+
+func newSessionService(
+	dbConn *mongo.Database,
+) (service *session) {
+
+	return &session{dbConn: dbConn}
+}
+
+// Get single session
+func (s *session) GetOne(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (session *models.SessionModel, err error) {
+
+	return repositories.ReadOneSession(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Get multiple sessions
+func (s *session) GetMany(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOptions,
+) (sessions []*models.SessionModel, err error) {
+
+	return repositories.ReadManySessions(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Create new session, the UID is its refresh token family
+func (s *session) SaveOne(
+	ctx context.Context,
+	session *models.SessionModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	session.LastUsedAt = now
+	session.CreatedAt = now
+	session.UpdatedAt = now
+
+	return repositories.SaveOneSession(
+		s.dbConn, ctx, session, opts...)
+}
+
+// Update session
+func (s *session) UpdateOne(
+	ctx context.Context,
+	session *models.SessionModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	session.UpdatedAt = now
+
+	return repositories.UpdateOneSession(
+		s.dbConn, ctx, session, opts...)
+}
+
+// Permanently delete session
+func (s *session) DeleteOne(
+	ctx context.Context,
+	session *models.SessionModel,
+	opts ...*options.DeleteOptions,
+) (err error) {
+
+	return repositories.DeleteOneSession(
+		s.dbConn, ctx, session, opts...)
+}
+
+// Permanently delete multiple sessions
+func (s *session) DeleteMany(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.DeleteOptions,
+) (err error) {
+
+	return repositories.DeleteManySessions(
+		s.dbConn, ctx, filter, opts...)
+}
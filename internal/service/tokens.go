@@ -61,6 +61,61 @@ func (s *revokedToken) SaveOne(
 		s.dbConn, ctx, revokedToken, opts...)
 }
 
+// Revoke all of the owner's tokens issued until now
+func (s *revokedToken) RevokeAllOfOwner(
+	ctx context.Context,
+	owner *models.UserModel,
+	expiresAt time.Time,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	return s.SaveOne(ctx, &models.RevokedTokenModel{
+		UID:          primitive.NewObjectID(),
+		ExpiresAt:    primitive.NewDateTimeFromTime(expiresAt),
+		IssuedBefore: now,
+		Owner:        owner.ToCommonModel(),
+	}, opts...)
+}
+
+// Revoke the refresh token because it's replaced by its successor.
+//
+// The token UID is the primary key, so only one of concurrent rotations
+// of the same token succeeds, the others get rotated = false.
+func (s *revokedToken) RotateOne(
+	ctx context.Context,
+	revokedToken *models.RevokedTokenModel,
+	opts ...*options.InsertOneOptions,
+) (rotated bool, err error) {
+	revokedToken.RotatedAt = primitive.NewDateTimeFromTime(time.Now())
+	if err = s.SaveOne(ctx, revokedToken, opts...); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Revoke all tokens of the token family
+func (s *revokedToken) RevokeFamily(
+	ctx context.Context,
+	owner models.UserCommonModel,
+	familyID string,
+	expiresAt time.Time,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+
+	return s.SaveOne(ctx, &models.RevokedTokenModel{
+		UID:           primitive.NewObjectID(),
+		ExpiresAt:     primitive.NewDateTimeFromTime(expiresAt),
+		FamilyID:      familyID,
+		RevokedFamily: familyID,
+		Owner:         owner,
+	}, opts...)
+}
+
 // Update revoked token
 func (s *revokedToken) UpdateOne(
 	ctx context.Context,
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/database/repositories"
+	"github.com/misterabdul/goblog-server/pkg/hash"
+	"github.com/misterabdul/goblog-server/pkg/totp"
+)
+
+const (
+	twoFactorRecoveryCodesCount  = 10
+	twoFactorRecoveryCodesLength = 12
+	twoFactorAllowedSkew         = 1
+)
+
+var twoFactorCodePattern = regexp.MustCompile(`^[0-9]{6}$`)
+
+// Start the second factor enrollment with new pending secret
+func (s *user) EnrollTwoFactor(
+	ctx context.Context,
+	user *models.UserModel,
+) (secret string, err error) {
+	if secret, err = totp.GenerateSecret(); err != nil {
+		return "", err
+	}
+	user.TwoFactor.PendingSecret = secret
+
+	return secret, s.UpdateOne(ctx, user)
+}
+
+// Confirm the pending enrollment with the code generated from it,
+// returning the plain recovery codes to be shown once
+func (s *user) ConfirmTwoFactor(
+	ctx context.Context,
+	user *models.UserModel,
+	code string,
+) (recoveryCodes []string, valid bool, err error) {
+	var (
+		hashedCodes []string
+		step        int64
+	)
+
+	if user.TwoFactor.PendingSecret == "" {
+		return nil, false, nil
+	}
+	if step, valid = totp.Validate(
+		code, user.TwoFactor.PendingSecret, time.Now(), twoFactorAllowedSkew,
+	); !valid {
+		return nil, false, nil
+	}
+	if recoveryCodes, hashedCodes, err = makeRecoveryCodes(); err != nil {
+		return nil, false, err
+	}
+	user.TwoFactor = models.UserTwoFactor{
+		Secret:        user.TwoFactor.PendingSecret,
+		RecoveryCodes: hashedCodes,
+		LastUsedStep:  step,
+		EnabledAt:     primitive.NewDateTimeFromTime(time.Now())}
+
+	return recoveryCodes, true, s.UpdateOne(ctx, user)
+}
+
+// Replace the user's recovery codes with new ones
+func (s *user) RegenerateRecoveryCodes(
+	ctx context.Context,
+	user *models.UserModel,
+) (recoveryCodes []string, err error) {
+	var hashedCodes []string
+
+	if recoveryCodes, hashedCodes, err = makeRecoveryCodes(); err != nil {
+		return nil, err
+	}
+	user.TwoFactor.RecoveryCodes = hashedCodes
+
+	return recoveryCodes, s.UpdateOne(ctx, user)
+}
+
+// Disable the second factor & remove all its data
+func (s *user) ResetTwoFactor(
+	ctx context.Context,
+	user *models.UserModel,
+) (err error) {
+	user.TwoFactor = models.UserTwoFactor{}
+
+	return s.UpdateOne(ctx, user)
+}
+
+// Check the one-time or recovery code of the enrolled user.
+//
+// Each one-time code & recovery code can only be used once.
+func (s *user) UseTwoFactorCode(
+	ctx context.Context,
+	user *models.UserModel,
+	code string,
+) (valid bool, err error) {
+	var step int64
+
+	if user.TwoFactor.EnabledAt == nil {
+		return false, nil
+	}
+	if !twoFactorCodePattern.MatchString(code) {
+		return repositories.UpdateOneUserConditionally(
+			s.dbConn, ctx,
+			bson.M{
+				"_id":                     user.UID,
+				"twofactor.recoverycodes": hash.HashToken(code)},
+			bson.M{"$pull": bson.M{
+				"twofactor.recoverycodes": hash.HashToken(code)}})
+	}
+	if step, valid = totp.Validate(
+		code, user.TwoFactor.Secret, time.Now(), twoFactorAllowedSkew,
+	); !valid {
+		return false, nil
+	}
+
+	return repositories.UpdateOneUserConditionally(
+		s.dbConn, ctx,
+		bson.M{
+			"_id":                    user.UID,
+			"twofactor.lastusedstep": bson.M{"$lt": step}},
+		bson.M{"$set": bson.M{
+			"twofactor.lastusedstep": step}})
+}
+
+func makeRecoveryCodes() (codes []string, hashedCodes []string, err error) {
+	var code, hashedCode string
+
+	for i := 0; i < twoFactorRecoveryCodesCount; i++ {
+		if code, hashedCode, err = hash.MakeToken(twoFactorRecoveryCodesLength); err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, code)
+		hashedCodes = append(hashedCodes, hashedCode)
+	}
+
+	return codes, hashedCodes, nil
+}
@@ -2,25 +2,52 @@ package service
 
 import (
 	"context"
+	"errors"
+	"os"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/misterabdul/goblog-server/internal/database/models"
 	"github.com/misterabdul/goblog-server/internal/database/repositories"
+	customMongo "github.com/misterabdul/goblog-server/pkg/mongo"
+)
+
+const (
+	// Replace the author of the deleted user's content with a placeholder.
+	AccountDeletionPolicyAnonymize = "anonymize"
+	// Transfer the deleted user's content to another user.
+	AccountDeletionPolicyReassign = "reassign"
 )
 
 type user struct {
 	dbConn *mongo.Database
+
+	deletionPolicy     string
+	deletionReassignTo string
 }
 
 func newUserService(
 	dbConn *mongo.Database,
 ) (service *user) {
+	var (
+		policy     string
+		reassignTo string
+		ok         bool
+	)
 
-	return &user{dbConn: dbConn}
+	if policy, ok = os.LookupEnv("ACCOUNT_DELETION_POLICY"); !ok || policy != AccountDeletionPolicyReassign {
+		policy = AccountDeletionPolicyAnonymize
+	}
+	reassignTo, _ = os.LookupEnv("ACCOUNT_DELETION_REASSIGN_TO")
+
+	return &user{
+		dbConn:             dbConn,
+		deletionPolicy:     policy,
+		deletionReassignTo: reassignTo}
 }
 
 // Get single user
@@ -87,7 +114,7 @@ func (s *user) UpdateOne(
 		s.dbConn, ctx, user, opts...)
 }
 
-// Delete user to trash
+// Delete user to trash, invalidating all of the user's tokens & sessions
 func (s *user) TrashOne(
 	ctx context.Context,
 	user *models.UserModel,
@@ -96,9 +123,9 @@ func (s *user) TrashOne(
 	var now = primitive.NewDateTimeFromTime(time.Now())
 
 	user.DeletedAt = now
+	user.TokenVersion++
 
-	return repositories.UpdateOneUser(
-		s.dbConn, ctx, user, opts...)
+	return s.updateOneRevokingSessions(ctx, user, opts...)
 }
 
 // Restore user from trash
@@ -123,3 +150,267 @@ func (s *user) DeleteOne(
 	return repositories.DeleteOneUser(
 		s.dbConn, ctx, user, opts...)
 }
+
+// Mark the user's email verified
+func (s *user) VerifyOne(
+	ctx context.Context,
+	user *models.UserModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	user.VerifiedAt = now
+	user.UpdatedAt = now
+
+	return repositories.UpdateOneUser(
+		s.dbConn, ctx, user, opts...)
+}
+
+// Invalidate all access & refresh tokens issued to the user so far,
+// removing the user's sessions which can no longer be refreshed
+func (s *user) RevokeTokens(
+	ctx context.Context,
+	user *models.UserModel,
+) (err error) {
+	user.TokenVersion++
+
+	return customMongo.Transaction(ctx, s.dbConn, false,
+		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
+			if _, sErr = repositories.UpdateOneUserConditionally(
+				dbConn, sCtx,
+				bson.M{"_id": user.UID},
+				bson.M{"$inc": bson.M{"tokenversion": 1}},
+			); sErr != nil {
+				return sErr
+			}
+
+			return repositories.DeleteManySessions(
+				dbConn, sCtx, bson.M{"owner._id": user.UID})
+		})
+}
+
+// Replace the user's outdated password hash with the rehashed one,
+// unless the password has been changed in the meantime
+func (s *user) RehashPassword(
+	ctx context.Context,
+	user *models.UserModel,
+	hashedPassword string,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	if _, err = repositories.UpdateOneUserConditionally(
+		s.dbConn, ctx,
+		bson.M{"$and": []bson.M{
+			{"_id": bson.M{"$eq": user.UID}},
+			{"password": bson.M{"$eq": user.Password}}}},
+		bson.M{"$set": bson.M{
+			"password":  hashedPassword,
+			"updatedat": now}},
+	); err != nil {
+		return err
+	}
+	user.Password = hashedPassword
+
+	return nil
+}
+
+// Link the external identity to the user, the identities
+// may be null for the users saved before they're introduced
+func (s *user) LinkIdentity(
+	ctx context.Context,
+	user *models.UserModel,
+	identity models.UserIdentity,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	identity.LinkedAt = now
+	if _, err = repositories.UpdateOneUserConditionally(
+		s.dbConn, ctx,
+		bson.M{"_id": user.UID},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{
+			"identities": bson.M{"$concatArrays": bson.A{
+				bson.M{"$ifNull": bson.A{"$identities", bson.A{}}},
+				bson.A{bson.M{"$literal": identity}}}},
+			"updatedat": now}}}},
+	); err != nil {
+		return err
+	}
+	user.Identities = append(user.Identities, identity)
+
+	return nil
+}
+
+// Grant the admin role to the user
+func (s *user) AdminizeOne(
+	ctx context.Context,
+	user *models.UserModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	for _, role := range user.Roles {
+		if role.Level == 1 {
+			return nil
+		}
+	}
+	user.Roles = append(user.Roles, models.UserRole{
+		Level: 1,
+		Name:  "Admin",
+		Since: now})
+	user.TokenVersion++
+	user.UpdatedAt = now
+
+	return s.updateOneRevokingSessions(ctx, user, opts...)
+}
+
+// Revoke the admin role from the user
+func (s *user) DeadminizeOne(
+	ctx context.Context,
+	user *models.UserModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	var (
+		now   = primitive.NewDateTimeFromTime(time.Now())
+		roles = []models.UserRole{}
+	)
+
+	for _, role := range user.Roles {
+		if role.Level != 1 {
+			roles = append(roles, role)
+		}
+	}
+	if len(roles) == len(user.Roles) {
+		return nil
+	}
+	user.Roles = roles
+	user.TokenVersion++
+	user.UpdatedAt = now
+
+	return s.updateOneRevokingSessions(ctx, user, opts...)
+}
+
+// Update the user whose token version is bumped,
+// removing the user's sessions which can no longer be refreshed
+func (s *user) updateOneRevokingSessions(
+	ctx context.Context,
+	user *models.UserModel,
+	opts ...*options.UpdateOptions,
+) (err error) {
+	return customMongo.Transaction(ctx, s.dbConn, false,
+		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
+			if sErr = repositories.UpdateOneUser(
+				dbConn, sCtx, user, opts...,
+			); sErr != nil {
+				return sErr
+			}
+
+			return repositories.DeleteManySessions(
+				dbConn, sCtx, bson.M{"owner._id": user.UID})
+		})
+}
+
+// Get the author taking over the content of the user to be erased,
+// depending on the configured account deletion policy
+func (s *user) GetSuccessorAuthor(
+	ctx context.Context,
+	user *models.UserModel,
+) (author models.UserCommonModel, err error) {
+	var successor *models.UserModel
+
+	if s.deletionPolicy != AccountDeletionPolicyReassign {
+		return models.UserCommonModel{
+			UID:       user.UID,
+			FirstName: "Deleted",
+			LastName:  "User"}, nil
+	}
+	if successor, err = repositories.ReadOneUser(
+		s.dbConn, ctx, bson.M{"$and": []bson.M{
+			{"deletedat": bson.M{"$eq": primitive.Null{}}},
+			{"username": bson.M{"$eq": s.deletionReassignTo}}}},
+	); err != nil {
+		return models.UserCommonModel{}, err
+	}
+	if successor == nil || successor.UID == user.UID {
+		return models.UserCommonModel{}, errors.New("unable to get the user taking over the content")
+	}
+
+	return successor.ToCommonModel(), nil
+}
+
+// Permanently erase the user's personal data, handing the user's posts
+// & pages over to the successor author. The audit logs are kept.
+func (s *user) EraseOne(
+	ctx context.Context,
+	user *models.UserModel,
+	author models.UserCommonModel,
+) (err error) {
+	var ownerFilter = bson.M{"owner._id": user.UID}
+
+	return customMongo.Transaction(ctx, s.dbConn, false,
+		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
+			if sErr = repositories.ReassignManyPostAuthor(
+				dbConn, sCtx, user.UID, author,
+			); sErr != nil {
+				return sErr
+			}
+			if sErr = repositories.UpdateManyPostContributor(
+				dbConn, sCtx, user.UID, author,
+			); sErr != nil {
+				return sErr
+			}
+			if sErr = repositories.ReassignManyPageAuthor(
+				dbConn, sCtx, user.UID, author,
+			); sErr != nil {
+				return sErr
+			}
+			if sErr = repositories.UpdateManyPostRevisionEditor(
+				dbConn, sCtx, user.UID, author,
+			); sErr != nil {
+				return sErr
+			}
+			if sErr = repositories.UpdateManyPageRevisionEditor(
+				dbConn, sCtx, user.UID, author,
+			); sErr != nil {
+				return sErr
+			}
+			if author.UID != user.UID {
+				if sErr = repositories.UpdateManyCommentPostAuthor(
+					dbConn, sCtx, user.UID, author.UID,
+				); sErr != nil {
+					return sErr
+				}
+			}
+			if sErr = repositories.DeleteManySessions(
+				dbConn, sCtx, ownerFilter,
+			); sErr != nil {
+				return sErr
+			}
+			if sErr = repositories.DeleteManyRevokedTokens(
+				dbConn, sCtx, ownerFilter,
+			); sErr != nil {
+				return sErr
+			}
+			if sErr = repositories.DeleteManyPersonalTokens(
+				dbConn, sCtx, ownerFilter,
+			); sErr != nil {
+				return sErr
+			}
+			if sErr = repositories.DeleteManyPasswordResets(
+				dbConn, sCtx, ownerFilter,
+			); sErr != nil {
+				return sErr
+			}
+			if sErr = repositories.DeleteManyEmailVerifications(
+				dbConn, sCtx, ownerFilter,
+			); sErr != nil {
+				return sErr
+			}
+			if sErr = repositories.DeleteManyNotifications(
+				dbConn, sCtx, ownerFilter,
+			); sErr != nil {
+				return sErr
+			}
+
+			return repositories.DeleteOneUser(dbConn, sCtx, user)
+		})
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/misterabdul/goblog-server/internal/database/models"
+	"github.com/misterabdul/goblog-server/internal/database/repositories"
+	customMongo "github.com/misterabdul/goblog-server/pkg/mongo"
+)
+
+type emailVerification struct {
+	dbConn *mongo.Database
+}
+
+func newEmailVerificationService(
+	dbConn *mongo.Database,
+) (service *emailVerification) {
+
+	return &emailVerification{dbConn: dbConn}
+}
+
+// Get single email verification
+func (s *emailVerification) GetOne(
+	ctx context.Context,
+	filter interface{},
+	opts ...*options.FindOneOptions,
+) (verification *models.EmailVerificationModel, err error) {
+
+	return repositories.ReadOneEmailVerification(
+		s.dbConn, ctx, filter, opts...)
+}
+
+// Create new email verification, replacing the owner's previous ones
+func (s *emailVerification) SaveOne(
+	ctx context.Context,
+	verification *models.EmailVerificationModel,
+	opts ...*options.InsertOneOptions,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	verification.UID = primitive.NewObjectID()
+	verification.UsedAt = nil
+	verification.CreatedAt = now
+
+	return customMongo.Transaction(ctx, s.dbConn, false,
+		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
+			if sErr = repositories.DeleteManyEmailVerifications(
+				dbConn, sCtx, bson.M{"owner._id": verification.Owner.UID},
+			); sErr != nil {
+				return sErr
+			}
+			if sErr = repositories.SaveOneEmailVerification(
+				dbConn, sCtx, verification, opts...,
+			); sErr != nil {
+				return sErr
+			}
+
+			return nil
+		})
+}
+
+// Create new unverified user with its email verification
+func (s *emailVerification) SaveOneWithUser(
+	ctx context.Context,
+	user *models.UserModel,
+	verification *models.EmailVerificationModel,
+) (err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	user.UID = primitive.NewObjectID()
+	user.VerifiedAt = nil
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	user.DeletedAt = nil
+	verification.UID = primitive.NewObjectID()
+	verification.Owner = user.ToCommonModel()
+	verification.UsedAt = nil
+	verification.CreatedAt = now
+
+	return customMongo.Transaction(ctx, s.dbConn, false,
+		func(sCtx context.Context, dbConn *mongo.Database) (sErr error) {
+			if sErr = repositories.SaveOneUser(
+				dbConn, sCtx, user,
+			); sErr != nil {
+				return sErr
+			}
+			if sErr = repositories.SaveOneEmailVerification(
+				dbConn, sCtx, verification,
+			); sErr != nil {
+				return sErr
+			}
+
+			return nil
+		})
+}
+
+// Mark the unused & unexpired email verification of given token hash used
+func (s *emailVerification) UseOne(
+	ctx context.Context,
+	tokenHash string,
+) (verification *models.EmailVerificationModel, err error) {
+	var now = primitive.NewDateTimeFromTime(time.Now())
+
+	return repositories.UseOneEmailVerification(
+		s.dbConn, ctx, bson.M{
+			"$and": []bson.M{
+				{"tokenhash": bson.M{"$eq": tokenHash}},
+				{"usedat": bson.M{"$eq": primitive.Null{}}},
+				{"expiresat": bson.M{"$gt": now}}}},
+		now)
+}
@@ -0,0 +1,187 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+/**
+ * Line based unified diff, the same format produced by `diff -u`,
+ * computed from the longest common subsequence of both texts' lines.
+ */
+
+const DefaultContext = 3
+
+type operation struct {
+	kind byte
+	line string
+}
+
+// Get the unified diff of both texts with given number of context lines,
+// empty when both texts are equal.
+func Unified(
+	fromName string,
+	toName string,
+	from string,
+	to string,
+	context int,
+) (unified string) {
+	var (
+		operations = diffLines(splitLines(from), splitLines(to))
+		builder    strings.Builder
+	)
+
+	if !hasChanges(operations) {
+		return ""
+	}
+	builder.WriteString("--- " + fromName + "\n")
+	builder.WriteString("+++ " + toName + "\n")
+	for _, hunk := range makeHunks(operations, context) {
+		builder.WriteString(hunk)
+	}
+
+	return builder.String()
+}
+
+func splitLines(text string) (lines []string) {
+	if len(text) == 0 {
+		return []string{}
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+func diffLines(from []string, to []string) (operations []operation) {
+	var (
+		lengths = make([][]int, len(from)+1)
+		i, j    int
+	)
+
+	for i = range lengths {
+		lengths[i] = make([]int, len(to)+1)
+	}
+	for i = len(from) - 1; i >= 0; i-- {
+		for j = len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+	for i, j = 0, 0; i < len(from) || j < len(to); {
+		switch {
+		case i < len(from) && j < len(to) && from[i] == to[j]:
+			operations = append(operations, operation{' ', from[i]})
+			i++
+			j++
+		case i < len(from) && (j == len(to) || lengths[i+1][j] >= lengths[i][j+1]):
+			operations = append(operations, operation{'-', from[i]})
+			i++
+		default:
+			operations = append(operations, operation{'+', to[j]})
+			j++
+		}
+	}
+
+	return operations
+}
+
+func hasChanges(operations []operation) (changed bool) {
+	for _, op := range operations {
+		if op.kind != ' ' {
+			return true
+		}
+	}
+
+	return false
+}
+
+func makeHunks(operations []operation, context int) (hunks []string) {
+	var (
+		fromLines = make([]int, len(operations)+1)
+		toLines   = make([]int, len(operations)+1)
+		start     = -1
+		end       = -1
+	)
+
+	for i, op := range operations {
+		fromLines[i+1], toLines[i+1] = fromLines[i], toLines[i]
+		if op.kind != '+' {
+			fromLines[i+1]++
+		}
+		if op.kind != '-' {
+			toLines[i+1]++
+		}
+	}
+	for i, op := range operations {
+		if op.kind == ' ' {
+			continue
+		}
+		// Like diff -u, the hunks are joined unless more than twice
+		// the context lines are unchanged between them.
+		if start >= 0 && i-context <= end+1 {
+			end = minInt(i+context, len(operations)-1)
+			continue
+		}
+		if start >= 0 {
+			hunks = append(hunks, makeHunk(operations, fromLines, toLines, start, end))
+		}
+		start = maxInt(i-context, 0)
+		end = minInt(i+context, len(operations)-1)
+	}
+	if start >= 0 {
+		hunks = append(hunks, makeHunk(operations, fromLines, toLines, start, end))
+	}
+
+	return hunks
+}
+
+func makeHunk(
+	operations []operation,
+	fromLines []int,
+	toLines []int,
+	start int,
+	end int,
+) (hunk string) {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("@@ -%s +%s @@\n",
+		hunkRange(fromLines[start], fromLines[end+1]-fromLines[start]),
+		hunkRange(toLines[start], toLines[end+1]-toLines[start])))
+	for _, op := range operations[start : end+1] {
+		builder.WriteByte(op.kind)
+		builder.WriteString(op.line + "\n")
+	}
+
+	return builder.String()
+}
+
+func hunkRange(offset int, length int) (formatted string) {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", offset)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", offset+1)
+	}
+
+	return fmt.Sprintf("%d,%d", offset+1, length)
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,84 @@
+package diff
+
+import "testing"
+
+func TestUnified(t *testing.T) {
+	// The expected hunks are the output of `diff -U<context>`.
+	var tests = []struct {
+		name     string
+		from     string
+		to       string
+		context  int
+		expected string
+	}{{
+		name:     "equal",
+		from:     "a\nb\n",
+		to:       "a\nb\n",
+		context:  DefaultContext,
+		expected: "",
+	}, {
+		name:     "both empty",
+		context:  DefaultContext,
+		expected: "",
+	}, {
+		name:     "insert into empty",
+		to:       "a\n",
+		context:  DefaultContext,
+		expected: "@@ -0,0 +1 @@\n+a\n",
+	}, {
+		name:     "delete all",
+		from:     "a\nb\n",
+		context:  DefaultContext,
+		expected: "@@ -1,2 +0,0 @@\n-a\n-b\n",
+	}, {
+		name:     "middle change",
+		from:     "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+		to:       "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+		context:  DefaultContext,
+		expected: "@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+	}, {
+		name:     "gap of twice the context",
+		from:     "a\n1\n2\n3\n4\n5\n6\nb\n",
+		to:       "A\n1\n2\n3\n4\n5\n6\nB\n",
+		context:  DefaultContext,
+		expected: "@@ -1,8 +1,8 @@\n-a\n+A\n 1\n 2\n 3\n 4\n 5\n 6\n-b\n+B\n",
+	}, {
+		name:    "gap beyond twice the context",
+		from:    "a\n1\n2\n3\n4\n5\n6\n7\nb\n",
+		to:      "A\n1\n2\n3\n4\n5\n6\n7\nB\n",
+		context: DefaultContext,
+		expected: "@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n" +
+			"@@ -6,4 +6,4 @@\n 5\n 6\n 7\n-b\n+B\n",
+	}, {
+		name:     "no context",
+		from:     "a\nb\nc\n",
+		to:       "a\nB\nc\n",
+		context:  0,
+		expected: "@@ -2 +2 @@\n-b\n+B\n",
+	}, {
+		name:     "append",
+		from:     "a\nb\n",
+		to:       "a\nb\nc\n",
+		context:  1,
+		expected: "@@ -2 +2,2 @@\n b\n+c\n",
+	}, {
+		name:     "missing final newline",
+		from:     "a\nb",
+		to:       "a\nb\n",
+		context:  DefaultContext,
+		expected: "",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var expected = test.expected
+
+			if expected != "" {
+				expected = "--- from\n+++ to\n" + expected
+			}
+			if unified := Unified("from", "to", test.from, test.to, test.context); unified != expected {
+				t.Errorf("Unified\n got: %q\nwant: %q", unified, expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package hash
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/misterabdul/goblog-server/pkg/crypto"
+)
+
+type Argon2idParams struct {
+	Memory     uint32 // KiB
+	Time       uint32
+	Threads    uint8
+	SaltLength uint32
+	KeyLength  uint32
+}
+
+// The OWASP recommended minimum parameters.
+var DefaultArgon2idParams = Argon2idParams{
+	Memory:     19456,
+	Time:       2,
+	Threads:    1,
+	SaltLength: 16,
+	KeyLength:  32}
+
+// Hasher using argon2id, its hash is in the PHC string format,
+// e.g. "$argon2id$v=19$m=19456,t=2,p=1$<salt>$<hash>".
+type Argon2id struct {
+	Params Argon2idParams
+}
+
+func NewArgon2id(params Argon2idParams) (hasher *Argon2id) {
+	if params.Memory == 0 || params.Time == 0 || params.Threads == 0 {
+		params = DefaultArgon2idParams
+	}
+	if params.SaltLength == 0 {
+		params.SaltLength = DefaultArgon2idParams.SaltLength
+	}
+	if params.KeyLength == 0 {
+		params.KeyLength = DefaultArgon2idParams.KeyLength
+	}
+
+	return &Argon2id{Params: params}
+}
+
+func (h *Argon2id) Make(secret string) (hashed string, err error) {
+	var (
+		salt []byte
+		key  []byte
+	)
+
+	if salt, err = crypto.GenerateRandomBytes(int(h.Params.SaltLength)); err != nil {
+		return "", err
+	}
+	key = argon2.IDKey(
+		[]byte(secret),
+		salt,
+		h.Params.Time,
+		h.Params.Memory,
+		h.Params.Threads,
+		h.Params.KeyLength)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.Params.Memory,
+		h.Params.Time,
+		h.Params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (h *Argon2id) Check(secret string, hashed string) (match bool) {
+	var (
+		params Argon2idParams
+		salt   []byte
+		key    []byte
+		err    error
+	)
+
+	if params, salt, key, err = decodeArgon2id(hashed); err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(key, argon2.IDKey(
+		[]byte(secret),
+		salt,
+		params.Time,
+		params.Memory,
+		params.Threads,
+		params.KeyLength)) == 1
+}
+
+func (h *Argon2id) Identify(hashed string) (identified bool) {
+	return strings.HasPrefix(hashed, "$argon2id$")
+}
+
+func (h *Argon2id) NeedsRehash(hashed string) (needed bool) {
+	var (
+		params Argon2idParams
+		err    error
+	)
+
+	if params, _, _, err = decodeArgon2id(hashed); err != nil {
+		return true
+	}
+
+	return params != h.Params
+}
+
+func decodeArgon2id(hashed string) (
+	params Argon2idParams,
+	salt []byte,
+	key []byte,
+	err error,
+) {
+	var (
+		parts   = strings.Split(hashed, "$")
+		version int
+	)
+
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return params, nil, nil, fmt.Errorf("invalid argon2id hash format")
+	}
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, err
+	}
+	if version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2 version: %d", version)
+	}
+	if _, err = fmt.Sscanf(
+		parts[3], "m=%d,t=%d,p=%d",
+		&params.Memory, &params.Time, &params.Threads,
+	); err != nil {
+		return params, nil, nil, err
+	}
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return params, nil, nil, err
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return params, nil, nil, err
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,52 @@
+package hash
+
+import "golang.org/x/crypto/bcrypt"
+
+const DefaultBcryptCost = bcrypt.DefaultCost
+
+// Hasher using bcrypt, its hash is in the modular crypt format,
+// e.g. "$2a$10$<salt><hash>".
+type Bcrypt struct {
+	Cost int
+}
+
+func NewBcrypt(cost int) (hasher *Bcrypt) {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = DefaultBcryptCost
+	}
+
+	return &Bcrypt{Cost: cost}
+}
+
+func (h *Bcrypt) Make(secret string) (hashed string, err error) {
+	var hashedBytes []byte
+
+	if hashedBytes, err = bcrypt.GenerateFromPassword(
+		[]byte(secret),
+		h.Cost,
+	); err != nil {
+		return "", err
+	}
+
+	return string(hashedBytes), nil
+}
+
+func (h *Bcrypt) Check(secret string, hashed string) (match bool) {
+	err := bcrypt.CompareHashAndPassword(
+		[]byte(hashed),
+		[]byte(secret))
+
+	return err == nil
+}
+
+func (h *Bcrypt) Identify(hashed string) (identified bool) {
+	_, err := bcrypt.Cost([]byte(hashed))
+
+	return err == nil
+}
+
+func (h *Bcrypt) NeedsRehash(hashed string) (needed bool) {
+	cost, err := bcrypt.Cost([]byte(hashed))
+
+	return err != nil || cost != h.Cost
+}
@@ -0,0 +1,34 @@
+package hash
+
+// Hasher of secrets, e.g. passwords, whose hashes encode
+// the algorithm & parameters used to make them.
+type Hasher interface {
+	// Create hashed string from given secret string.
+	Make(secret string) (hashed string, err error)
+	// Check the given secret against the hashed string.
+	Check(secret string, hashed string) (match bool)
+	// Check whether the hash is made by this hasher's algorithm.
+	Identify(hashed string) (identified bool)
+	// Check whether the hash is made with other algorithm or parameters
+	// than this hasher's, it should be remade on the next chance.
+	NeedsRehash(hashed string) (needed bool)
+}
+
+// All of the supported password algorithms, used to check any stored hash
+// regardless of the currently configured hasher. The tokens' hasher isn't
+// one of them, a bare digest must never pass as a password.
+var knownHashers = []Hasher{
+	NewArgon2id(DefaultArgon2idParams),
+	NewBcrypt(DefaultBcryptCost)}
+
+// Check the given secret against the hashed string
+// of any supported algorithm.
+func Check(secret string, hashed string) (match bool) {
+	for _, hasher := range knownHashers {
+		if hasher.Identify(hashed) {
+			return hasher.Check(secret, hashed)
+		}
+	}
+
+	return false
+}
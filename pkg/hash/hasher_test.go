@@ -0,0 +1,163 @@
+package hash
+
+import (
+	"strings"
+	"testing"
+)
+
+// Cheap argon2id parameters, so the tests don't take long.
+var testArgon2idParams = Argon2idParams{
+	Memory:     64,
+	Time:       1,
+	Threads:    1,
+	SaltLength: 16,
+	KeyLength:  32}
+
+func TestHashers(t *testing.T) {
+	var tests = []struct {
+		name   string
+		hasher Hasher
+		prefix string
+	}{{
+		name:   "argon2id",
+		hasher: NewArgon2id(testArgon2idParams),
+		prefix: "$argon2id$v=19$m=64,t=1,p=1$",
+	}, {
+		name:   "bcrypt",
+		hasher: NewBcrypt(4),
+		prefix: "$2a$04$",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hashed, err := test.hasher.Make("password")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(hashed, test.prefix) {
+				t.Errorf("Make = %q, want prefix %q", hashed, test.prefix)
+			}
+			if !test.hasher.Identify(hashed) {
+				t.Error("Identify of its own hash = false")
+			}
+			if !test.hasher.Check("password", hashed) {
+				t.Error("Check of the right secret = false")
+			}
+			if test.hasher.Check("Password", hashed) {
+				t.Error("Check of a wrong secret = true")
+			}
+			if test.hasher.NeedsRehash(hashed) {
+				t.Error("NeedsRehash of its own hash = true")
+			}
+			if again, _ := test.hasher.Make("password"); again == hashed {
+				t.Error("Make isn't salted")
+			}
+			if !Check("password", hashed) {
+				t.Error("package Check of a known algorithm = false")
+			}
+		})
+	}
+}
+
+func TestArgon2idNeedsRehash(t *testing.T) {
+	var (
+		hasher    = NewArgon2id(testArgon2idParams)
+		stronger  = testArgon2idParams
+		bcrypt, _ = NewBcrypt(4).Make("password")
+	)
+
+	stronger.Memory *= 2
+	hashed, err := hasher.Make("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name   string
+		hasher Hasher
+		hashed string
+		needed bool
+	}{
+		{"same parameters", hasher, hashed, false},
+		{"stronger parameters", NewArgon2id(stronger), hashed, true},
+		{"other algorithm", hasher, bcrypt, true},
+		{"malformed", hasher, "$argon2id$v=19$m=64$salt$key", true},
+		{"other version", hasher, strings.Replace(hashed, "v=19", "v=16", 1), true},
+		{"bcrypt of other cost", NewBcrypt(5), bcrypt, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if needed := test.hasher.NeedsRehash(test.hashed); needed != test.needed {
+				t.Errorf("NeedsRehash = %t, want %t", needed, test.needed)
+			}
+		})
+	}
+}
+
+func TestArgon2idParamsInHash(t *testing.T) {
+	var stronger = testArgon2idParams
+
+	stronger.Time = 2
+	hashed, err := NewArgon2id(stronger).Make("password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The hash is checked with its own parameters, not the hasher's.
+	if !NewArgon2id(testArgon2idParams).Check("password", hashed) {
+		t.Error("Check of a hash made with other parameters = false")
+	}
+	if NewArgon2id(testArgon2idParams).Check("password", strings.Replace(hashed, "t=2", "t=1", 1)) {
+		t.Error("Check of a hash with tampered parameters = true")
+	}
+}
+
+func TestNewArgon2idDefaults(t *testing.T) {
+	if hasher := NewArgon2id(Argon2idParams{}); hasher.Params != DefaultArgon2idParams {
+		t.Errorf("Params = %+v, want the defaults", hasher.Params)
+	}
+	if hasher := NewBcrypt(0); hasher.Cost != DefaultBcryptCost {
+		t.Errorf("Cost = %d, want the default", hasher.Cost)
+	}
+}
+
+func TestCheckUnknownHash(t *testing.T) {
+	var tests = []struct {
+		name   string
+		hashed string
+	}{
+		{"empty", ""},
+		{"plain", "password"},
+		// The token hasher's bare digest of "password" must never pass.
+		{"sha256", HashToken("password")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if Check("password", test.hashed) {
+				t.Errorf("Check of %q = true", test.hashed)
+			}
+		})
+	}
+}
+
+func TestToken(t *testing.T) {
+	token, hashedToken, err := MakeToken(32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if HashToken(token) != hashedToken {
+		t.Error("HashToken isn't deterministic")
+	}
+	if !CheckToken(token, hashedToken) || CheckToken(token+"x", hashedToken) {
+		t.Error("CheckToken doesn't match the token only")
+	}
+	if other, _, _ := MakeToken(32); other == token {
+		t.Error("MakeToken repeated the token")
+	}
+	// The hash stays the bare hex digest the tokens were stored with.
+	if hashed := HashToken("abc"); hashed !=
+		"ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad" {
+		t.Errorf("HashToken(abc) = %s", hashed)
+	}
+}
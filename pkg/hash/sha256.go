@@ -0,0 +1,44 @@
+package hash
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+)
+
+// Deterministic hasher using plain SHA-256, only for high entropy
+// random tokens, whose hashes are looked up by equality.
+//
+// The hash is the bare hex digest, as the tokens were stored before.
+type SHA256 struct{}
+
+func NewSHA256() (hasher *SHA256) {
+	return &SHA256{}
+}
+
+func (h *SHA256) Make(secret string) (hashed string, err error) {
+	var sum = sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (h *SHA256) Check(secret string, hashed string) (match bool) {
+	var sum = sha256.Sum256([]byte(secret))
+
+	return subtle.ConstantTimeCompare(
+		[]byte(hex.EncodeToString(sum[:])),
+		[]byte(hashed)) == 1
+}
+
+func (h *SHA256) Identify(hashed string) (identified bool) {
+	if len(hashed) != hex.EncodedLen(sha256.Size) {
+		return false
+	}
+	_, err := hex.DecodeString(hashed)
+
+	return err == nil
+}
+
+func (h *SHA256) NeedsRehash(hashed string) (needed bool) {
+	return !h.Identify(hashed)
+}
@@ -0,0 +1,32 @@
+package hash
+
+import "github.com/misterabdul/goblog-server/pkg/crypto"
+
+// Hasher of the random tokens, e.g. password reset & personal tokens,
+// it must be deterministic so the tokens can be looked up by their hash.
+var TokenHasher Hasher = NewSHA256()
+
+// Create new random URL-safe token string with its hashed form.
+func MakeToken(length int) (token string, hashedToken string, err error) {
+	if token, err = crypto.GenerateRandomStringURLSafe(length); err != nil {
+		return "", "", err
+	}
+	if hashedToken, err = TokenHasher.Make(token); err != nil {
+		return "", "", err
+	}
+
+	return token, hashedToken, nil
+}
+
+// Create hashed string from given token string,
+// the token hasher is deterministic & doesn't fail.
+func HashToken(token string) (hashedToken string) {
+	hashedToken, _ = TokenHasher.Make(token)
+
+	return hashedToken
+}
+
+// Check the given token against the hashed string of the token hasher.
+func CheckToken(token string, hashedToken string) (match bool) {
+	return TokenHasher.Identify(hashedToken) && TokenHasher.Check(token, hashedToken)
+}
@@ -0,0 +1,64 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"sort"
+)
+
+// Public key in the JSON Web Key format (RFC 7517).
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+// Get all public verification keys in JSON Web Key format,
+// the shared HMAC secrets are never published.
+func (s *KeySet) JSONWebKeys() (keys []JSONWebKey) {
+	keys = []JSONWebKey{}
+	for _, key := range s.Verification {
+		var jwk = JSONWebKey{
+			Use:       "sig",
+			KeyID:     key.ID,
+			Algorithm: key.Method.Alg()}
+
+		switch publicKey := key.PublicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = encodeBase64URL(publicKey.N.Bytes())
+			jwk.E = encodeBase64URL(big.NewInt(int64(publicKey.E)).Bytes())
+		case *ecdsa.PublicKey:
+			size := (publicKey.Curve.Params().BitSize + 7) / 8
+			jwk.KeyType = "EC"
+			jwk.Curve = publicKey.Curve.Params().Name
+			jwk.X = encodeBase64URL(publicKey.X.FillBytes(make([]byte, size)))
+			jwk.Y = encodeBase64URL(publicKey.Y.FillBytes(make([]byte, size)))
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = encodeBase64URL(publicKey)
+		default:
+			continue
+		}
+		keys = append(keys, jwk)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].KeyID < keys[j].KeyID
+	})
+
+	return keys
+}
+
+func encodeBase64URL(data []byte) (encoded string) {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
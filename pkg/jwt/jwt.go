@@ -13,12 +13,22 @@ type CustomClaims struct {
 	KeyID     string `json:"kid,omitempty"`
 	Id        string `json:"jti,omitempty"`
 	Type      string `json:"typ,omitempty"`
+	FamilyID  string `json:"fid,omitempty"`
+	Version   int64  `json:"ver,omitempty"`
 	Subject   string `json:"sub,omitempty"`
 	Audience  string `json:"aud,omitempty"`
 	Issuer    string `json:"iss,omitempty"`
 	IssuedAt  int64  `json:"iat,omitempty"`
 	NotBefore int64  `json:"nbf,omitempty"`
 	ExpiresAt int64  `json:"exp,omitempty"`
+
+	Actor *ActorClaim `json:"act,omitempty"`
+}
+
+// The party acting on behalf of the subject, e.g. while impersonating.
+type ActorClaim struct {
+	Subject string `json:"sub"`
+	Version int64  `json:"ver,omitempty"`
 }
 
 func (c CustomClaims) Valid() (err error) {
@@ -58,15 +68,29 @@ func Issue(
 	claimType string,
 	subject string,
 	duration time.Duration,
-	secret string,
+	keys *KeySet,
 ) (
 	claims *CustomClaims,
 	tokenString string,
 	err error,
 ) {
+	claims = NewClaims(claimType, subject, duration)
+	if tokenString, err = IssueClaims(claims, keys); err != nil {
+		return nil, "", err
+	}
+
+	return claims, tokenString, nil
+}
+
+func NewClaims(
+	claimType string,
+	subject string,
+	duration time.Duration,
+) (claims *CustomClaims) {
 	now := time.Now()
 	tokenID := primitive.NewObjectID().Hex()
-	claims = &CustomClaims{
+
+	return &CustomClaims{
 		Id:        tokenID,
 		Type:      claimType,
 		Subject:   subject,
@@ -75,21 +99,20 @@ func Issue(
 		IssuedAt:  now.Unix(),
 		NotBefore: now.Unix(),
 		ExpiresAt: now.Add(duration).Unix()}
-	if tokenString, err = IssueClaims(claims, secret); err != nil {
-		return nil, "", err
-	}
-
-	return claims, tokenString, nil
 }
 
-func IssueClaims(claims *CustomClaims, secret string) (tokenString string, err error) {
-	jwtClaim := jwt.NewWithClaims(jwt.SigningMethodHS512, *claims)
-	tokenString, err = jwtClaim.SignedString([]byte(secret))
+// Sign the claims with the signing key, stamping its key ID.
+func IssueClaims(claims *CustomClaims, keys *KeySet) (tokenString string, err error) {
+	claims.KeyID = keys.Signing.ID
+	jwtClaim := jwt.NewWithClaims(keys.Signing.Method, *claims)
+	jwtClaim.Header["kid"] = keys.Signing.ID
+	tokenString, err = jwtClaim.SignedString(keys.Signing.PrivateKey)
 
 	return tokenString, err
 }
 
-func Check(tokenString string, secret string) (claims *CustomClaims, err error) {
+// Parse & validate the token with the verification key of its key ID.
+func Check(tokenString string, keys *KeySet) (claims *CustomClaims, err error) {
 	var (
 		token     *jwt.Token
 		rawClaims = CustomClaims{}
@@ -100,7 +123,19 @@ func Check(tokenString string, secret string) (claims *CustomClaims, err error)
 		tokenString,
 		&rawClaims,
 		func(token *jwt.Token) (interface{}, error) {
-			return []byte(secret), nil
+			var (
+				keyID, _ = token.Header["kid"].(string)
+				key      = keys.lookup(keyID)
+			)
+
+			if key == nil {
+				return nil, errors.New("unknown key id")
+			}
+			if token.Method.Alg() != key.Method.Alg() {
+				return nil, errors.New("unexpected signing method")
+			}
+
+			return key.PublicKey, nil
 		}); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,269 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// Generate PEM encoded private key of the signing method,
+// in the encoding commonly produced by openssl for its type.
+func generatePrivateKeyPEM(t *testing.T, methodName string) (pemBytes []byte) {
+	var (
+		block = &pem.Block{}
+		der   []byte
+		err   error
+	)
+
+	switch methodName {
+	case "RS256":
+		var privateKey *rsa.PrivateKey
+		if privateKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+			t.Fatal(err)
+		}
+		block.Type, der = "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(privateKey)
+	case "ES256":
+		var privateKey *ecdsa.PrivateKey
+		if privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+			t.Fatal(err)
+		}
+		if der, err = x509.MarshalECPrivateKey(privateKey); err != nil {
+			t.Fatal(err)
+		}
+		block.Type = "EC PRIVATE KEY"
+	case "EdDSA":
+		var privateKey ed25519.PrivateKey
+		if _, privateKey, err = ed25519.GenerateKey(rand.Reader); err != nil {
+			t.Fatal(err)
+		}
+		if der, err = x509.MarshalPKCS8PrivateKey(privateKey); err != nil {
+			t.Fatal(err)
+		}
+		block.Type = "PRIVATE KEY"
+	default:
+		t.Fatalf("unsupported method %s", methodName)
+	}
+	block.Bytes = der
+
+	return pem.EncodeToMemory(block)
+}
+
+func parseTestKey(t *testing.T, methodName string) (key *Key) {
+	var err error
+
+	if methodName == "HS512" {
+		return NewHMACKey("hmac", "secret")
+	}
+	if key, err = ParsePrivateKeyPEM(generatePrivateKeyPEM(t, methodName), methodName); err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+func TestIssueCheck(t *testing.T) {
+	for _, methodName := range []string{"HS512", "RS256", "ES256", "EdDSA"} {
+		t.Run(methodName, func(t *testing.T) {
+			var keys = NewKeySet(parseTestKey(t, methodName))
+
+			issued, tokenString, err := Issue("access", "subject", time.Minute, keys)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if issued.KeyID != keys.Signing.ID {
+				t.Errorf("KeyID = %q, want %q", issued.KeyID, keys.Signing.ID)
+			}
+			claims, err := Check(tokenString, keys)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *claims != *issued {
+				t.Errorf("Check = %+v, want %+v", claims, issued)
+			}
+		})
+	}
+}
+
+func TestCheckKeySelection(t *testing.T) {
+	var (
+		previous = parseTestKey(t, "RS256")
+		current  = parseTestKey(t, "ES256")
+		hmacKey  = parseTestKey(t, "HS512")
+		rotated  = NewKeySet(current, previous, hmacKey)
+	)
+
+	issue := func(keys *KeySet, duration time.Duration) (tokenString string) {
+		_, tokenString, err := Issue("access", "subject", duration, keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tokenString
+	}
+	sign := func(method jwt.SigningMethod, keyID string, privateKey interface{}) (tokenString string) {
+		token := jwt.NewWithClaims(method, *NewClaims("access", "subject", time.Minute))
+		if keyID != "" {
+			token.Header["kid"] = keyID
+		}
+		tokenString, err := token.SignedString(privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tokenString
+	}
+
+	var tests = []struct {
+		name        string
+		tokenString string
+		err         string
+	}{{
+		name:        "current key",
+		tokenString: issue(rotated, time.Minute),
+	}, {
+		name:        "previous key",
+		tokenString: issue(NewKeySet(previous), time.Minute),
+	}, {
+		name:        "no key id falls back to hmac",
+		tokenString: sign(jwt.SigningMethodHS512, "", []byte("secret")),
+	}, {
+		name:        "unknown key id",
+		tokenString: issue(NewKeySet(parseTestKey(t, "EdDSA")), time.Minute),
+		err:         "unknown key id",
+	}, {
+		name:        "algorithm of another key",
+		tokenString: sign(jwt.SigningMethodHS512, previous.ID, []byte("secret")),
+		err:         "unexpected signing method",
+	}, {
+		name:        "public key as hmac secret",
+		tokenString: sign(jwt.SigningMethodHS512, current.ID, []byte(current.ID)),
+		err:         "unexpected signing method",
+	}, {
+		name:        "expired",
+		tokenString: issue(rotated, -time.Minute),
+		err:         "expired",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Check(test.tokenString, rotated)
+			switch {
+			case test.err == "" && err != nil:
+				t.Errorf("Check error = %v", err)
+			case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+				t.Errorf("Check error = %v, want %q", err, test.err)
+			}
+		})
+	}
+}
+
+func TestParsePrivateKeyPEM(t *testing.T) {
+	var pemBytes = generatePrivateKeyPEM(t, "RS256")
+
+	if _, err := ParsePrivateKeyPEM(pemBytes, "ES256"); err == nil {
+		t.Error("ParsePrivateKeyPEM of a RSA key for ES256 succeeded")
+	}
+	if _, err := ParsePrivateKeyPEM([]byte("not a pem"), "RS256"); err == nil {
+		t.Error("ParsePrivateKeyPEM of an invalid PEM succeeded")
+	}
+}
+
+func TestParseVerificationKeyPEM(t *testing.T) {
+	for _, methodName := range []string{"RS256", "ES256", "EdDSA"} {
+		t.Run(methodName, func(t *testing.T) {
+			var (
+				pemBytes   = generatePrivateKeyPEM(t, methodName)
+				signing, _ = ParsePrivateKeyPEM(pemBytes, methodName)
+			)
+
+			fromPrivate, err := ParseVerificationKeyPEM(pemBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			der, err := x509.MarshalPKIXPublicKey(signing.PublicKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fromPublic, err := ParseVerificationKeyPEM(
+				pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, key := range []*Key{fromPrivate, fromPublic} {
+				if key.ID != signing.ID || key.Method != signing.Method || key.PrivateKey != nil {
+					t.Errorf("verification key = %+v, want ID %s & method %s without private key",
+						key, signing.ID, methodName)
+				}
+			}
+
+			_, tokenString, err := Issue("access", "subject", time.Minute, NewKeySet(signing))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = Check(tokenString, NewKeySet(signing, fromPublic)); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestJSONWebKeys(t *testing.T) {
+	var (
+		rsaKey     = parseTestKey(t, "RS256")
+		ecKey      = parseTestKey(t, "ES256")
+		ed25519Key = parseTestKey(t, "EdDSA")
+		keys       = NewKeySet(rsaKey, ecKey, ed25519Key, parseTestKey(t, "HS512"))
+		byID       = map[string]JSONWebKey{}
+	)
+
+	jwks := keys.JSONWebKeys()
+	if len(jwks) != 3 {
+		t.Fatalf("len(JSONWebKeys) = %d, want 3 without the hmac key", len(jwks))
+	}
+	for i, jwk := range jwks {
+		if i > 0 && jwks[i-1].KeyID >= jwk.KeyID {
+			t.Errorf("JSONWebKeys aren't sorted by key ID")
+		}
+		if jwk.Use != "sig" {
+			t.Errorf("Use = %q, want sig", jwk.Use)
+		}
+		byID[jwk.KeyID] = jwk
+	}
+
+	rsaJWK := byID[rsaKey.ID]
+	n, _ := base64.RawURLEncoding.DecodeString(rsaJWK.N)
+	e, _ := base64.RawURLEncoding.DecodeString(rsaJWK.E)
+	rsaPublicKey := rsaKey.PublicKey.(*rsa.PublicKey)
+	if rsaJWK.KeyType != "RSA" || rsaJWK.Algorithm != "RS256" ||
+		new(big.Int).SetBytes(n).Cmp(rsaPublicKey.N) != 0 ||
+		int(new(big.Int).SetBytes(e).Int64()) != rsaPublicKey.E {
+		t.Errorf("RSA JWK = %+v", rsaJWK)
+	}
+
+	ecJWK := byID[ecKey.ID]
+	x, _ := base64.RawURLEncoding.DecodeString(ecJWK.X)
+	y, _ := base64.RawURLEncoding.DecodeString(ecJWK.Y)
+	ecPublicKey := ecKey.PublicKey.(*ecdsa.PublicKey)
+	if ecJWK.KeyType != "EC" || ecJWK.Algorithm != "ES256" || ecJWK.Curve != "P-256" ||
+		len(x) != 32 || len(y) != 32 ||
+		new(big.Int).SetBytes(x).Cmp(ecPublicKey.X) != 0 ||
+		new(big.Int).SetBytes(y).Cmp(ecPublicKey.Y) != 0 {
+		t.Errorf("EC JWK = %+v", ecJWK)
+	}
+
+	okpJWK := byID[ed25519Key.ID]
+	okpX, _ := base64.RawURLEncoding.DecodeString(okpJWK.X)
+	if okpJWK.KeyType != "OKP" || okpJWK.Algorithm != "EdDSA" || okpJWK.Curve != "Ed25519" ||
+		!ed25519Key.PublicKey.(ed25519.PublicKey).Equal(ed25519.PublicKey(okpX)) {
+		t.Errorf("OKP JWK = %+v", okpJWK)
+	}
+}
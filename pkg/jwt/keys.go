@@ -0,0 +1,173 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// A single signing or verification key identified by its key ID.
+//
+// PrivateKey is only needed for signing, it's the shared secret for HMAC.
+type Key struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PrivateKey interface{}
+	PublicKey  interface{}
+}
+
+// The key used to sign new tokens, along with all keys still accepted
+// for verification, e.g. the previous keys during the key rotation.
+type KeySet struct {
+	Signing      *Key
+	Verification map[string]*Key
+}
+
+func NewKeySet(signing *Key, verification ...*Key) (keySet *KeySet) {
+	keySet = &KeySet{
+		Signing:      signing,
+		Verification: map[string]*Key{signing.ID: signing}}
+	for _, key := range verification {
+		if _, exists := keySet.Verification[key.ID]; !exists {
+			keySet.Verification[key.ID] = key
+		}
+	}
+
+	return keySet
+}
+
+// Get the verification key of the key ID.
+//
+// Tokens issued before key IDs were stamped have none,
+// they're all signed with the shared HMAC secret.
+func (s *KeySet) lookup(keyID string) (key *Key) {
+	if keyID != "" {
+		return s.Verification[keyID]
+	}
+	for _, key = range s.Verification {
+		if key.Method == jwt.SigningMethodHS512 {
+			return key
+		}
+	}
+
+	return nil
+}
+
+// Create HS512 key from the shared secret.
+func NewHMACKey(id string, secret string) (key *Key) {
+	return &Key{
+		ID:         id,
+		Method:     jwt.SigningMethodHS512,
+		PrivateKey: []byte(secret),
+		PublicKey:  []byte(secret)}
+}
+
+// Parse PEM encoded private key (PKCS#8, PKCS#1 or SEC 1)
+// to be signed with the given method.
+//
+// Supported methods: RS256, ES256 & EdDSA.
+func ParsePrivateKeyPEM(pemBytes []byte, methodName string) (key *Key, err error) {
+	var (
+		block      *pem.Block
+		privateKey interface{}
+		signer     crypto.Signer
+		ok         bool
+	)
+
+	if block, _ = pem.Decode(pemBytes); block == nil {
+		return nil, errors.New("invalid PEM encoded private key")
+	}
+	if privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes); err != nil {
+		if privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+			if privateKey, err = x509.ParseECPrivateKey(block.Bytes); err != nil {
+				return nil, errors.New("unsupported private key format")
+			}
+		}
+	}
+	if signer, ok = privateKey.(crypto.Signer); !ok {
+		return nil, errors.New("unsupported private key type")
+	}
+	if key, err = newPublicKey(signer.Public()); err != nil {
+		return nil, err
+	}
+	if key.Method.Alg() != methodName {
+		return nil, fmt.Errorf(
+			"private key of %s can't be used for %s", key.Method.Alg(), methodName)
+	}
+	key.PrivateKey = privateKey
+
+	return key, nil
+}
+
+// Parse PEM encoded public key (PKIX) or private key,
+// the signing method is determined by the key type.
+func ParseVerificationKeyPEM(pemBytes []byte) (key *Key, err error) {
+	var (
+		block     *pem.Block
+		publicKey interface{}
+	)
+
+	if block, _ = pem.Decode(pemBytes); block == nil {
+		return nil, errors.New("invalid PEM encoded public key")
+	}
+	if publicKey, err = x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return newPublicKey(publicKey)
+	}
+	for _, methodName := range []string{"RS256", "ES256", "ES384", "ES512", "EdDSA"} {
+		if key, err = ParsePrivateKeyPEM(pemBytes, methodName); err == nil {
+			key.PrivateKey = nil
+			return key, nil
+		}
+	}
+
+	return nil, errors.New("unsupported public key format")
+}
+
+// Create verification key of the public key,
+// identified by the truncated SHA-256 thumbprint of its PKIX form.
+func newPublicKey(publicKey interface{}) (key *Key, err error) {
+	var (
+		method jwt.SigningMethod
+		der    []byte
+		sum    [32]byte
+	)
+
+	switch publicKey := publicKey.(type) {
+	case *rsa.PublicKey:
+		method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		switch publicKey.Curve {
+		case elliptic.P256():
+			method = jwt.SigningMethodES256
+		case elliptic.P384():
+			method = jwt.SigningMethodES384
+		case elliptic.P521():
+			method = jwt.SigningMethodES512
+		default:
+			return nil, errors.New("unsupported elliptic curve")
+		}
+	case ed25519.PublicKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return nil, errors.New("unsupported public key type")
+	}
+	if der, err = x509.MarshalPKIXPublicKey(publicKey); err != nil {
+		return nil, err
+	}
+	sum = sha256.Sum256(der)
+
+	return &Key{
+		ID:        base64.RawURLEncoding.EncodeToString(sum[:12]),
+		Method:    method,
+		PublicKey: publicKey}, nil
+}
@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/misterabdul/goblog-server/pkg/crypto"
+)
+
+// Write messages as .eml files into an outbox directory,
+// useful for development & testing without any SMTP server.
+type FileTransport struct {
+	Directory string
+}
+
+func NewFileTransport(directory string) (transport *FileTransport) {
+	return &FileTransport{
+		Directory: directory}
+}
+
+func (t *FileTransport) Send(ctx context.Context, message *Message) (err error) {
+	var (
+		suffix   string
+		fileName string
+	)
+
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+	if err = os.MkdirAll(t.Directory, 0o755); err != nil {
+		return err
+	}
+	if suffix, err = crypto.GenerateRandomString(8); err != nil {
+		return err
+	}
+	fileName = fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), suffix)
+
+	return os.WriteFile(filepath.Join(t.Directory, fileName), message.Bytes(), 0o644)
+}
@@ -0,0 +1,38 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Common mail transport functionalities.
+type Transport interface {
+	// Deliver the given message.
+	Send(ctx context.Context, message *Message) (err error)
+}
+
+// Plain text mail message.
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Encode the message into RFC 822 formatted bytes.
+func (m *Message) Bytes() (data []byte) {
+	var buffer bytes.Buffer
+
+	fmt.Fprintf(&buffer, "From: %s\r\n", m.From)
+	fmt.Fprintf(&buffer, "To: %s\r\n", strings.Join(m.To, ", "))
+	fmt.Fprintf(&buffer, "Subject: %s\r\n", m.Subject)
+	fmt.Fprintf(&buffer, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buffer, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buffer, "Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	fmt.Fprintf(&buffer, "\r\n%s\r\n", m.Body)
+
+	return buffer.Bytes()
+}
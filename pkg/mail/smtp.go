@@ -0,0 +1,40 @@
+package mail
+
+import (
+	"context"
+	"net/smtp"
+)
+
+// Deliver messages through an SMTP server.
+type SMTPTransport struct {
+	Address  string
+	Username string
+	Password string
+	Host     string
+}
+
+func NewSMTPTransport(
+	host string,
+	port string,
+	username string,
+	password string,
+) (transport *SMTPTransport) {
+	return &SMTPTransport{
+		Address:  host + ":" + port,
+		Username: username,
+		Password: password,
+		Host:     host}
+}
+
+func (t *SMTPTransport) Send(ctx context.Context, message *Message) (err error) {
+	var auth smtp.Auth
+
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+	if len(t.Username) > 0 {
+		auth = smtp.PlainAuth("", t.Username, t.Password, t.Host)
+	}
+
+	return smtp.SendMail(t.Address, auth, message.From, message.To, message.Bytes())
+}
@@ -0,0 +1,611 @@
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	blockParagraph = iota
+	blockHeading
+	blockThematicBreak
+	blockCode
+	blockQuote
+	blockList
+	blockListItem
+	blockTable
+)
+
+type block struct {
+	kind       int
+	afterBlank bool
+	level      int
+	text       string
+	info       string
+	children   []*block
+	ordered    bool
+	start      int
+	tight      bool
+	aligns     []string
+	rows       [][]string
+}
+
+type listMarker struct {
+	ordered bool
+	char    byte
+	start   int
+	indent  int
+	content string
+	empty   bool
+}
+
+var (
+	atxHeadingRegex    = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ ]+(.*?))?(?:[ ]+#+)?[ ]*$`)
+	thematicBreakRegex = regexp.MustCompile(`^ {0,3}(?:(?:\*[ ]*){3,}|(?:-[ ]*){3,}|(?:_[ ]*){3,})$`)
+	fenceRegex         = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})(.*)$")
+	setextRegex        = regexp.MustCompile(`^ {0,3}(=+|-+)[ ]*$`)
+	bulletRegex        = regexp.MustCompile(`^( {0,3})([-+*])( +|$)`)
+	orderedRegex       = regexp.MustCompile(`^( {0,3})([0-9]{1,9})([.)])( +|$)`)
+	footnoteDefRegex   = regexp.MustCompile(`^ {0,3}\[\^([^\]\s]+)\]:[ ]*(.*)$`)
+	referenceDefRegex  = regexp.MustCompile(`^ {0,3}\[([^\]]+)\]:[ ]*(<[^>\n]*>|\S+)(?:[ ]+("(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|\((?:[^)\\]|\\.)*\)))?[ ]*$`)
+	tableDelimiterCell = regexp.MustCompile(`^:?-+:?$`)
+)
+
+func (r *renderer) parseBlocks(lines []string) (blocks []*block) {
+	var afterBlank bool
+
+	for i := 0; i < len(lines); {
+		var (
+			line    = lines[i]
+			current *block
+		)
+
+		switch {
+		case isBlank(line):
+			afterBlank = true
+			i++
+			continue
+		case indentOf(line) >= 4:
+			current, i = parseIndentedCode(lines, i)
+		case fenceRegex.MatchString(line) && isFenceOpening(line):
+			current, i = parseFencedCode(lines, i)
+		case atxHeadingRegex.MatchString(line):
+			matches := atxHeadingRegex.FindStringSubmatch(line)
+			current = &block{kind: blockHeading, level: len(matches[1]), text: strings.TrimSpace(matches[2])}
+			i++
+		case thematicBreakRegex.MatchString(line):
+			current = &block{kind: blockThematicBreak}
+			i++
+		case isQuoteLine(line):
+			current, i = r.parseQuote(lines, i)
+		case footnoteDefRegex.MatchString(line):
+			i = r.parseFootnoteDefinition(lines, i)
+			continue
+		case referenceDefRegex.MatchString(line) && r.parseReferenceDefinition(line):
+			i++
+			continue
+		case parseListMarker(line) != nil:
+			current, i = r.parseList(lines, i)
+		case isTableStart(lines, i):
+			current, i = parseTable(lines, i)
+		default:
+			current, i = parseParagraph(lines, i)
+		}
+		current.afterBlank = afterBlank
+		afterBlank = false
+		blocks = append(blocks, current)
+	}
+
+	return blocks
+}
+
+func parseIndentedCode(lines []string, i int) (code *block, next int) {
+	var codeLines []string
+
+	for ; i < len(lines); i++ {
+		if isBlank(lines[i]) {
+			codeLines = append(codeLines, "")
+			continue
+		}
+		if indentOf(lines[i]) < 4 {
+			break
+		}
+		codeLines = append(codeLines, lines[i][4:])
+	}
+	for len(codeLines) > 0 && codeLines[len(codeLines)-1] == "" {
+		codeLines = codeLines[:len(codeLines)-1]
+	}
+
+	return &block{kind: blockCode, text: strings.Join(codeLines, "\n") + "\n"}, i
+}
+
+func isFenceOpening(line string) (opening bool) {
+	var matches = fenceRegex.FindStringSubmatch(line)
+
+	return matches[2][0] != '`' || !strings.Contains(matches[3], "`")
+}
+
+func parseFencedCode(lines []string, i int) (code *block, next int) {
+	var (
+		matches   = fenceRegex.FindStringSubmatch(lines[i])
+		indent    = len(matches[1])
+		fence     = matches[2]
+		info      = strings.Fields(unescapeBackslashes(strings.TrimSpace(matches[3])))
+		codeLines []string
+	)
+
+	code = &block{kind: blockCode}
+	if len(info) > 0 {
+		code.info = info[0]
+	}
+	for i++; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if indentOf(lines[i]) < 4 && strings.HasPrefix(trimmed, fence) &&
+			strings.Trim(trimmed, fence[:1]) == "" {
+			i++
+			break
+		}
+		codeLines = append(codeLines, removeIndent(lines[i], indent))
+	}
+	if len(codeLines) > 0 {
+		code.text = strings.Join(codeLines, "\n") + "\n"
+	}
+
+	return code, i
+}
+
+func isQuoteLine(line string) (quote bool) {
+	return indentOf(line) < 4 && strings.HasPrefix(strings.TrimLeft(line, " "), ">")
+}
+
+func (r *renderer) parseQuote(lines []string, i int) (quote *block, next int) {
+	var (
+		quoteLines []string
+		lazy       bool
+	)
+
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case isQuoteLine(line):
+			content := strings.TrimPrefix(strings.TrimLeft(line, " "), ">")
+			content = strings.TrimPrefix(content, " ")
+			quoteLines = append(quoteLines, content)
+			lazy = !isBlank(content) && !fenceRegex.MatchString(content)
+		case lazy && !isBlank(line) && !startsBlock(line):
+			// Lazy continuation of the quoted paragraph.
+			quoteLines = append(quoteLines, line)
+		default:
+			return &block{kind: blockQuote, children: r.parseBlocks(quoteLines)}, i
+		}
+	}
+
+	return &block{kind: blockQuote, children: r.parseBlocks(quoteLines)}, i
+}
+
+func (r *renderer) parseFootnoteDefinition(lines []string, i int) (next int) {
+	var (
+		matches   = footnoteDefRegex.FindStringSubmatch(lines[i])
+		label     = normalizeLabel(matches[1])
+		noteLines = []string{matches[2]}
+	)
+
+	for i++; i < len(lines); i++ {
+		line := lines[i]
+		if isBlank(line) {
+			if nextNonBlankIndent(lines, i) < 4 {
+				break
+			}
+			noteLines = append(noteLines, "")
+			continue
+		}
+		if indentOf(line) >= 4 {
+			noteLines = append(noteLines, line[4:])
+			continue
+		}
+		if isBlank(lines[i-1]) || startsBlock(line) || footnoteDefRegex.MatchString(line) {
+			break
+		}
+		noteLines = append(noteLines, line)
+	}
+	if _, exist := r.footnotes[label]; !exist {
+		r.footnotes[label] = &footnote{label: label, blocks: r.parseBlocks(noteLines)}
+	}
+
+	return i
+}
+
+// Parse the link reference definition, it's a paragraph line instead
+// when the destination is an unclosed angle bracket.
+func (r *renderer) parseReferenceDefinition(line string) (parsed bool) {
+	var (
+		matches     = referenceDefRegex.FindStringSubmatch(line)
+		label       = normalizeLabel(matches[1])
+		destination = matches[2]
+		title       = matches[3]
+	)
+
+	if strings.HasPrefix(destination, "<") {
+		if len(destination) < 2 || !strings.HasSuffix(destination, ">") {
+			return false
+		}
+		destination = destination[1 : len(destination)-1]
+	}
+	if _, exist := r.references[label]; exist {
+		return true
+	}
+	if len(title) >= 2 {
+		title = title[1 : len(title)-1]
+	}
+	r.references[label] = linkReference{
+		destination: unescapeBackslashes(destination),
+		title:       unescapeBackslashes(title)}
+
+	return true
+}
+
+func parseListMarker(line string) (marker *listMarker) {
+	var matches []string
+
+	if matches = bulletRegex.FindStringSubmatch(line); matches != nil {
+		marker = &listMarker{char: matches[2][0]}
+	} else if matches = orderedRegex.FindStringSubmatch(line); matches != nil {
+		start, _ := strconv.Atoi(matches[2])
+		marker = &listMarker{ordered: true, char: matches[3][0], start: start}
+	} else {
+		return nil
+	}
+	var (
+		width   = len(matches[0]) - len(matches[len(matches)-1])
+		spacing = len(matches[len(matches)-1])
+	)
+
+	marker.content = line[len(matches[0]):]
+	marker.empty = isBlank(marker.content)
+	switch {
+	case marker.empty:
+		marker.indent = width + 1
+		marker.content = ""
+	case spacing > 4:
+		// The content starting with indented code keeps its indentation.
+		marker.indent = width + 1
+		marker.content = strings.Repeat(" ", spacing-1) + marker.content
+	default:
+		marker.indent = width + spacing
+	}
+
+	return marker
+}
+
+func (r *renderer) parseList(lines []string, i int) (list *block, next int) {
+	var first = parseListMarker(lines[i])
+
+	list = &block{kind: blockList, ordered: first.ordered, start: first.start, tight: true}
+	for i < len(lines) {
+		var (
+			marker    = parseListMarker(lines[i])
+			itemLines []string
+			item      *block
+		)
+
+		if !isSameList(first, marker) || thematicBreakRegex.MatchString(lines[i]) {
+			break
+		}
+		itemLines = append(itemLines, marker.content)
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+			if isBlank(line) {
+				if marker.empty && len(itemLines) == 1 {
+					// The item starting with a blank line can't continue after another.
+					break
+				}
+				if nextNonBlankIndent(lines, i) < marker.indent {
+					break
+				}
+				itemLines = append(itemLines, "")
+				continue
+			}
+			if indentOf(line) >= marker.indent {
+				itemLines = append(itemLines, line[marker.indent:])
+				continue
+			}
+			if isBlank(lines[i-1]) || startsBlock(line) || parseListMarker(line) != nil {
+				break
+			}
+			// Lazy continuation of the item's paragraph.
+			itemLines = append(itemLines, strings.TrimLeft(line, " "))
+		}
+		item = &block{kind: blockListItem, children: r.parseBlocks(itemLines)}
+		for index, child := range item.children {
+			if index > 0 && child.afterBlank {
+				list.tight = false
+			}
+		}
+		list.children = append(list.children, item)
+		if i < len(lines) && isBlank(lines[i]) {
+			blankStart := i
+			for i < len(lines) && isBlank(lines[i]) {
+				i++
+			}
+			if i >= len(lines) || !isSameList(first, parseListMarker(lines[i])) ||
+				thematicBreakRegex.MatchString(lines[i]) {
+				return list, blankStart
+			}
+			list.tight = false
+		}
+	}
+
+	return list, i
+}
+
+func isSameList(first *listMarker, marker *listMarker) (same bool) {
+	return marker != nil && marker.ordered == first.ordered && marker.char == first.char
+}
+
+func isTableStart(lines []string, i int) (start bool) {
+	var aligns []string
+
+	if i+1 >= len(lines) || !strings.Contains(lines[i], "|") {
+		return false
+	}
+	if aligns = parseTableDelimiter(lines[i+1]); aligns == nil {
+		return false
+	}
+
+	return len(splitTableRow(lines[i])) == len(aligns)
+}
+
+func parseTableDelimiter(line string) (aligns []string) {
+	if !strings.Contains(line, "-") || indentOf(line) >= 4 {
+		return nil
+	}
+	for _, cell := range splitTableRow(line) {
+		if !tableDelimiterCell.MatchString(cell) {
+			return nil
+		}
+		switch {
+		case strings.HasPrefix(cell, ":") && strings.HasSuffix(cell, ":"):
+			aligns = append(aligns, "center")
+		case strings.HasPrefix(cell, ":"):
+			aligns = append(aligns, "left")
+		case strings.HasSuffix(cell, ":"):
+			aligns = append(aligns, "right")
+		default:
+			aligns = append(aligns, "")
+		}
+	}
+
+	return aligns
+}
+
+func splitTableRow(line string) (cells []string) {
+	var (
+		trimmed = strings.TrimSpace(line)
+		cell    strings.Builder
+	)
+
+	trimmed = strings.TrimPrefix(trimmed, "|")
+	if strings.HasSuffix(trimmed, "|") && !strings.HasSuffix(trimmed, "\\|") {
+		trimmed = strings.TrimSuffix(trimmed, "|")
+	}
+	for i := 0; i < len(trimmed); i++ {
+		switch {
+		case trimmed[i] == '\\' && i+1 < len(trimmed) && trimmed[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case trimmed[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(trimmed[i])
+		}
+	}
+
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+func parseTable(lines []string, i int) (table *block, next int) {
+	table = &block{
+		kind:   blockTable,
+		aligns: parseTableDelimiter(lines[i+1]),
+		rows:   [][]string{splitTableRow(lines[i])}}
+	for i += 2; i < len(lines); i++ {
+		if isBlank(lines[i]) || startsBlock(lines[i]) {
+			break
+		}
+		row := splitTableRow(lines[i])
+		for len(row) < len(table.aligns) {
+			row = append(row, "")
+		}
+		table.rows = append(table.rows, row[:len(table.aligns)])
+	}
+
+	return table, i
+}
+
+func parseParagraph(lines []string, i int) (paragraph *block, next int) {
+	var paragraphLines = []string{strings.TrimLeft(lines[i], " ")}
+
+	for i++; i < len(lines); i++ {
+		line := lines[i]
+		if matches := setextRegex.FindStringSubmatch(line); matches != nil {
+			level := 2
+			if matches[1][0] == '=' {
+				level = 1
+			}
+			return &block{
+				kind:  blockHeading,
+				level: level,
+				text:  strings.TrimSpace(strings.Join(paragraphLines, "\n"))}, i + 1
+		}
+		if isBlank(line) || startsBlock(line) || isTableStart(lines, i) {
+			break
+		}
+		paragraphLines = append(paragraphLines, strings.TrimLeft(line, " "))
+	}
+
+	return &block{
+		kind: blockParagraph,
+		text: strings.TrimRight(strings.Join(paragraphLines, "\n"), " ")}, i
+}
+
+// Check whether the line starts a block interrupting a paragraph.
+func startsBlock(line string) (starts bool) {
+	if indentOf(line) >= 4 {
+		return false
+	}
+	if atxHeadingRegex.MatchString(line) || thematicBreakRegex.MatchString(line) ||
+		isQuoteLine(line) || (fenceRegex.MatchString(line) && isFenceOpening(line)) {
+		return true
+	}
+	if marker := parseListMarker(line); marker != nil {
+		return !marker.empty && (!marker.ordered || marker.start == 1)
+	}
+
+	return false
+}
+
+func (r *renderer) renderBlocks(builder *strings.Builder, blocks []*block, tight bool) {
+	for _, current := range blocks {
+		switch current.kind {
+		case blockParagraph:
+			if tight {
+				builder.WriteString(r.renderInline(current.text) + "\n")
+			} else {
+				builder.WriteString("<p>" + r.renderInline(current.text) + "</p>\n")
+			}
+		case blockHeading:
+			var (
+				nodes  = r.parseInline(current.text)
+				text   = plainText(nodes)
+				anchor = r.anchor(text)
+			)
+
+			r.headings = append(r.headings, Heading{
+				Level:  current.level,
+				Text:   text,
+				Anchor: anchor})
+			builder.WriteString(fmt.Sprintf("<h%d id=\"%s\">%s</h%d>\n",
+				current.level, escapeHTML(anchor), r.renderNodes(nodes), current.level))
+			r.text.WriteString(" ")
+		case blockThematicBreak:
+			builder.WriteString("<hr />\n")
+		case blockCode:
+			if len(current.info) > 0 {
+				builder.WriteString("<pre><code class=\"language-" +
+					escapeHTML(sanitizeLanguage(current.info)) + "\">")
+			} else {
+				builder.WriteString("<pre><code>")
+			}
+			builder.WriteString(escapeHTML(current.text) + "</code></pre>\n")
+			r.text.WriteString(current.text + " ")
+		case blockQuote:
+			builder.WriteString("<blockquote>\n")
+			r.renderBlocks(builder, current.children, false)
+			builder.WriteString("</blockquote>\n")
+		case blockList:
+			r.renderList(builder, current)
+		case blockTable:
+			r.renderTable(builder, current)
+		}
+	}
+}
+
+func (r *renderer) renderList(builder *strings.Builder, list *block) {
+	var tag = "ul"
+
+	if list.ordered {
+		tag = "ol"
+		if list.start != 1 {
+			builder.WriteString(fmt.Sprintf("<ol start=\"%d\">\n", list.start))
+		} else {
+			builder.WriteString("<ol>\n")
+		}
+	} else {
+		builder.WriteString("<ul>\n")
+	}
+	for _, item := range list.children {
+		var content strings.Builder
+
+		r.renderBlocks(&content, item.children, list.tight)
+		rendered := content.String()
+		if list.tight {
+			rendered = strings.TrimSuffix(rendered, "\n")
+		} else if len(rendered) > 0 {
+			rendered = "\n" + rendered
+		}
+		builder.WriteString("<li>" + rendered + "</li>\n")
+	}
+	builder.WriteString("</" + tag + ">\n")
+}
+
+func (r *renderer) renderTable(builder *strings.Builder, table *block) {
+	builder.WriteString("<table>\n<thead>\n")
+	for index, row := range table.rows {
+		cellTag := "td"
+		if index == 0 {
+			cellTag = "th"
+		}
+		if index == 1 {
+			builder.WriteString("<tbody>\n")
+		}
+		builder.WriteString("<tr>\n")
+		for column, cell := range row {
+			if align := table.aligns[column]; len(align) > 0 {
+				builder.WriteString("<" + cellTag + " align=\"" + align + "\">")
+			} else {
+				builder.WriteString("<" + cellTag + ">")
+			}
+			builder.WriteString(r.renderInline(cell) + "</" + cellTag + ">\n")
+		}
+		builder.WriteString("</tr>\n")
+		if index == 0 {
+			builder.WriteString("</thead>\n")
+		}
+	}
+	if len(table.rows) > 1 {
+		builder.WriteString("</tbody>\n")
+	}
+	builder.WriteString("</table>\n")
+}
+
+func sanitizeLanguage(info string) (language string) {
+	var builder strings.Builder
+
+	for _, char := range info {
+		if (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') ||
+			(char >= '0' && char <= '9') || char == '-' || char == '_' || char == '+' {
+			builder.WriteRune(char)
+		}
+	}
+
+	return builder.String()
+}
+
+func isBlank(line string) (blank bool) {
+	return len(strings.TrimSpace(line)) == 0
+}
+
+func indentOf(line string) (indent int) {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func removeIndent(line string, indent int) (removed string) {
+	if indentOf(line) < indent {
+		return strings.TrimLeft(line, " ")
+	}
+
+	return line[indent:]
+}
+
+func nextNonBlankIndent(lines []string, i int) (indent int) {
+	for ; i < len(lines); i++ {
+		if !isBlank(lines[i]) {
+			return indentOf(lines[i])
+		}
+	}
+
+	return -1
+}
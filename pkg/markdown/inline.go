@@ -0,0 +1,766 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	nodeText = iota
+	nodeCode
+	nodeEmphasis
+	nodeStrong
+	nodeStrikethrough
+	nodeLink
+	nodeImage
+	nodeSoftBreak
+	nodeHardBreak
+	nodeFootnoteReference
+)
+
+const (
+	// The longer link labels never match a reference, as in CommonMark.
+	maxLabelLength = 999
+	// Nesting limit of the link destination's parentheses, as in cmark.
+	maxDestinationDepth = 32
+)
+
+type node struct {
+	kind        int
+	literal     string
+	destination string
+	title       string
+	number      int
+	reference   int
+	children    *nodeList
+	prev, next  *node
+}
+
+type nodeList struct {
+	head, tail *node
+}
+
+// Run of emphasis characters, the literal of its text node
+// is shortened while the run is used by the emphasis.
+type delimiter struct {
+	node       *node
+	char       byte
+	count      int
+	original   int
+	canOpen    bool
+	canClose   bool
+	prev, next *delimiter
+}
+
+type bracket struct {
+	node      *node
+	image     bool
+	active    bool
+	position  int
+	delimiter *delimiter
+	prev      *bracket
+}
+
+type inlineParser struct {
+	r          *renderer
+	source     string
+	position   int
+	nodes      *nodeList
+	delimiters *delimiter
+	brackets   *bracket
+	text       strings.Builder
+}
+
+var (
+	entityRegex        = regexp.MustCompile(`^&(?:#[xX][0-9a-fA-F]{1,6}|#[0-9]{1,7}|[a-zA-Z][a-zA-Z0-9]{1,31});`)
+	autolinkRegex      = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^<>\x00-\x20]*)>`)
+	emailAutolinkRegex = regexp.MustCompile(`^<([a-zA-Z0-9.!#$%&'*+/=?^_{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*)>`)
+	footnoteRefRegex   = regexp.MustCompile(`^\[\^([^\]\s]+)\]`)
+)
+
+func (list *nodeList) append(n *node) {
+	n.prev, n.next = list.tail, nil
+	if list.tail != nil {
+		list.tail.next = n
+	} else {
+		list.head = n
+	}
+	list.tail = n
+}
+
+func (list *nodeList) insertAfter(after *node, n *node) {
+	n.prev, n.next = after, after.next
+	if after.next != nil {
+		after.next.prev = n
+	} else {
+		list.tail = n
+	}
+	after.next = n
+}
+
+func (list *nodeList) remove(n *node) {
+	if n.prev != nil {
+		n.prev.next = n.next
+	} else {
+		list.head = n.next
+	}
+	if n.next != nil {
+		n.next.prev = n.prev
+	} else {
+		list.tail = n.prev
+	}
+	n.prev, n.next = nil, nil
+}
+
+// Move the nodes between both nodes, exclusively, into new list.
+func (list *nodeList) extractBetween(from *node, to *node) (extracted *nodeList) {
+	extracted = &nodeList{}
+	for current := from.next; current != nil && current != to; {
+		next := current.next
+		list.remove(current)
+		extracted.append(current)
+		current = next
+	}
+
+	return extracted
+}
+
+func (r *renderer) renderInline(source string) (rendered string) {
+	rendered = r.renderNodes(r.parseInline(source))
+	r.text.WriteString(" ")
+
+	return rendered
+}
+
+func (r *renderer) parseInline(source string) (nodes *nodeList) {
+	var p = &inlineParser{r: r, source: source, nodes: &nodeList{}}
+
+	for p.position < len(p.source) {
+		char := p.source[p.position]
+		switch char {
+		case '\\':
+			p.parseBackslash()
+		case '`':
+			p.parseCodeSpan()
+		case '*', '_', '~':
+			p.parseDelimiterRun()
+		case '!':
+			if p.position+1 < len(p.source) && p.source[p.position+1] == '[' {
+				p.openBracket(true)
+			} else {
+				p.text.WriteByte(char)
+				p.position++
+			}
+		case '[':
+			if !p.parseFootnoteReference() {
+				p.openBracket(false)
+			}
+		case ']':
+			p.closeBracket()
+		case '<':
+			p.parseAutolink()
+		case '&':
+			p.parseEntity()
+		case '\n':
+			p.parseLineBreak()
+		default:
+			p.text.WriteByte(char)
+			p.position++
+		}
+	}
+	p.flushText()
+	p.processEmphasis(nil)
+
+	return p.nodes
+}
+
+func (p *inlineParser) flushText() {
+	if p.text.Len() == 0 {
+		return
+	}
+	p.nodes.append(&node{kind: nodeText, literal: p.text.String()})
+	p.text.Reset()
+}
+
+func (p *inlineParser) parseBackslash() {
+	if p.position+1 < len(p.source) {
+		next := p.source[p.position+1]
+		if next == '\n' {
+			p.flushText()
+			p.nodes.append(&node{kind: nodeHardBreak})
+			p.position += 2
+			p.skipLeadingSpaces()
+			return
+		}
+		if strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", next) >= 0 {
+			p.text.WriteByte(next)
+			p.position += 2
+			return
+		}
+	}
+	p.text.WriteByte('\\')
+	p.position++
+}
+
+func (p *inlineParser) parseCodeSpan() {
+	var (
+		start  = p.position
+		length int
+	)
+
+	for p.position < len(p.source) && p.source[p.position] == '`' {
+		p.position++
+	}
+	length = p.position - start
+	for search := p.position; search < len(p.source); {
+		closing := strings.Index(p.source[search:], strings.Repeat("`", length))
+		if closing < 0 {
+			break
+		}
+		closing += search
+		end := closing + length
+		if (end < len(p.source) && p.source[end] == '`') || p.source[closing-1] == '`' {
+			// Only the backtick run of the same length closes it.
+			for end < len(p.source) && p.source[end] == '`' {
+				end++
+			}
+			search = end
+			continue
+		}
+		code := strings.ReplaceAll(p.source[p.position:closing], "\n", " ")
+		if len(code) >= 2 && code[0] == ' ' && code[len(code)-1] == ' ' &&
+			len(strings.Trim(code, " ")) > 0 {
+			code = code[1 : len(code)-1]
+		}
+		p.flushText()
+		p.nodes.append(&node{kind: nodeCode, literal: code})
+		p.position = end
+		return
+	}
+	p.text.WriteString(p.source[start:p.position])
+}
+
+func (p *inlineParser) parseDelimiterRun() {
+	var (
+		char   = p.source[p.position]
+		start  = p.position
+		before = ' '
+		after  = ' '
+	)
+
+	for p.position < len(p.source) && p.source[p.position] == char {
+		p.position++
+	}
+	if start > 0 {
+		before, _ = utf8.DecodeLastRuneInString(p.source[:start])
+	}
+	if p.position < len(p.source) {
+		after, _ = utf8.DecodeRuneInString(p.source[p.position:])
+	}
+	var (
+		count         = p.position - start
+		leftFlanking  = !unicode.IsSpace(after) && (!isPunctuation(after) || unicode.IsSpace(before) || isPunctuation(before))
+		rightFlanking = !unicode.IsSpace(before) && (!isPunctuation(before) || unicode.IsSpace(after) || isPunctuation(after))
+		current       = &delimiter{char: char, count: count, original: count}
+	)
+
+	switch char {
+	case '_':
+		current.canOpen = leftFlanking && (!rightFlanking || isPunctuation(before))
+		current.canClose = rightFlanking && (!leftFlanking || isPunctuation(after))
+	case '~':
+		current.canOpen = leftFlanking && count <= 2
+		current.canClose = rightFlanking && count <= 2
+	default:
+		current.canOpen = leftFlanking
+		current.canClose = rightFlanking
+	}
+	p.flushText()
+	current.node = &node{kind: nodeText, literal: p.source[start:p.position]}
+	p.nodes.append(current.node)
+	if !current.canOpen && !current.canClose {
+		return
+	}
+	current.prev = p.delimiters
+	if p.delimiters != nil {
+		p.delimiters.next = current
+	}
+	p.delimiters = current
+}
+
+func (p *inlineParser) parseFootnoteReference() (parsed bool) {
+	var (
+		matches = footnoteRefRegex.FindStringSubmatch(p.source[p.position:])
+		note    *footnote
+		ok      bool
+	)
+
+	if matches == nil {
+		return false
+	}
+	if note, ok = p.r.footnotes[normalizeLabel(matches[1])]; !ok {
+		return false
+	}
+	if note.number == 0 {
+		p.r.referenced = append(p.r.referenced, note)
+		note.number = len(p.r.referenced)
+	}
+	note.references++
+	p.flushText()
+	p.nodes.append(&node{
+		kind:      nodeFootnoteReference,
+		number:    note.number,
+		reference: note.references})
+	p.position += len(matches[0])
+
+	return true
+}
+
+func (p *inlineParser) openBracket(image bool) {
+	var literal = "["
+
+	if image {
+		literal = "!["
+	}
+	p.flushText()
+	current := &node{kind: nodeText, literal: literal}
+	p.nodes.append(current)
+	p.position += len(literal)
+	p.brackets = &bracket{
+		node:      current,
+		image:     image,
+		active:    true,
+		position:  p.position,
+		delimiter: p.delimiters,
+		prev:      p.brackets}
+}
+
+func (p *inlineParser) closeBracket() {
+	var (
+		opener      = p.brackets
+		labelEnd    = p.position
+		destination string
+		title       string
+		matched     bool
+	)
+
+	p.position++
+	if opener == nil {
+		p.text.WriteByte(']')
+		return
+	}
+	if !opener.active {
+		p.brackets = opener.prev
+		p.text.WriteByte(']')
+		return
+	}
+	if destination, title, matched = p.parseInlineLink(); !matched {
+		label := p.source[opener.position:labelEnd]
+		if len(label) > maxLabelLength {
+			label = ""
+		}
+		if end, reference, ok := p.parseReferenceLabel(); ok {
+			if len(reference) > 0 {
+				label = reference
+			}
+			if definition, exist := p.r.references[normalizeLabel(label)]; exist {
+				destination, title, matched = definition.destination, definition.title, true
+				p.position = end
+			}
+		} else if definition, exist := p.r.references[normalizeLabel(label)]; exist {
+			destination, title, matched = definition.destination, definition.title, true
+		}
+	}
+	p.brackets = opener.prev
+	if !matched {
+		p.text.WriteByte(']')
+		return
+	}
+	p.flushText()
+	link := &node{kind: nodeLink, destination: destination, title: title}
+	if opener.image {
+		link.kind = nodeImage
+	}
+	link.children = p.nodes.extractBetween(opener.node, nil)
+	p.processEmphasisWithin(opener.delimiter, link.children)
+	p.nodes.insertAfter(opener.node, link)
+	p.nodes.remove(opener.node)
+	if !opener.image {
+		// The links can't contain another links.
+		for previous := p.brackets; previous != nil; previous = previous.prev {
+			if !previous.image {
+				previous.active = false
+			}
+		}
+	}
+}
+
+func (p *inlineParser) parseInlineLink() (destination string, title string, matched bool) {
+	var position = p.position
+
+	if position >= len(p.source) || p.source[position] != '(' {
+		return "", "", false
+	}
+	position = skipSpaces(p.source, position+1)
+	if position < len(p.source) && p.source[position] == '<' {
+		end := strings.IndexAny(p.source[position+1:], ">\n")
+		if end < 0 || p.source[position+1+end] != '>' {
+			return "", "", false
+		}
+		destination = p.source[position+1 : position+1+end]
+		position += end + 2
+	} else {
+		start, depth := position, 0
+		for ; position < len(p.source); position++ {
+			char := p.source[position]
+			if char == '\\' && position+1 < len(p.source) {
+				position++
+				continue
+			}
+			if char == '(' {
+				if depth++; depth > maxDestinationDepth {
+					return "", "", false
+				}
+			} else if char == ')' {
+				if depth == 0 {
+					break
+				}
+				depth--
+			} else if char <= ' ' {
+				break
+			}
+		}
+		destination = p.source[start:position]
+	}
+	afterDestination := position
+	position = skipSpaces(p.source, position)
+	if position < len(p.source) && position > afterDestination &&
+		strings.IndexByte("\"'(", p.source[position]) >= 0 {
+		closing := p.source[position]
+		if closing == '(' {
+			closing = ')'
+		}
+		end := position + 1
+		for ; end < len(p.source) && p.source[end] != closing; end++ {
+			if p.source[end] == '\\' {
+				end++
+			}
+		}
+		if end >= len(p.source) {
+			return "", "", false
+		}
+		title = p.source[position+1 : end]
+		position = skipSpaces(p.source, end+1)
+	}
+	if position >= len(p.source) || p.source[position] != ')' {
+		return "", "", false
+	}
+	p.position = position + 1
+
+	return unescapeBackslashes(destination), unescapeBackslashes(title), true
+}
+
+// Parse the label of the full or collapsed reference link, following the brackets.
+func (p *inlineParser) parseReferenceLabel() (end int, label string, ok bool) {
+	if p.position >= len(p.source) || p.source[p.position] != '[' {
+		return 0, "", false
+	}
+	closing := strings.IndexAny(p.source[p.position+1:], "[]")
+	if closing < 0 || p.source[p.position+1+closing] != ']' {
+		return 0, "", false
+	}
+
+	return p.position + closing + 2, p.source[p.position+1 : p.position+1+closing], true
+}
+
+func (p *inlineParser) parseAutolink() {
+	var matches []string
+
+	if matches = autolinkRegex.FindStringSubmatch(p.source[p.position:]); matches != nil {
+		p.flushText()
+		p.nodes.append(&node{
+			kind:        nodeLink,
+			destination: matches[1],
+			children:    &nodeList{}})
+		p.nodes.tail.children.append(&node{kind: nodeText, literal: matches[1]})
+		p.position += len(matches[0])
+		return
+	}
+	if matches = emailAutolinkRegex.FindStringSubmatch(p.source[p.position:]); matches != nil {
+		p.flushText()
+		p.nodes.append(&node{
+			kind:        nodeLink,
+			destination: "mailto:" + matches[1],
+			children:    &nodeList{}})
+		p.nodes.tail.children.append(&node{kind: nodeText, literal: matches[1]})
+		p.position += len(matches[0])
+		return
+	}
+	p.text.WriteByte('<')
+	p.position++
+}
+
+func (p *inlineParser) parseEntity() {
+	var entity = entityRegex.FindString(p.source[p.position:])
+
+	if len(entity) == 0 {
+		p.text.WriteByte('&')
+		p.position++
+		return
+	}
+	p.text.WriteString(html.UnescapeString(entity))
+	p.position += len(entity)
+}
+
+func (p *inlineParser) parseLineBreak() {
+	var (
+		pending  = p.text.String()
+		trimmed  = strings.TrimRight(pending, " ")
+		hard     = len(pending)-len(trimmed) >= 2
+		lastNode = p.nodes.tail
+	)
+
+	if len(pending) == 0 && lastNode != nil && lastNode.kind == nodeText {
+		lastTrimmed := strings.TrimRight(lastNode.literal, " ")
+		hard = len(lastNode.literal)-len(lastTrimmed) >= 2
+		lastNode.literal = lastTrimmed
+	}
+	p.text.Reset()
+	p.text.WriteString(trimmed)
+	p.flushText()
+	if hard {
+		p.nodes.append(&node{kind: nodeHardBreak})
+	} else {
+		p.nodes.append(&node{kind: nodeSoftBreak})
+	}
+	p.position++
+	p.skipLeadingSpaces()
+}
+
+func (p *inlineParser) skipLeadingSpaces() {
+	p.position = skipSpaces(p.source, p.position)
+}
+
+// Resolve the emphasis of the delimiters above the bottom one,
+// the list is the one containing their nodes.
+func (p *inlineParser) processEmphasisWithin(bottom *delimiter, list *nodeList) {
+	var nodes = p.nodes
+
+	p.nodes = list
+	p.processEmphasis(bottom)
+	p.nodes = nodes
+}
+
+func (p *inlineParser) processEmphasis(bottom *delimiter) {
+	var (
+		closer        *delimiter
+		openersBottom = map[string]*delimiter{}
+	)
+
+	if closer = p.delimiters; closer == bottom {
+		return
+	}
+	for closer.prev != bottom {
+		closer = closer.prev
+	}
+	for closer != nil {
+		if !closer.canClose {
+			closer = closer.next
+			continue
+		}
+		var (
+			key    = fmt.Sprintf("%c%t%d", closer.char, closer.canOpen, closer.original%3)
+			opener = closer.prev
+			found  = false
+		)
+
+		for ; opener != nil && opener != bottom && opener != openersBottom[key]; opener = opener.prev {
+			if opener.char != closer.char || !opener.canOpen {
+				continue
+			}
+			if closer.char == '~' {
+				if opener.count == closer.count {
+					found = true
+					break
+				}
+				continue
+			}
+			if (opener.canClose || closer.canOpen) &&
+				(opener.original+closer.original)%3 == 0 &&
+				!(opener.original%3 == 0 && closer.original%3 == 0) {
+				continue
+			}
+			found = true
+			break
+		}
+		if !found {
+			openersBottom[key] = closer.prev
+			next := closer.next
+			if !closer.canOpen {
+				p.removeDelimiter(closer)
+			}
+			closer = next
+			continue
+		}
+		var (
+			used     = 1
+			emphasis = &node{kind: nodeEmphasis}
+		)
+
+		switch {
+		case closer.char == '~':
+			used = closer.count
+			emphasis.kind = nodeStrikethrough
+		case opener.count >= 2 && closer.count >= 2:
+			used = 2
+			emphasis.kind = nodeStrong
+		}
+		opener.count -= used
+		closer.count -= used
+		opener.node.literal = opener.node.literal[:opener.count]
+		closer.node.literal = closer.node.literal[:closer.count]
+		emphasis.children = p.nodes.extractBetween(opener.node, closer.node)
+		p.nodes.insertAfter(opener.node, emphasis)
+		for between := closer.prev; between != nil && between != opener; {
+			previous := between.prev
+			p.removeDelimiter(between)
+			between = previous
+		}
+		if opener.count == 0 {
+			p.nodes.remove(opener.node)
+			p.removeDelimiter(opener)
+		}
+		if closer.count == 0 {
+			next := closer.next
+			p.nodes.remove(closer.node)
+			p.removeDelimiter(closer)
+			closer = next
+		}
+	}
+	for p.delimiters != nil && p.delimiters != bottom {
+		p.removeDelimiter(p.delimiters)
+	}
+}
+
+func (p *inlineParser) removeDelimiter(current *delimiter) {
+	if current.prev != nil {
+		current.prev.next = current.next
+	}
+	if current.next != nil {
+		current.next.prev = current.prev
+	} else {
+		p.delimiters = current.prev
+	}
+	current.prev, current.next = nil, nil
+}
+
+func (r *renderer) renderNodes(nodes *nodeList) (rendered string) {
+	var builder strings.Builder
+
+	for current := nodes.head; current != nil; current = current.next {
+		switch current.kind {
+		case nodeText:
+			r.text.WriteString(current.literal)
+			builder.WriteString(escapeHTML(current.literal))
+		case nodeCode:
+			r.text.WriteString(current.literal)
+			builder.WriteString("<code>" + escapeHTML(current.literal) + "</code>")
+		case nodeEmphasis:
+			builder.WriteString("<em>" + r.renderNodes(current.children) + "</em>")
+		case nodeStrong:
+			builder.WriteString("<strong>" + r.renderNodes(current.children) + "</strong>")
+		case nodeStrikethrough:
+			builder.WriteString("<del>" + r.renderNodes(current.children) + "</del>")
+		case nodeLink:
+			if !isSafeURL(current.destination) {
+				builder.WriteString(r.renderNodes(current.children))
+				continue
+			}
+			r.links = append(r.links, current.destination)
+			builder.WriteString("<a href=\"" + escapeHTML(current.destination) + "\"")
+			if len(current.title) > 0 {
+				builder.WriteString(" title=\"" + escapeHTML(current.title) + "\"")
+			}
+			builder.WriteString(">" + r.renderNodes(current.children) + "</a>")
+		case nodeImage:
+			if !isSafeURL(current.destination) {
+				builder.WriteString(escapeHTML(plainText(current.children)))
+				continue
+			}
+			r.images = append(r.images, current.destination)
+			builder.WriteString("<img src=\"" + escapeHTML(current.destination) +
+				"\" alt=\"" + escapeHTML(plainText(current.children)) + "\"")
+			if len(current.title) > 0 {
+				builder.WriteString(" title=\"" + escapeHTML(current.title) + "\"")
+			}
+			builder.WriteString(" />")
+		case nodeSoftBreak:
+			r.text.WriteString(" ")
+			builder.WriteString("\n")
+		case nodeHardBreak:
+			r.text.WriteString(" ")
+			builder.WriteString("<br />\n")
+		case nodeFootnoteReference:
+			id := fmt.Sprintf("fnref-%d", current.number)
+			if current.reference > 1 {
+				id = fmt.Sprintf("fnref-%d-%d", current.number, current.reference)
+			}
+			builder.WriteString(fmt.Sprintf(
+				"<sup class=\"footnote-ref\"><a href=\"#fn-%d\" id=\"%s\">%d</a></sup>",
+				current.number, id, current.number))
+		}
+	}
+
+	return builder.String()
+}
+
+func plainText(nodes *nodeList) (text string) {
+	var builder strings.Builder
+
+	if nodes == nil {
+		return ""
+	}
+	for current := nodes.head; current != nil; current = current.next {
+		switch current.kind {
+		case nodeText, nodeCode:
+			builder.WriteString(current.literal)
+		case nodeSoftBreak, nodeHardBreak:
+			builder.WriteString(" ")
+		case nodeFootnoteReference:
+		default:
+			builder.WriteString(plainText(current.children))
+		}
+	}
+
+	return builder.String()
+}
+
+func isPunctuation(char rune) (punctuation bool) {
+	return unicode.IsPunct(char) || unicode.IsSymbol(char)
+}
+
+func skipSpaces(source string, position int) (skipped int) {
+	for position < len(source) && (source[position] == ' ' || source[position] == '\n') {
+		position++
+	}
+
+	return position
+}
+
+func unescapeBackslashes(text string) (unescaped string) {
+	var builder strings.Builder
+
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' && i+1 < len(text) &&
+			strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", text[i+1]) >= 0 {
+			i++
+		}
+		builder.WriteByte(text[i])
+	}
+
+	return html.UnescapeString(builder.String())
+}
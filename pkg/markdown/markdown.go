@@ -0,0 +1,194 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+)
+
+/**
+ * CommonMark renderer with the GitHub flavored tables, strikethrough
+ * & footnotes. The raw HTML isn't passed through but escaped and the links
+ * & images are limited to the safe URL schemes, so the rendered HTML
+ * is sanitized. The fenced code gets its language as "language-*" class
+ * for the syntax highlighters, the headings get their anchors.
+ */
+
+// Heading of the rendered document, for the table of contents.
+type Heading struct {
+	Level  int
+	Text   string
+	Anchor string
+}
+
+// Rendered document with its headings, the words count of its text
+// and the URLs of its links & images.
+type Document struct {
+	HTML     string
+	Headings []Heading
+	Words    int
+	Links    []string
+	Images   []string
+}
+
+type linkReference struct {
+	destination string
+	title       string
+}
+
+type footnote struct {
+	label      string
+	number     int
+	references int
+	blocks     []*block
+}
+
+type renderer struct {
+	references map[string]linkReference
+	footnotes  map[string]*footnote
+	referenced []*footnote
+	anchors    map[string]int
+	headings   []Heading
+	text       strings.Builder
+	links      []string
+	images     []string
+}
+
+var safeSchemes = []string{"http", "https", "mailto"}
+
+// Render the markdown source into sanitized HTML.
+func Render(source string) (document Document) {
+	var (
+		r = &renderer{
+			references: map[string]linkReference{},
+			footnotes:  map[string]*footnote{},
+			anchors:    map[string]int{},
+			headings:   []Heading{},
+			links:      []string{},
+			images:     []string{}}
+		builder strings.Builder
+		blocks  = r.parseBlocks(splitSourceLines(source))
+	)
+
+	r.renderBlocks(&builder, blocks, false)
+	r.renderFootnotes(&builder)
+
+	return Document{
+		HTML:     builder.String(),
+		Headings: r.headings,
+		Words:    len(strings.Fields(r.text.String())),
+		Links:    r.links,
+		Images:   r.images}
+}
+
+func splitSourceLines(source string) (lines []string) {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	source = strings.ReplaceAll(source, "\r", "\n")
+	source = strings.ReplaceAll(source, "\x00", "�")
+	for _, line := range strings.Split(source, "\n") {
+		lines = append(lines, expandTabs(line))
+	}
+
+	return lines
+}
+
+func expandTabs(line string) (expanded string) {
+	var (
+		builder strings.Builder
+		column  int
+	)
+
+	if !strings.Contains(line, "\t") {
+		return line
+	}
+	for _, char := range line {
+		if char == '\t' {
+			builder.WriteString(strings.Repeat(" ", 4-column%4))
+			column += 4 - column%4
+			continue
+		}
+		builder.WriteRune(char)
+		column++
+	}
+
+	return builder.String()
+}
+
+func (r *renderer) renderFootnotes(builder *strings.Builder) {
+	if len(r.referenced) == 0 {
+		return
+	}
+	builder.WriteString("<section class=\"footnotes\">\n<ol>\n")
+	// The footnotes can reference another ones, appended while rendering.
+	for i := 0; i < len(r.referenced); i++ {
+		var (
+			note    = r.referenced[i]
+			content strings.Builder
+			backref = fmt.Sprintf(
+				"<a href=\"#fnref-%d\" class=\"footnote-backref\">↩</a>", note.number)
+		)
+
+		r.renderBlocks(&content, note.blocks, false)
+		rendered := strings.TrimSuffix(content.String(), "\n")
+		if strings.HasSuffix(rendered, "</p>") {
+			rendered = strings.TrimSuffix(rendered, "</p>") + " " + backref + "</p>"
+		} else {
+			rendered = rendered + "\n" + backref
+		}
+		builder.WriteString(fmt.Sprintf("<li id=\"fn-%d\">\n%s\n</li>\n", note.number, rendered))
+	}
+	builder.WriteString("</ol>\n</section>\n")
+}
+
+// Get unique anchor of the heading text, the same way GitHub does.
+func (r *renderer) anchor(text string) (anchor string) {
+	var builder strings.Builder
+
+	for _, char := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case unicode.IsLetter(char) || unicode.IsDigit(char) || char == '_' || char == '-':
+			builder.WriteRune(char)
+		case char == ' ':
+			builder.WriteRune('-')
+		}
+	}
+	anchor = builder.String()
+	if len(anchor) == 0 {
+		anchor = "section"
+	}
+	if count, ok := r.anchors[anchor]; ok {
+		r.anchors[anchor] = count + 1
+		anchor = fmt.Sprintf("%s-%d", anchor, count+1)
+	}
+	r.anchors[anchor] = 0
+
+	return anchor
+}
+
+func escapeHTML(text string) (escaped string) {
+	return html.EscapeString(text)
+}
+
+// Check whether the URL is relative or using the safe schemes.
+func isSafeURL(url string) (safe bool) {
+	var (
+		trimmed = strings.TrimSpace(url)
+		colon   = strings.Index(trimmed, ":")
+	)
+
+	if colon < 0 || strings.ContainsAny(trimmed[:colon], "/?#") {
+		return true
+	}
+	for _, scheme := range safeSchemes {
+		if strings.EqualFold(trimmed[:colon], scheme) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeLabel(label string) (normalized string) {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
@@ -0,0 +1,178 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	var tests = []struct {
+		name     string
+		source   string
+		expected string
+	}{{
+		name:     "paragraph",
+		source:   "Hello *world*",
+		expected: "<p>Hello <em>world</em></p>\n",
+	}, {
+		name:   "table",
+		source: "| a | b |\n|:-|-:|\n| 1 | 2 |",
+		expected: "<table>\n<thead>\n<tr>\n<th align=\"left\">a</th>\n<th align=\"right\">b</th>\n</tr>\n</thead>\n" +
+			"<tbody>\n<tr>\n<td align=\"left\">1</td>\n<td align=\"right\">2</td>\n</tr>\n</tbody>\n</table>\n",
+	}, {
+		name:   "footnote",
+		source: "x[^1]\n\n[^1]: note",
+		expected: "<p>x<sup class=\"footnote-ref\"><a href=\"#fn-1\" id=\"fnref-1\">1</a></sup></p>\n" +
+			"<section class=\"footnotes\">\n<ol>\n<li id=\"fn-1\">\n" +
+			"<p>note <a href=\"#fnref-1\" class=\"footnote-backref\">↩</a></p>\n</li>\n</ol>\n</section>\n",
+	}, {
+		name:     "undefined footnote",
+		source:   "x[^missing]",
+		expected: "<p>x[^missing]</p>\n",
+	}, {
+		name:     "fenced code class",
+		source:   "```go\nx := 1\n```",
+		expected: "<pre><code class=\"language-go\">x := 1\n</code></pre>\n",
+	}, {
+		name:     "fenced code without info",
+		source:   "```\n<b>\n```",
+		expected: "<pre><code>&lt;b&gt;\n</code></pre>\n",
+	}, {
+		name:     "duplicate heading anchors",
+		source:   "# A\n\n# A\n\n## A",
+		expected: "<h1 id=\"a\">A</h1>\n<h1 id=\"a-1\">A</h1>\n<h2 id=\"a-2\">A</h2>\n",
+	}, {
+		name:     "strikethrough",
+		source:   "~~gone~~",
+		expected: "<p><del>gone</del></p>\n",
+	}, {
+		name:     "reference link",
+		source:   "[go]\n\n[go]: https://go.dev \"Go\"",
+		expected: "<p><a href=\"https://go.dev\" title=\"Go\">go</a></p>\n",
+	}, {
+		name:     "unclosed reference destination",
+		source:   "[x]:<",
+		expected: "<p>[x]:&lt;</p>\n",
+	}, {
+		name:     "tight list",
+		source:   "- one\n- two",
+		expected: "<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if html := Render(test.source).HTML; html != test.expected {
+				t.Errorf("Render(%q)\n got: %q\nwant: %q", test.source, html, test.expected)
+			}
+		})
+	}
+}
+
+func TestRenderSanitized(t *testing.T) {
+	var tests = []struct {
+		name      string
+		source    string
+		forbidden []string
+	}{{
+		name:      "javascript link",
+		source:    "[a](javascript:alert(1))",
+		forbidden: []string{"<a", "javascript:"},
+	}, {
+		name:      "mixed case scheme",
+		source:    "[a](JaVaScRiPt:alert(1))",
+		forbidden: []string{"<a"},
+	}, {
+		name:      "entity encoded scheme",
+		source:    "[a](&#106;avascript:alert(1))",
+		forbidden: []string{"<a"},
+	}, {
+		name:      "named entity colon",
+		source:    "[a](javascript&colon;alert(1))",
+		forbidden: []string{"<a"},
+	}, {
+		name:      "data url",
+		source:    "[a](data:text/html,<script>alert(1)</script>)",
+		forbidden: []string{"<a", "<script"},
+	}, {
+		name:      "javascript reference",
+		source:    "[ref]\n\n[ref]: javascript:alert(1)",
+		forbidden: []string{"<a", "javascript:"},
+	}, {
+		name:      "javascript autolink",
+		source:    "<javascript:alert(1)>",
+		forbidden: []string{"<a", "href"},
+	}, {
+		name:      "javascript image",
+		source:    "![x](javascript:alert(1))",
+		forbidden: []string{"<img"},
+	}, {
+		name:      "raw script",
+		source:    "<script>alert(1)</script>",
+		forbidden: []string{"<script"},
+	}, {
+		name:      "raw inline html",
+		source:    "text <img src=x onerror=alert(1)>",
+		forbidden: []string{"<img"},
+	}, {
+		name:      "quotes in alt & title",
+		source:    "![x\" onerror=\"alert(1)](a.png \"t\\\" onload=\\\"x\")",
+		forbidden: []string{"\" onerror", "\" onload"},
+	}, {
+		name:      "quotes in code info",
+		source:    "```js\" onclick=\"x\ncode\n```",
+		forbidden: []string{"onclick"},
+	}, {
+		name:      "quotes in heading anchor",
+		source:    "# a\" onclick=\"x",
+		forbidden: []string{"\" onclick"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			html := Render(test.source).HTML
+			for _, forbidden := range test.forbidden {
+				if strings.Contains(html, forbidden) {
+					t.Errorf("Render(%q) = %q, contains %q", test.source, html, forbidden)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderDocument(t *testing.T) {
+	var document = Render("# Title\n\nSee [a](/a) & [b](https://b.dev).\n\n![i](i.png)\n\n## Title\n")
+
+	if document.Words != 6 {
+		t.Errorf("Words = %d, want 6", document.Words)
+	}
+	if len(document.Headings) != 2 ||
+		document.Headings[0] != (Heading{Level: 1, Text: "Title", Anchor: "title"}) ||
+		document.Headings[1] != (Heading{Level: 2, Text: "Title", Anchor: "title-1"}) {
+		t.Errorf("Headings = %+v", document.Headings)
+	}
+	if strings.Join(document.Links, " ") != "/a https://b.dev" {
+		t.Errorf("Links = %v", document.Links)
+	}
+	if strings.Join(document.Images, " ") != "i.png" {
+		t.Errorf("Images = %v", document.Images)
+	}
+}
+
+func FuzzRender(f *testing.F) {
+	for _, seed := range []string{
+		"# Heading\n\nParagraph *em* **strong** ~~del~~ `code`",
+		"- a\n  - b\n\n1. c\n2. d",
+		"> quote\nlazy",
+		"| a | b |\n|---|---|\n| c | d |",
+		"x[^1]\n\n[^1]: note\n\n    more",
+		"[x]:<",
+		"[a](<b c> \"t\") ![i][r]\n\n[r]: /i.png",
+		"```go\ncode\n```",
+		"***a** b*",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, source string) {
+		Render(source)
+	})
+}
@@ -0,0 +1,123 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// Allowed clock skew between this server & the provider.
+const clockSkew = time.Minute
+
+// Claims of the ID token, see OpenID Connect Core 1.0.
+type IDTokenClaims struct {
+	Issuer            string   `json:"iss"`
+	Subject           string   `json:"sub"`
+	Audience          audience `json:"aud"`
+	AuthorizedParty   string   `json:"azp"`
+	ExpiresAt         int64    `json:"exp"`
+	IssuedAt          int64    `json:"iat"`
+	Nonce             string   `json:"nonce"`
+	Email             string   `json:"email"`
+	EmailVerified     boolean  `json:"email_verified"`
+	Name              string   `json:"name"`
+	GivenName         string   `json:"given_name"`
+	FamilyName        string   `json:"family_name"`
+	PreferredUsername string   `json:"preferred_username"`
+}
+
+// The claims are validated by VerifyIDToken with the provider's clock.
+func (c *IDTokenClaims) Valid() (err error) {
+	return nil
+}
+
+// The aud claim is either a single string or an array of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) (err error) {
+	var single string
+
+	if err = json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	return json.Unmarshal(data, (*[]string)(a))
+}
+
+func (a audience) contains(value string) (exist bool) {
+	for _, _value := range a {
+		if _value == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Some providers send the boolean claims as strings.
+type boolean bool
+
+func (b *boolean) UnmarshalJSON(data []byte) (err error) {
+	var value string
+
+	if err = json.Unmarshal(data, &value); err == nil {
+		*b = value == "true"
+		return nil
+	}
+
+	return json.Unmarshal(data, (*bool)(b))
+}
+
+// Verify the ID token's signature against the provider's keys
+// & validate its claims.
+func (p *Provider) VerifyIDToken(
+	ctx context.Context,
+	rawIDToken string,
+	nonce string,
+) (claims *IDTokenClaims, err error) {
+	var (
+		_claims IDTokenClaims
+		now     = p.now()
+		parser  = jwt.Parser{
+			ValidMethods: []string{
+				"RS256", "RS384", "RS512",
+				"PS256", "PS384", "PS512",
+				"ES256", "ES384", "ES512",
+				"EdDSA"}}
+	)
+
+	if _, err = parser.ParseWithClaims(
+		rawIDToken,
+		&_claims,
+		func(token *jwt.Token) (key interface{}, err error) {
+			keyID, _ := token.Header["kid"].(string)
+
+			return p.publicKey(ctx, keyID)
+		},
+	); err != nil {
+		return nil, err
+	}
+	switch {
+	case _claims.Issuer != p.Issuer:
+		return nil, fmt.Errorf("issuer mismatch, expected %q, got %q", p.Issuer, _claims.Issuer)
+	case !_claims.Audience.contains(p.ClientID):
+		return nil, errors.New("the token is not issued for this client")
+	case len(_claims.Audience) > 1 && _claims.AuthorizedParty != p.ClientID:
+		return nil, errors.New("the token is not authorized for this client")
+	case _claims.Subject == "":
+		return nil, errors.New("no subject in the token")
+	case now.After(time.Unix(_claims.ExpiresAt, 0).Add(clockSkew)):
+		return nil, errors.New("the token is expired")
+	case now.Add(clockSkew).Before(time.Unix(_claims.IssuedAt, 0)):
+		return nil, errors.New("the token is issued in the future")
+	case _claims.Nonce != nonce:
+		return nil, errors.New("nonce mismatch")
+	}
+
+	return &_claims, nil
+}
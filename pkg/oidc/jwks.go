@@ -0,0 +1,150 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"time"
+)
+
+// Minimum interval between refetching the provider's keys,
+// when the ID token is signed with an unknown key ID.
+const keysRefetchInterval = time.Minute
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// Get the provider's public key of the key ID,
+// refetching the provider's keys when it's unknown, e.g. after rotation.
+func (p *Provider) publicKey(ctx context.Context, keyID string) (key interface{}, err error) {
+	var (
+		discovery *Discovery
+		ok        bool
+	)
+
+	if discovery, err = p.Discover(ctx); err != nil {
+		return nil, err
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if key, ok = p.keys[keyID]; ok {
+		return key, nil
+	}
+	if p.keys != nil && p.now().Sub(p.keysFetchedAt) < keysRefetchInterval {
+		return nil, errors.New("unknown signing key id: " + keyID)
+	}
+	if p.keys, err = p.fetchKeys(ctx, discovery.JWKSURI); err != nil {
+		return nil, err
+	}
+	p.keysFetchedAt = p.now()
+	if key, ok = p.keys[keyID]; !ok {
+		return nil, errors.New("unknown signing key id: " + keyID)
+	}
+
+	return key, nil
+}
+
+func (p *Provider) fetchKeys(
+	ctx context.Context,
+	jwksURI string,
+) (keys map[string]interface{}, err error) {
+	var (
+		keySet jsonWebKeySet
+		key    interface{}
+	)
+
+	if err = p.getJSON(ctx, jwksURI, &keySet); err != nil {
+		return nil, err
+	}
+	keys = map[string]interface{}{}
+	for _, jwk := range keySet.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if key, err = jwk.publicKey(); err != nil {
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+
+	return keys, nil
+}
+
+func (k *jsonWebKey) publicKey() (key interface{}, err error) {
+	switch k.KeyType {
+	case "RSA":
+		var n, e []byte
+
+		if n, err = base64.RawURLEncoding.DecodeString(k.N); err != nil {
+			return nil, err
+		}
+		if e, err = base64.RawURLEncoding.DecodeString(k.E); err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var (
+			curve elliptic.Curve
+			x, y  []byte
+		)
+
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.New("unsupported elliptic curve: " + k.Curve)
+		}
+		if x, err = base64.RawURLEncoding.DecodeString(k.X); err != nil {
+			return nil, err
+		}
+		if y, err = base64.RawURLEncoding.DecodeString(k.Y); err != nil {
+			return nil, err
+		}
+		publicKey := &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(publicKey.X, publicKey.Y) {
+			return nil, errors.New("invalid elliptic curve point")
+		}
+		return publicKey, nil
+	case "OKP":
+		var x []byte
+
+		if k.Curve != "Ed25519" {
+			return nil, errors.New("unsupported curve: " + k.Curve)
+		}
+		if x, err = base64.RawURLEncoding.DecodeString(k.X); err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid ed25519 public key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, errors.New("unsupported key type: " + k.KeyType)
+	}
+}
@@ -0,0 +1,26 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/misterabdul/goblog-server/pkg/crypto"
+)
+
+// Create new random PKCE code verifier, see RFC 7636.
+func NewCodeVerifier() (verifier string, err error) {
+	var randomBytes []byte
+
+	if randomBytes, err = crypto.GenerateRandomBytes(32); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(randomBytes), nil
+}
+
+// Get the S256 code challenge of the code verifier.
+func CodeChallenge(verifier string) (challenge string) {
+	var sum = sha256.Sum256([]byte(verifier))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
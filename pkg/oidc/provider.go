@@ -0,0 +1,245 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OpenID Connect provider, signing in with the authorization code flow & PKCE.
+//
+// The provider's endpoints are discovered from its issuer, so pointing
+// the issuer & the HTTP client to a mock provider is enough to test it.
+type Provider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	HTTPClient   *http.Client
+	// Current time, used to validate the ID tokens.
+	Now func() time.Time
+
+	mutex         sync.Mutex
+	discovery     *Discovery
+	keys          map[string]interface{}
+	keysFetchedAt time.Time
+}
+
+// The provider's metadata, see OpenID Connect Discovery 1.0.
+type Discovery struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	TokenEndpointAuth     []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+// Response of the token endpoint.
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+type tokenError struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (p *Provider) httpClient() (client *http.Client) {
+	if p.HTTPClient != nil {
+		return p.HTTPClient
+	}
+
+	return http.DefaultClient
+}
+
+func (p *Provider) now() (now time.Time) {
+	if p.Now != nil {
+		return p.Now()
+	}
+
+	return time.Now()
+}
+
+// Get the provider's metadata, fetched only once.
+func (p *Provider) Discover(ctx context.Context) (discovery *Discovery, err error) {
+	var (
+		_discovery Discovery
+		endpoint   = strings.TrimSuffix(p.Issuer, "/") + "/.well-known/openid-configuration"
+	)
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+	if err = p.getJSON(ctx, endpoint, &_discovery); err != nil {
+		return nil, err
+	}
+	if _discovery.Issuer != p.Issuer {
+		return nil, fmt.Errorf(
+			"issuer mismatch, expected %q, got %q", p.Issuer, _discovery.Issuer)
+	}
+	if _discovery.AuthorizationEndpoint == "" ||
+		_discovery.TokenEndpoint == "" ||
+		_discovery.JWKSURI == "" {
+		return nil, errors.New("incomplete provider metadata")
+	}
+	p.discovery = &_discovery
+
+	return p.discovery, nil
+}
+
+// Get the URL to redirect the user to for the authorization.
+func (p *Provider) AuthCodeURL(
+	ctx context.Context,
+	state string,
+	nonce string,
+	codeVerifier string,
+) (authURL string, err error) {
+	var (
+		discovery *Discovery
+		parsedURL *url.URL
+		query     url.Values
+		scopes    = p.Scopes
+	)
+
+	if discovery, err = p.Discover(ctx); err != nil {
+		return "", err
+	}
+	if parsedURL, err = url.Parse(discovery.AuthorizationEndpoint); err != nil {
+		return "", err
+	}
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+	query = parsedURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.ClientID)
+	query.Set("redirect_uri", p.RedirectURL)
+	query.Set("scope", strings.Join(scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", CodeChallenge(codeVerifier))
+	query.Set("code_challenge_method", "S256")
+	parsedURL.RawQuery = query.Encode()
+
+	return parsedURL.String(), nil
+}
+
+// Exchange the authorization code for the tokens.
+func (p *Provider) Exchange(
+	ctx context.Context,
+	code string,
+	codeVerifier string,
+) (token *Token, err error) {
+	var (
+		discovery *Discovery
+		request   *http.Request
+		response  *http.Response
+		body      []byte
+		_token    Token
+		_error    tokenError
+		basicAuth bool
+		form      = url.Values{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {p.ClientID},
+			"code":          {code},
+			"redirect_uri":  {p.RedirectURL},
+			"code_verifier": {codeVerifier}}
+	)
+
+	if discovery, err = p.Discover(ctx); err != nil {
+		return nil, err
+	}
+	if basicAuth = p.supportsBasicAuth(discovery); !basicAuth {
+		form.Set("client_secret", p.ClientSecret)
+	}
+	if request, err = http.NewRequestWithContext(
+		ctx, http.MethodPost, discovery.TokenEndpoint,
+		strings.NewReader(form.Encode()),
+	); err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	if basicAuth {
+		request.SetBasicAuth(
+			url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+	}
+	if response, err = p.httpClient().Do(request); err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if body, err = io.ReadAll(io.LimitReader(response.Body, 1<<20)); err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		if json.Unmarshal(body, &_error) == nil && _error.Error != "" {
+			return nil, fmt.Errorf(
+				"token exchange failed: %s %s", _error.Error, _error.Description)
+		}
+		return nil, fmt.Errorf("token exchange failed with status %d", response.StatusCode)
+	}
+	if err = json.Unmarshal(body, &_token); err != nil {
+		return nil, err
+	}
+	if _token.IDToken == "" {
+		return nil, errors.New("no id token in the token response")
+	}
+
+	return &_token, nil
+}
+
+// The default client authentication is client_secret_basic,
+// unless the provider only supports the others.
+func (p *Provider) supportsBasicAuth(discovery *Discovery) (supported bool) {
+	if len(discovery.TokenEndpointAuth) == 0 {
+		return true
+	}
+	for _, method := range discovery.TokenEndpointAuth {
+		if method == "client_secret_basic" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Provider) getJSON(
+	ctx context.Context,
+	endpoint string,
+	obj interface{},
+) (err error) {
+	var (
+		request  *http.Request
+		response *http.Response
+	)
+
+	if request, err = http.NewRequestWithContext(
+		ctx, http.MethodGet, endpoint, nil,
+	); err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+	if response, err = p.httpClient().Do(request); err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s failed with status %d", endpoint, response.StatusCode)
+	}
+
+	return json.NewDecoder(io.LimitReader(response.Body, 1<<20)).Decode(obj)
+}
@@ -0,0 +1,297 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	mockClientID     = "client"
+	mockClientSecret = "secret"
+	mockKeyID        = "key-1"
+	mockCode         = "code"
+	mockNonce        = "nonce"
+)
+
+// Mock OpenID provider serving the discovery, the keys & the token endpoint.
+type mockProvider struct {
+	t         *testing.T
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	challenge string
+	idToken   string
+	requests  map[string]int
+}
+
+func newMockProvider(t *testing.T) (mock *mockProvider) {
+	var err error
+
+	mock = &mockProvider{t: t, requests: map[string]int{}}
+	if mock.key, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		t.Fatal(err)
+	}
+	mock.server = httptest.NewServer(http.HandlerFunc(mock.serveHTTP))
+	t.Cleanup(mock.server.Close)
+
+	return mock
+}
+
+func (m *mockProvider) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	m.requests[r.URL.Path]++
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/.well-known/openid-configuration":
+		json.NewEncoder(w).Encode(Discovery{
+			Issuer:                m.server.URL,
+			AuthorizationEndpoint: m.server.URL + "/authorize",
+			TokenEndpoint:         m.server.URL + "/token",
+			JWKSURI:               m.server.URL + "/keys"})
+	case "/keys":
+		json.NewEncoder(w).Encode(jsonWebKeySet{Keys: []jsonWebKey{{
+			KeyType: "RSA",
+			KeyID:   mockKeyID,
+			Use:     "sig",
+			N:       base64.RawURLEncoding.EncodeToString(m.key.N.Bytes()),
+			E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(m.key.E)).Bytes())}}})
+	case "/token":
+		clientID, clientSecret, _ := r.BasicAuth()
+		switch {
+		case clientID != mockClientID || clientSecret != mockClientSecret:
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(tokenError{Error: "invalid_client"})
+		case r.PostFormValue("code") != mockCode ||
+			CodeChallenge(r.PostFormValue("code_verifier")) != m.challenge:
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(tokenError{Error: "invalid_grant"})
+		default:
+			json.NewEncoder(w).Encode(Token{
+				AccessToken: "access",
+				TokenType:   "Bearer",
+				IDToken:     m.idToken})
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (m *mockProvider) provider() (provider *Provider) {
+	return &Provider{
+		Name:         "mock",
+		Issuer:       m.server.URL,
+		ClientID:     mockClientID,
+		ClientSecret: mockClientSecret,
+		RedirectURL:  "https://blog.test/callback",
+		HTTPClient:   m.server.Client()}
+}
+
+func (m *mockProvider) claims() (claims jwt.MapClaims) {
+	var now = time.Now()
+
+	return jwt.MapClaims{
+		"iss":   m.server.URL,
+		"sub":   "subject",
+		"aud":   mockClientID,
+		"exp":   now.Add(time.Hour).Unix(),
+		"iat":   now.Unix(),
+		"nonce": mockNonce,
+		"email": "user@blog.test"}
+}
+
+func (m *mockProvider) sign(claims jwt.MapClaims, keyID string) (rawIDToken string) {
+	var (
+		token = jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		err   error
+	)
+
+	token.Header["kid"] = keyID
+	if rawIDToken, err = token.SignedString(m.key); err != nil {
+		m.t.Fatal(err)
+	}
+
+	return rawIDToken
+}
+
+func TestProviderSignIn(t *testing.T) {
+	var (
+		ctx          = context.Background()
+		mock         = newMockProvider(t)
+		provider     = mock.provider()
+		codeVerifier string
+		authURL      string
+		parsedURL    *url.URL
+		token        *Token
+		claims       *IDTokenClaims
+		err          error
+	)
+
+	if codeVerifier, err = NewCodeVerifier(); err != nil {
+		t.Fatal(err)
+	}
+	if authURL, err = provider.AuthCodeURL(ctx, "state", mockNonce, codeVerifier); err != nil {
+		t.Fatal(err)
+	}
+	if parsedURL, err = url.Parse(authURL); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(authURL, mock.server.URL+"/authorize?") {
+		t.Errorf("AuthCodeURL = %q, not the discovered endpoint", authURL)
+	}
+	query := parsedURL.Query()
+	if query.Get("code_challenge_method") != "S256" || query.Get("nonce") != mockNonce ||
+		query.Get("state") != "state" || query.Get("client_id") != mockClientID {
+		t.Errorf("AuthCodeURL query = %v", query)
+	}
+	mock.challenge = query.Get("code_challenge")
+	mock.idToken = mock.sign(mock.claims(), mockKeyID)
+
+	if _, err = provider.Exchange(ctx, mockCode, "wrong-verifier"); err == nil {
+		t.Error("Exchange with a wrong code verifier succeeded")
+	}
+	if token, err = provider.Exchange(ctx, mockCode, codeVerifier); err != nil {
+		t.Fatal(err)
+	}
+	if claims, err = provider.VerifyIDToken(ctx, token.IDToken, mockNonce); err != nil {
+		t.Fatal(err)
+	}
+	if claims.Subject != "subject" || claims.Email != "user@blog.test" {
+		t.Errorf("claims = %+v", claims)
+	}
+	if _, err = provider.VerifyIDToken(ctx, token.IDToken, mockNonce); err != nil {
+		t.Fatal(err)
+	}
+	if mock.requests["/.well-known/openid-configuration"] != 1 || mock.requests["/keys"] != 1 {
+		t.Errorf("the provider's metadata & keys are refetched: %v", mock.requests)
+	}
+}
+
+func TestProviderVerifyIDToken(t *testing.T) {
+	var tests = []struct {
+		name   string
+		modify func(claims jwt.MapClaims)
+		keyID  string
+		nonce  string
+		err    string
+	}{{
+		name:  "wrong nonce",
+		keyID: mockKeyID,
+		nonce: "other",
+		err:   "nonce mismatch",
+	}, {
+		name:   "wrong audience",
+		modify: func(claims jwt.MapClaims) { claims["aud"] = "other" },
+		keyID:  mockKeyID,
+		nonce:  mockNonce,
+		err:    "not issued for this client",
+	}, {
+		name: "unauthorized party",
+		modify: func(claims jwt.MapClaims) {
+			claims["aud"] = []string{mockClientID, "other"}
+			claims["azp"] = "other"
+		},
+		keyID: mockKeyID,
+		nonce: mockNonce,
+		err:   "not authorized for this client",
+	}, {
+		name:   "wrong issuer",
+		modify: func(claims jwt.MapClaims) { claims["iss"] = "https://evil.test" },
+		keyID:  mockKeyID,
+		nonce:  mockNonce,
+		err:    "issuer mismatch",
+	}, {
+		name: "expired",
+		modify: func(claims jwt.MapClaims) {
+			claims["exp"] = time.Now().Add(-clockSkew - time.Minute).Unix()
+		},
+		keyID: mockKeyID,
+		nonce: mockNonce,
+		err:   "expired",
+	}, {
+		name: "issued in the future",
+		modify: func(claims jwt.MapClaims) {
+			claims["iat"] = time.Now().Add(clockSkew + time.Minute).Unix()
+		},
+		keyID: mockKeyID,
+		nonce: mockNonce,
+		err:   "issued in the future",
+	}, {
+		name:   "no subject",
+		modify: func(claims jwt.MapClaims) { delete(claims, "sub") },
+		keyID:  mockKeyID,
+		nonce:  mockNonce,
+		err:    "no subject",
+	}, {
+		name:  "unknown key id",
+		keyID: "key-2",
+		nonce: mockNonce,
+		err:   "unknown signing key id",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				mock   = newMockProvider(t)
+				claims = mock.claims()
+			)
+
+			if test.modify != nil {
+				test.modify(claims)
+			}
+			_, err := mock.provider().VerifyIDToken(
+				context.Background(), mock.sign(claims, test.keyID), test.nonce)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("VerifyIDToken error = %v, want %q", err, test.err)
+			}
+		})
+	}
+}
+
+func TestProviderVerifyIDTokenSignature(t *testing.T) {
+	var (
+		mock     = newMockProvider(t)
+		provider = mock.provider()
+		other    *rsa.PrivateKey
+		err      error
+	)
+
+	if other, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		t.Fatal(err)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, mock.claims())
+	token.Header["kid"] = mockKeyID
+	rawIDToken, err := token.SignedString(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = provider.VerifyIDToken(context.Background(), rawIDToken, mockNonce); err == nil {
+		t.Error("VerifyIDToken with a foreign signature succeeded")
+	}
+	token = jwt.NewWithClaims(jwt.SigningMethodHS256, mock.claims())
+	token.Header["kid"] = mockKeyID
+	if rawIDToken, err = token.SignedString([]byte(mockClientSecret)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = provider.VerifyIDToken(context.Background(), rawIDToken, mockNonce); err == nil {
+		t.Error("VerifyIDToken with a symmetric signature succeeded")
+	}
+}
+
+func TestProviderDiscoverIssuerMismatch(t *testing.T) {
+	var provider = newMockProvider(t).provider()
+
+	provider.Issuer += "/"
+	if _, err := provider.Discover(context.Background()); err == nil {
+		t.Error("Discover with a mismatched issuer succeeded")
+	}
+}
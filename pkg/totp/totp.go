@@ -0,0 +1,112 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/misterabdul/goblog-server/pkg/crypto"
+)
+
+/**
+ * Time-based one-time password as described in RFC 6238,
+ * using the defaults supported by common authenticator apps:
+ * HMAC-SHA1, 6 digits & 30 seconds period.
+ */
+
+const (
+	Digits = 6
+	Period = 30
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Generate new random base32 encoded secret.
+func GenerateSecret() (secret string, err error) {
+	var secretBytes []byte
+
+	if secretBytes, err = crypto.GenerateRandomBytes(20); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(secretBytes), nil
+}
+
+// Get the time step of given time.
+func Step(t time.Time) (step int64) {
+	return t.Unix() / Period
+}
+
+// Generate the code of given secret at given time step.
+func Code(secret string, step int64) (code string, err error) {
+	var (
+		key     []byte
+		counter = make([]byte, 8)
+		mac     = []byte{}
+		offset  byte
+		value   uint32
+	)
+
+	if key, err = encoding.DecodeString(strings.ToUpper(secret)); err != nil {
+		return "", err
+	}
+	binary.BigEndian.PutUint64(counter, uint64(step))
+	hasher := hmac.New(sha1.New, key)
+	hasher.Write(counter)
+	mac = hasher.Sum(mac)
+	offset = mac[len(mac)-1] & 0x0f
+	value = binary.BigEndian.Uint32(mac[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", Digits, value%1000000), nil
+}
+
+// Validate the given code against the secret at given time,
+// allowing the given number of time steps of clock skew.
+//
+// Return the matched time step so the caller can reject reused codes.
+func Validate(
+	code string,
+	secret string,
+	t time.Time,
+	skew int64,
+) (matchedStep int64, valid bool) {
+	var (
+		current  = Step(t)
+		expected string
+		err      error
+	)
+
+	for step := current - skew; step <= current+skew; step++ {
+		if expected, err = Code(secret, step); err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// Create the otpauth URI for provisioning the secret into authenticator apps.
+func URI(issuer string, account string, secret string) (uri string) {
+	var query = url.Values{}
+
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprintf("%d", Digits))
+	query.Set("period", fmt.Sprintf("%d", Period))
+
+	return (&url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode()}).String()
+}
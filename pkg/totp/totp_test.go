@@ -0,0 +1,119 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+// Base32 of the RFC 6238 SHA-1 test secret "12345678901234567890".
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	// RFC 6238 Appendix B's SHA-1 vectors, truncated to 6 digits.
+	var tests = []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, test := range tests {
+		code, err := Code(rfcSecret, Step(time.Unix(test.unix, 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != test.code {
+			t.Errorf("Code at %d = %s, want %s", test.unix, code, test.code)
+		}
+	}
+}
+
+func TestCodeLowercaseSecret(t *testing.T) {
+	var step = Step(time.Unix(59, 0))
+
+	lower, err := Code("gezdgnbvgy3tqojqgezdgnbvgy3tqojq", step)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upper, _ := Code(rfcSecret, step); lower != upper {
+		t.Errorf("Code of the lowercase secret = %s, want %s", lower, upper)
+	}
+	if _, err = Code("not base32!", step); err == nil {
+		t.Error("Code of an invalid secret succeeded")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var (
+		now     = time.Unix(1111111111, 0)
+		current = Step(now)
+	)
+	previous, _ := Code(rfcSecret, current-1)
+	next, _ := Code(rfcSecret, current+1)
+	later, _ := Code(rfcSecret, current+2)
+
+	var tests = []struct {
+		name  string
+		code  string
+		skew  int64
+		step  int64
+		valid bool
+	}{
+		{"current", "050471", 0, current, true},
+		{"previous within skew", previous, 1, current - 1, true},
+		{"next within skew", next, 1, current + 1, true},
+		{"previous without skew", previous, 0, 0, false},
+		{"beyond skew", later, 1, 0, false},
+		{"wrong code", "000000", 1, 0, false},
+		{"wrong length", "50471", 1, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			step, valid := Validate(test.code, rfcSecret, now, test.skew)
+			if valid != test.valid || step != test.step {
+				t.Errorf("Validate = (%d, %t), want (%d, %t)", step, valid, test.step, test.valid)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(secret) != 32 {
+		t.Errorf("len(secret) = %d, want 32", len(secret))
+	}
+	if _, err = Code(secret, 0); err != nil {
+		t.Errorf("Code of the generated secret: %v", err)
+	}
+	if other, _ := GenerateSecret(); other == secret {
+		t.Error("GenerateSecret repeated the secret")
+	}
+}
+
+func TestURI(t *testing.T) {
+	var uri = URI("GoBlog", "user@example.com", rfcSecret)
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := parsed.Query()
+	if parsed.Scheme != "otpauth" || parsed.Host != "totp" ||
+		parsed.Path != "/GoBlog:user@example.com" {
+		t.Errorf("URI = %q", uri)
+	}
+	if query.Get("secret") != rfcSecret || query.Get("issuer") != "GoBlog" ||
+		query.Get("digits") != "6" || query.Get("period") != "30" {
+		t.Errorf("URI query = %v", query)
+	}
+}